@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"gowget/internal/crawler"
+	"gowget/internal/downloader"
 )
 
 func main() {
@@ -14,6 +15,12 @@ func main() {
 	concurrencyFlag := flag.Int("concurrency", 5, "Max concurrent downloads")
 	outputFlag := flag.String("output", "downloaded_site", "Output directory")
 	timeoutFlag := flag.Duration("timeout", 10*time.Second, "Request timeout")
+	noRobotsFlag := flag.Bool("no-robots", false, "Ignore robots.txt rules and Crawl-delay")
+	triesFlag := flag.Int("tries", downloader.DefaultRetryPolicy.MaxAttempts, "Max attempts per URL on timeouts, connection resets, 429 and 5xx (1 disables retries)")
+	retryDelayFlag := flag.Duration("retry-delay", downloader.DefaultRetryPolicy.BaseDelay, "Initial delay between retries, doubled on each attempt")
+	retryMaxDelayFlag := flag.Duration("retry-max-delay", downloader.DefaultRetryPolicy.MaxDelay, "Max delay between retries; a longer Retry-After gives up on the URL")
+	perHostFlag := flag.Int("per-host", 2, "Max concurrent requests to one host (0 means no limit)")
+	rateFlag := flag.Float64("rate", 0, "Max requests per second to one host (0 means no limit)")
 
 	flag.Parse()
 
@@ -26,6 +33,14 @@ func main() {
 		MaxConcurrency: *concurrencyFlag,
 		Timeout:        *timeoutFlag,
 		OutputDir:      *outputFlag,
+		IgnoreRobots:   *noRobotsFlag,
+		PerHost:        *perHostFlag,
+		Rate:           *rateFlag,
+		Retry: downloader.RetryPolicy{
+			MaxAttempts: *triesFlag,
+			BaseDelay:   *retryDelayFlag,
+			MaxDelay:    *retryMaxDelayFlag,
+		},
 	}
 
 	c := crawler.New(cfg)
@@ -37,5 +52,6 @@ func main() {
 		log.Fatalf("Crawl failed: %v", err)
 	}
 
-	log.Printf("Completed in %v", time.Since(startTime))
+	st := c.Stats()
+	log.Printf("Completed in %v: %d saved, %d failed, %d retries", time.Since(startTime), st.Saved, st.Failed, st.Retries)
 }
\ No newline at end of file
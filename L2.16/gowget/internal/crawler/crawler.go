@@ -6,8 +6,10 @@ import (
 	"io"
 	"log"
 	"net/url"
+	"path"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"gowget/internal/downloader"
@@ -20,6 +22,17 @@ type Config struct {
 	MaxConcurrency int
 	Timeout        time.Duration
 	OutputDir      string
+	IgnoreRobots   bool // не читать robots.txt и не выдерживать Crawl-delay
+	Retry          downloader.RetryPolicy // повторы при таймаутах, обрывах, 429 и 5xx
+	PerHost        int     // одновременных запросов к одному хосту, 0 — без ограничения
+	Rate           float64 // запросов в секунду к одному хосту, 0 — без ограничения
+}
+
+// Stats — итоги обхода.
+type Stats struct {
+	Saved   int64 // сохранено файлов
+	Failed  int64 // не удалось скачать или сохранить
+	Retries int64 // повторных запросов после временных сбоев, в том числе обрывов при чтении тела
 }
 
 type Crawler struct {
@@ -28,19 +41,23 @@ type Crawler struct {
 	fs         *storage.FileSystem
 	parser     *parser.Processor
 	visited    sync.Map // Thread-safe map для посещенных URL
+	hosts      sync.Map // scheme://host -> *hostPolicy
 	sem        chan struct{} // Семафор для ограничения горутин
 	wg         sync.WaitGroup
+	saved      atomic.Int64
+	failed     atomic.Int64
 }
 
 func New(cfg Config) *Crawler {
 	fs := storage.New(cfg.OutputDir)
-	return &Crawler{
+	c := &Crawler{
 		cfg:     cfg,
-		client:  downloader.New(cfg.Timeout),
+		client:  downloader.New(cfg.Timeout, cfg.Retry, pool(cfg)),
 		fs:      fs,
-		parser:  parser.New(fs),
 		sem:     make(chan struct{}, cfg.MaxConcurrency),
 	}
+	c.parser = parser.New(fs, c.allowed)
+	return c
 }
 
 func (c *Crawler) Start(startURL string) error {
@@ -55,6 +72,28 @@ func (c *Crawler) Start(startURL string) error {
 	return nil
 }
 
+// pool подбирает пул соединений под параллельность обхода.
+func pool(cfg Config) downloader.Pool {
+	if cfg.PerHost > 0 {
+		return downloader.Pool{MaxConnsPerHost: cfg.PerHost, MaxIdleConnsPerHost: cfg.PerHost}
+	}
+	return downloader.Pool{MaxIdleConnsPerHost: cfg.MaxConcurrency}
+}
+
+// Stats возвращает итоги обхода; вызывать после Start.
+func (c *Crawler) Stats() Stats {
+	return Stats{
+		Saved:   c.saved.Load(),
+		Failed:  c.failed.Load(),
+		Retries: c.client.Retries(),
+	}
+}
+
+// allowed — разрешает ли robots.txt хоста скачивать u.
+func (c *Crawler) allowed(u *url.URL) bool {
+	return c.policy(u).rules.Allowed(u.RequestURI())
+}
+
 func (c *Crawler) visit(u *url.URL, depth int) {
 	defer c.wg.Done()
 
@@ -70,55 +109,120 @@ func (c *Crawler) visit(u *url.URL, depth int) {
 		return
 	}
 
-	// Занимаем слот в семафоре
-	c.sem <- struct{}{}
-	defer func() { <-c.sem }()
+	p := c.policy(u)
+	if !p.rules.Allowed(u.RequestURI()) {
+		log.Printf("[SKIPPED] %s: disallowed by robots.txt", uStr)
+		return
+	}
+
+	// Сначала слот хоста, потом общий: иначе запросы к одному занятому хосту
+	// держали бы общие слоты, и остальные хосты простаивали бы. Общий слот
+	// занимается перед каждой попыткой, уже после паузы хоста (см. sharedSlot).
+	p.acquire()
+	defer p.release()
+
+	slot := &sharedSlot{sem: c.sem}
+	defer slot.release()
 
 	log.Printf("[DOWNLOADING] %s", uStr)
 
-	resp, err := c.client.Fetch(uStr)
+	newLinks, isCSS, err := c.download(u, uStr, func() { slot.acquireAfter(p) })
 	if err != nil {
-		log.Printf("[ERROR] fetching %s: %v", uStr, err)
+		c.failed.Add(1)
 		return
 	}
-	defer resp.Body.Close()
 
+	// Рекурсивно обходим ссылки
+	for _, link := range newLinks {
+		// Ресурсы (картинки, css) качаем всегда, страницы - только если позволяет глубина
+		// Здесь простая логика: увеличиваем глубину только для переходов по ссылкам <a>
+		// Для ресурсов depth можно не увеличивать или обрабатывать отдельно.
+		// В этой реализации depth увеличивается для всех.
+		// Исключение — ресурсы из CSS: фон и шрифты нужны той же странице, что и сама таблица стилей.
+		if isCSS {
+			c.wg.Add(1)
+			go c.visit(link, depth)
+		} else if depth+1 <= c.cfg.MaxDepth {
+			c.wg.Add(1)
+			go c.visit(link, depth+1)
+		}
+	}
+}
+
+// download скачивает и сохраняет u и возвращает найденные в нём ссылки.
+// Если соединение рвётся или истекает таймаут уже во время чтения тела, загрузка
+// повторяется по RetryPolicy и продолжается с места обрыва, когда .part это позволяет.
+// wait вызывается перед каждым запросом.
+func (c *Crawler) download(u *url.URL, uStr string, wait func()) ([]*url.URL, bool, error) {
+	var attempts int // на URL всего, включая повторы внутри FetchFrom
+	for {
+		resp, err := c.fetch(u, uStr, wait, &attempts)
+		if err != nil {
+			log.Printf("[ERROR] fetching %s: %v", uStr, err)
+			return nil, false, err
+		}
+
+		localPath, links, isCSS, err := c.save(u, resp)
+		resp.Body.Close()
+		if err == nil {
+			if attempts > 1 {
+				log.Printf("[SAVED] %s -> %s (after %d attempts)", uStr, localPath, attempts)
+			} else {
+				log.Printf("[SAVED] %s -> %s", uStr, localPath)
+			}
+			c.saved.Add(1)
+			return links, isCSS, nil
+		}
+		if !c.client.Backoff(uStr, attempts, err) {
+			log.Printf("[ERROR] saving file %s: %v", uStr, err)
+			return nil, false, err
+		}
+	}
+}
+
+// save разбирает ответ, если это HTML или CSS, и сохраняет его на диск.
+func (c *Crawler) save(u *url.URL, resp *downloader.Response) (string, []*url.URL, bool, error) {
 	// Определяем тип контента
 	contentType := resp.ContentType
 	isHTML := strings.Contains(contentType, "text/html")
+	isCSS := !isHTML && isStylesheet(u, contentType)
 
 	var reader io.Reader = resp.Body
 	var newLinks []*url.URL
 
-	// Если HTML, нужно парсить и менять ссылки
+	// Если HTML или CSS, нужно парсить и менять ссылки
 	if isHTML {
+		// html.Parse ошибается, только если не дочитал тело: сохранять тут уже нечего.
 		res, err := c.parser.Process(u, resp.Body)
 		if err != nil {
-			log.Printf("[ERROR] parsing HTML %s: %v", uStr, err)
-			// Если ошибка парсинга, пробуем сохранить как есть
-		} else {
-			reader = bytes.NewReader(res.ModifiedHTML)
-			newLinks = res.Links
+			return "", nil, false, fmt.Errorf("reading HTML: %w", err)
+		}
+		reader = bytes.NewReader(res.Content)
+		newLinks = res.Links
+	} else if isCSS {
+		res, err := c.parser.ProcessCSS(u, resp.Body)
+		if err != nil {
+			return "", nil, false, fmt.Errorf("reading CSS: %w", err)
 		}
+		reader = bytes.NewReader(res.Content)
+		newLinks = res.Links
 	}
 
-	// Сохраняем файл
-	localPath, err := c.fs.Save(u, reader, isHTML)
-	if err != nil {
-		log.Printf("[ERROR] saving file %s: %v", uStr, err)
+	// Сохраняем файл; остальное можно будет докачать, если загрузка прервётся
+	var localPath string
+	var err error
+	if isHTML || isCSS {
+		localPath, err = c.fs.Save(u, reader, isHTML)
 	} else {
-		log.Printf("[SAVED] %s -> %s", uStr, localPath)
+		localPath, err = c.fs.SaveFrom(u, resp.Body, resp.Offset, resp.Validator())
 	}
+	return localPath, newLinks, isCSS, err
+}
 
-	// Рекурсивно обходим ссылки
-	for _, link := range newLinks {
-		// Ресурсы (картинки, css) качаем всегда, страницы - только если позволяет глубина
-		// Здесь простая логика: увеличиваем глубину только для переходов по ссылкам <a>
-		// Для ресурсов depth можно не увеличивать или обрабатывать отдельно.
-		// В этой реализации depth увеличивается для всех.
-		if depth+1 <= c.cfg.MaxDepth {
-			c.wg.Add(1)
-			go c.visit(link, depth+1)
-		}
+// isStylesheet — CSS ли это: по Content-Type, а если сервер его не знает — по расширению.
+func isStylesheet(u *url.URL, contentType string) bool {
+	if strings.Contains(contentType, "text/css") {
+		return true
 	}
+	return path.Ext(u.Path) == ".css" && (contentType == "" || strings.HasPrefix(contentType, "text/plain") || strings.HasPrefix(contentType, "application/octet-stream"))
 }
\ No newline at end of file
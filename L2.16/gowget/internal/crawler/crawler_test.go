@@ -0,0 +1,213 @@
+package crawler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gowget/internal/downloader"
+)
+
+// site — тестовый сайт: страница со ссылками, закрытый раздел и robots.txt.
+type site struct {
+	*httptest.Server
+	robots string
+	status int // код ответа на /robots.txt, 0 — 200
+	fails  int // столько первых запросов robots.txt получат 503
+
+	mu       sync.Mutex
+	requests []string
+	times    []time.Time
+}
+
+func newSite(t *testing.T, robots string) *site {
+	s := &site{robots: robots}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		status := s.status
+		if s.fails > 0 {
+			s.fails--
+			status = http.StatusServiceUnavailable
+		}
+		s.mu.Unlock()
+		if status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(s.robots))
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<html><body><a href="/a.html">A</a><a href="/private/secret.html">S</a><img src="/logo.png"></body></html>`))
+	})
+	mux.HandleFunc("/logo.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("png"))
+	})
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r.URL.Path)
+		if r.URL.Path != "/robots.txt" {
+			s.times = append(s.times, time.Now())
+		}
+		s.mu.Unlock()
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *site) requested(path string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Contains(s.requests, path)
+}
+
+func crawl(t *testing.T, s *site, cfg Config) string {
+	t.Helper()
+	cfg.OutputDir = t.TempDir()
+	cfg.MaxDepth = 1
+	cfg.MaxConcurrency = 4
+	cfg.Timeout = 5 * time.Second
+	if err := New(cfg).Start(s.URL + "/"); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	return filepath.Join(cfg.OutputDir, "127.0.0.1")
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func TestCrawler_HonoursRobots(t *testing.T) {
+	s := newSite(t, "User-agent: *\nDisallow: /\n\nUser-agent: GoWget\nDisallow: /private/\nCrawl-delay: 0.1\n")
+	root := crawl(t, s, Config{})
+
+	for _, f := range []string{"index.html", "a.html", "logo.png"} {
+		if !exists(filepath.Join(root, f)) {
+			t.Errorf("%s was not saved", f)
+		}
+	}
+	if s.requested("/private/secret.html") || exists(filepath.Join(root, "private")) {
+		t.Error("disallowed page was fetched")
+	}
+	// Закрытая страница не скачана, поэтому ссылка на неё ведёт на сервер.
+	index, _ := os.ReadFile(filepath.Join(root, "index.html"))
+	if !strings.Contains(string(index), `href="`+s.URL+`/private/secret.html"`) || !strings.Contains(string(index), `href="a.html"`) {
+		t.Errorf("index.html links:\n%s", index)
+	}
+
+	// Три страницы с паузой не меньше Crawl-delay между соседними запросами.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.times) != 3 {
+		t.Fatalf("requests = %v", s.requests)
+	}
+	slices.SortFunc(s.times, func(a, b time.Time) int { return a.Compare(b) })
+	for i := 1; i < len(s.times); i++ {
+		if gap := s.times[i].Sub(s.times[i-1]); gap < 90*time.Millisecond {
+			t.Errorf("requests %d and %d are %v apart, want at least the crawl delay", i-1, i, gap)
+		}
+	}
+}
+
+func TestCrawler_NoRobots(t *testing.T) {
+	s := newSite(t, "User-agent: *\nDisallow: /\n")
+	root := crawl(t, s, Config{IgnoreRobots: true})
+
+	if !exists(filepath.Join(root, "private", "secret.html")) {
+		t.Error("--no-robots must fetch disallowed pages")
+	}
+	if s.requested("/robots.txt") {
+		t.Error("robots.txt must not be fetched with --no-robots")
+	}
+}
+
+func TestCrawler_RobotsStatus(t *testing.T) {
+	// Нет robots.txt — можно всё.
+	s := newSite(t, "")
+	s.status = http.StatusNotFound
+	root := crawl(t, s, Config{})
+	if !exists(filepath.Join(root, "private", "secret.html")) {
+		t.Error("missing robots.txt must allow everything")
+	}
+
+	// Сервер сломан — не скачиваем ничего.
+	s = newSite(t, "")
+	s.status = http.StatusServiceUnavailable
+	crawl(t, s, Config{})
+	if s.requested("/") {
+		t.Error("unavailable robots.txt must disallow the host")
+	}
+}
+
+func TestCrawler_RetriesAndStats(t *testing.T) {
+	// robots.txt дважды отвечает 503 — повторы должны дождаться нормального ответа.
+	s := newSite(t, "User-agent: *\nDisallow: /private/\n")
+	s.fails = 2
+
+	cfg := Config{
+		MaxDepth:       1,
+		MaxConcurrency: 4,
+		Timeout:        5 * time.Second,
+		OutputDir:      t.TempDir(),
+		Retry:          downloader.RetryPolicy{MaxAttempts: 3, BaseDelay: 10 * time.Millisecond},
+	}
+	c := New(cfg)
+	if err := c.Start(s.URL + "/"); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	st := c.Stats()
+	if st != (Stats{Saved: 3, Retries: 2}) {
+		t.Errorf("stats = %+v, want 3 saved files and 2 retries", st)
+	}
+}
+
+func TestCrawler_Stylesheets(t *testing.T) {
+	files := map[string]struct{ typ, body string }{
+		"/":              {"text/html", `<html><head><link rel="stylesheet" href="/css/site.css"></head><body></body></html>`},
+		"/css/site.css":  {"text/css", `@import "print.css"; body { background: url(/img/bg.png) }`},
+		"/css/print.css": {"text/css", `@font-face { src: url(../fonts/a.woff2) }`},
+		"/img/bg.png":    {"image/png", "png"},
+		"/fonts/a.woff2": {"font/woff2", "woff"},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", f.typ)
+		w.Write([]byte(f.body))
+	}))
+	defer srv.Close()
+
+	cfg := Config{MaxDepth: 1, MaxConcurrency: 4, Timeout: 5 * time.Second, OutputDir: t.TempDir()}
+	if err := New(cfg).Start(srv.URL + "/"); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	root := filepath.Join(cfg.OutputDir, "127.0.0.1")
+
+	// Ресурсы из CSS качаются на той же глубине, что и сама таблица стилей.
+	for _, f := range []string{"css/print.css", "img/bg.png", "fonts/a.woff2"} {
+		if !exists(filepath.Join(root, f)) {
+			t.Errorf("%s was not saved", f)
+		}
+	}
+	css, err := os.ReadFile(filepath.Join(root, "css", "site.css"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `@import "print.css"; body { background: url(../img/bg.png) }`; string(css) != want {
+		t.Errorf("site.css = %q, want %q", css, want)
+	}
+}
@@ -0,0 +1,101 @@
+package crawler
+
+import (
+	"net/url"
+	"sync"
+	"time"
+
+	"gowget/internal/robots"
+)
+
+// hostPolicy — всё, что краулер знает об одном хосте: правила robots.txt,
+// сколько запросов к нему можно держать одновременно и когда можно следующий.
+type hostPolicy struct {
+	once  sync.Once
+	rules *robots.Rules
+
+	slots chan struct{} // семафор на хост; nil — без ограничения
+
+	mu       sync.Mutex
+	interval time.Duration // минимальный промежуток между запросами: Crawl-delay или 1/Rate
+	next     time.Time     // раньше этого момента следующий запрос к хосту не делаем
+}
+
+// policy возвращает правила для хоста u; robots.txt скачивается один раз на хост.
+func (c *Crawler) policy(u *url.URL) *hostPolicy {
+	key := u.Scheme + "://" + u.Host
+	v, ok := c.hosts.Load(key)
+	if !ok {
+		p := &hostPolicy{}
+		if c.cfg.PerHost > 0 {
+			p.slots = make(chan struct{}, c.cfg.PerHost)
+		}
+		v, _ = c.hosts.LoadOrStore(key, p)
+	}
+	p := v.(*hostPolicy)
+	p.once.Do(func() {
+		p.rules = robots.AllowAll()
+		if !c.cfg.IgnoreRobots {
+			p.rules = c.fetchRobots(key)
+		}
+		p.interval = p.rules.CrawlDelay()
+		if c.cfg.Rate > 0 {
+			p.interval = max(p.interval, time.Duration(float64(time.Second)/c.cfg.Rate))
+		}
+	})
+	return p
+}
+
+// acquire занимает слот хоста; отпускать — release.
+func (p *hostPolicy) acquire() {
+	if p.slots != nil {
+		p.slots <- struct{}{}
+	}
+}
+
+func (p *hostPolicy) release() {
+	if p.slots != nil {
+		<-p.slots
+	}
+}
+
+// wait выдерживает интервал между запросами: к хосту уходит не больше одного
+// запроса за interval, даже если их ждут несколько горутин.
+func (p *hostPolicy) wait() {
+	if p.interval <= 0 {
+		return
+	}
+	p.mu.Lock()
+	now := time.Now()
+	at := p.next
+	if at.Before(now) {
+		at = now
+	}
+	p.next = at.Add(p.interval)
+	p.mu.Unlock()
+
+	time.Sleep(at.Sub(now))
+}
+
+// sharedSlot — общий слот загрузки (семафор Crawler), который держит одна загрузка URL.
+// Паузу хоста загрузка выдерживает, отпустив слот: иначе хост с большим Crawl-delay
+// занял бы все общие слоты, пока его запросы ждут своей очереди.
+type sharedSlot struct {
+	sem  chan struct{}
+	held bool
+}
+
+// acquireAfter отпускает слот, выдерживает интервал хоста p и занимает слот снова.
+func (s *sharedSlot) acquireAfter(p *hostPolicy) {
+	s.release()
+	p.wait()
+	s.sem <- struct{}{}
+	s.held = true
+}
+
+func (s *sharedSlot) release() {
+	if s.held {
+		<-s.sem
+		s.held = false
+	}
+}
@@ -0,0 +1,171 @@
+package crawler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gowget/internal/downloader"
+)
+
+// busySite — страница с n картинками; каждая отдаётся 30 мс, сервер помнит,
+// сколько запросов обрабатывал одновременно и когда они пришли.
+type busySite struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+	times    []time.Time
+}
+
+func newBusySite(t *testing.T, n int) *busySite {
+	s := &busySite{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			var page strings.Builder
+			for i := range n {
+				fmt.Fprintf(&page, `<img src="/%d.png">`, i)
+			}
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte(page.String()))
+			return
+		}
+		if r.URL.Path == "/robots.txt" {
+			http.NotFound(w, r)
+			return
+		}
+
+		s.mu.Lock()
+		s.inFlight++
+		s.peak = max(s.peak, s.inFlight)
+		s.times = append(s.times, time.Now())
+		s.mu.Unlock()
+
+		time.Sleep(30 * time.Millisecond)
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("png"))
+
+		s.mu.Lock()
+		s.inFlight--
+		s.mu.Unlock()
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *busySite) crawl(t *testing.T, cfg Config) {
+	t.Helper()
+	cfg.OutputDir = t.TempDir()
+	cfg.MaxDepth = 1
+	cfg.MaxConcurrency = 8
+	cfg.Timeout = 5 * time.Second
+	if err := New(cfg).Start(s.URL + "/"); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+}
+
+func TestCrawler_PerHost(t *testing.T) {
+	s := newBusySite(t, 8)
+	s.crawl(t, Config{PerHost: 2})
+	if s.peak != 2 {
+		t.Errorf("peak concurrency = %d, want 2", s.peak)
+	}
+
+	// Без ограничения на хост упираемся только в общий MaxConcurrency.
+	s = newBusySite(t, 8)
+	s.crawl(t, Config{})
+	if s.peak <= 2 {
+		t.Errorf("peak concurrency = %d without a per-host limit", s.peak)
+	}
+}
+
+func TestCrawler_Rate(t *testing.T) {
+	s := newBusySite(t, 4)
+	s.crawl(t, Config{Rate: 20})
+
+	if len(s.times) != 4 {
+		t.Fatalf("got %d requests, want 4", len(s.times))
+	}
+	slices.SortFunc(s.times, func(a, b time.Time) int { return a.Compare(b) })
+	for i := 1; i < len(s.times); i++ {
+		if gap := s.times[i].Sub(s.times[i-1]); gap < 35*time.Millisecond {
+			t.Errorf("requests %d and %d are %v apart, want at least 50ms at 20 rps", i-1, i, gap)
+		}
+	}
+}
+
+func TestCrawler_RateCoversRetries(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		times []time.Time
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		times = append(times, time.Now())
+		n := len(times)
+		mu.Unlock()
+		if n <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("png"))
+	}))
+	defer srv.Close()
+
+	// Пауза между повторами почти нулевая: выдерживать интервал должен сам лимит.
+	c := New(Config{
+		MaxConcurrency: 1,
+		Timeout:        5 * time.Second,
+		OutputDir:      t.TempDir(),
+		IgnoreRobots:   true,
+		Rate:           20,
+		Retry:          downloader.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond},
+	})
+	if err := c.Start(srv.URL + "/logo.png"); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	if len(times) != 3 || c.Stats().Saved != 1 {
+		t.Fatalf("got %d requests and %+v, want 3 requests and the file saved", len(times), c.Stats())
+	}
+	for i := 1; i < len(times); i++ {
+		if gap := times[i].Sub(times[i-1]); gap < 35*time.Millisecond {
+			t.Errorf("attempts %d and %d are %v apart, want at least 50ms at 20 rps", i-1, i, gap)
+		}
+	}
+}
+
+func TestSharedSlot_ReleasedDuringHostDelay(t *testing.T) {
+	sem := make(chan struct{}, 1)
+	p := &hostPolicy{interval: 300 * time.Millisecond}
+
+	// Первая попытка проходит сразу и занимает единственный общий слот.
+	slot := &sharedSlot{sem: sem}
+	slot.acquireAfter(p)
+
+	// Повтор к тому же хосту ждёт интервал — без общего слота.
+	retried := make(chan struct{})
+	go func() {
+		slot.acquireAfter(p)
+		close(retried)
+	}()
+
+	select {
+	case sem <- struct{}{}: // слот свободен, пока хост выдерживает паузу
+		<-sem
+	case <-time.After(150 * time.Millisecond):
+		t.Fatal("the shared slot is held while waiting for the host's delay")
+	}
+	<-retried
+	slot.release()
+	if len(sem) != 0 {
+		t.Errorf("slot was not released")
+	}
+}
@@ -0,0 +1,47 @@
+package crawler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"gowget/internal/downloader"
+)
+
+// fetch скачивает u, продолжая недокачанный в прошлый раз файл, если он есть.
+// Перед каждым запросом вызывается wait; перезапуски с начала файла тратят те же
+// attempts, что и повторы после сбоев.
+func (c *Crawler) fetch(u *url.URL, uStr string, wait func(), attempts *int) (*downloader.Response, error) {
+	offset, validator := c.fs.Partial(u)
+	if offset == 0 {
+		return c.client.FetchFrom(uStr, 0, "", attempts, wait)
+	}
+
+	log.Printf("[RESUMING] %s from byte %d", uStr, offset)
+	resp, err := c.client.FetchFrom(uStr, offset, validator, attempts, wait)
+
+	// 416 — на сервере файл короче нашего куска: он изменился, начинаем заново.
+	var se *downloader.StatusError
+	if errors.As(err, &se) && se.Code == http.StatusRequestedRangeNotSatisfiable {
+		c.fs.DiscardPartial(u)
+		return c.client.FetchFrom(uStr, 0, "", attempts, wait)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case strings.Contains(resp.ContentType, "text/html") || isStylesheet(u, resp.ContentType):
+		// Страницы и стили разбираются целиком и не докачиваются; кусок больше не нужен.
+		c.fs.DiscardPartial(u)
+		if resp.Offset > 0 {
+			resp.Body.Close()
+			return c.client.FetchFrom(uStr, 0, "", attempts, wait)
+		}
+	case resp.Offset == 0:
+		log.Printf("[RESTARTING] %s: the server sent the whole file (no range support or the file changed)", uStr)
+	}
+	return resp, nil
+}
@@ -0,0 +1,203 @@
+package crawler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"gowget/internal/downloader"
+)
+
+var payload = bytes.Repeat([]byte("0123456789abcdef"), 4096) // 64 KiB
+
+// fileServer отдаёт payload через http.ServeContent (Range и If-Range) и запоминает
+// заголовки Range. Если abortAfter > 0, соединение рвётся после стольких байт:
+// у первых aborts ответов, а если aborts = 0 — у всех.
+type fileServer struct {
+	etag       string
+	noRanges   bool
+	abortAfter int
+	aborts     int
+
+	mu     sync.Mutex
+	ranges []string
+}
+
+func (fsrv *fileServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fsrv.mu.Lock()
+	fsrv.ranges = append(fsrv.ranges, r.Header.Get("Range"))
+	abort := fsrv.abortAfter > 0 && (fsrv.aborts == 0 || len(fsrv.ranges) <= fsrv.aborts)
+	fsrv.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	switch {
+	case abort:
+		w.Header().Set("ETag", fsrv.etag)
+		w.Header().Set("Content-Length", fmt.Sprint(len(payload)))
+		w.Write(payload[:fsrv.abortAfter])
+		w.(http.Flusher).Flush()
+		panic(http.ErrAbortHandler)
+	case fsrv.noRanges:
+		w.Write(payload)
+	default:
+		w.Header().Set("ETag", fsrv.etag)
+		http.ServeContent(w, r, "big.bin", time.Time{}, bytes.NewReader(payload))
+	}
+}
+
+func downloadBig(t *testing.T, srv *fileServer, dir string) string {
+	t.Helper()
+	path, _ := downloadBigWith(t, srv, dir, downloader.RetryPolicy{})
+	return path
+}
+
+func downloadBigWith(t *testing.T, srv *fileServer, dir string, retry downloader.RetryPolicy) (string, Stats) {
+	t.Helper()
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+	c := New(Config{MaxConcurrency: 1, Timeout: 5 * time.Second, OutputDir: dir, IgnoreRobots: true, Retry: retry})
+	if err := c.Start(ts.URL + "/big.bin"); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	return filepath.Join(dir, "127.0.0.1", "big.bin"), c.Stats()
+}
+
+// writePart имитирует прерванную загрузку: первые n байт и валидатор.
+func writePart(t *testing.T, dir string, n int, validator string) {
+	t.Helper()
+	base := filepath.Join(dir, "127.0.0.1", "big.bin")
+	if err := os.MkdirAll(filepath.Dir(base), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(base+".part", payload[:n], 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(base+".part.validator", []byte(validator+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func checkComplete(t *testing.T, path string) {
+	t.Helper()
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("file was not saved: %v", err)
+	}
+	if !bytes.Equal(got, payload) {
+		t.Fatalf("saved %d bytes, content differs from the original %d bytes", len(got), len(payload))
+	}
+	for _, leftover := range []string{path + ".part", path + ".part.validator"} {
+		if _, err := os.Stat(leftover); err == nil {
+			t.Errorf("%s was left behind", filepath.Base(leftover))
+		}
+	}
+}
+
+func TestResume_WithRange(t *testing.T) {
+	dir := t.TempDir()
+	writePart(t, dir, 40000, `"v1"`)
+	srv := &fileServer{etag: `"v1"`}
+
+	checkComplete(t, downloadBig(t, srv, dir))
+	if len(srv.ranges) != 1 || srv.ranges[0] != "bytes=40000-" {
+		t.Errorf("Range headers = %q", srv.ranges)
+	}
+}
+
+func TestResume_FileChanged(t *testing.T) {
+	dir := t.TempDir()
+	// Кусок от старой версии файла: If-Range не совпадёт, сервер отдаст файл целиком.
+	writePart(t, dir, 40000, `"v0"`)
+	checkComplete(t, downloadBig(t, &fileServer{etag: `"v1"`}, dir))
+}
+
+func TestResume_ServerIgnoresRanges(t *testing.T) {
+	dir := t.TempDir()
+	writePart(t, dir, 40000, `"v1"`)
+	checkComplete(t, downloadBig(t, &fileServer{noRanges: true}, dir))
+}
+
+func TestResume_AfterInterruption(t *testing.T) {
+	dir := t.TempDir()
+	path := downloadBig(t, &fileServer{etag: `"v1"`, abortAfter: 20000}, dir)
+
+	if _, err := os.Stat(path); err == nil {
+		t.Fatal("an incomplete file must not be moved into place")
+	}
+	info, err := os.Stat(path + ".part")
+	if err != nil || info.Size() == 0 || info.Size() >= int64(len(payload)) {
+		t.Fatalf("expected a partial file, got %v, %v", info, err)
+	}
+
+	srv := &fileServer{etag: `"v1"`}
+	checkComplete(t, downloadBig(t, srv, dir))
+	if want := fmt.Sprintf("bytes=%d-", info.Size()); len(srv.ranges) != 1 || srv.ranges[0] != want {
+		t.Errorf("Range headers = %q, want %q", srv.ranges, want)
+	}
+}
+
+func TestResume_RetriesInterruptedBody(t *testing.T) {
+	dir := t.TempDir()
+	// Оба первых ответа рвутся на середине тела (второй — тоже с начала файла,
+	// без Range); третья попытка докачивает остаток.
+	srv := &fileServer{etag: `"v1"`, abortAfter: 20000, aborts: 2}
+	path, st := downloadBigWith(t, srv, dir, downloader.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond})
+
+	checkComplete(t, path)
+	if want := []string{"", "bytes=20000-", "bytes=20000-"}; !slices.Equal(srv.ranges, want) {
+		t.Errorf("Range headers = %q, want %q", srv.ranges, want)
+	}
+	if st.Saved != 1 || st.Failed != 0 || st.Retries != 2 {
+		t.Errorf("stats = %+v, want 1 saved and 2 retries", st)
+	}
+
+	// Попытки кончились — файл не сохранён, кусок остался для следующего запуска.
+	dir = t.TempDir()
+	srv = &fileServer{etag: `"v1"`, abortAfter: 20000}
+	path, st = downloadBigWith(t, srv, dir, downloader.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond})
+	if st.Saved != 0 || st.Failed != 1 || st.Retries != 1 || len(srv.ranges) != 2 {
+		t.Errorf("stats = %+v after %d requests, want 1 failure after 2", st, len(srv.ranges))
+	}
+	if _, err := os.Stat(path + ".part"); err != nil {
+		t.Errorf("partial file: %v", err)
+	}
+}
+
+func TestResume_AttemptLimitCoversBodyRetries(t *testing.T) {
+	// 503, обрыв тела, потом снова 503: все запросы к URL делят один бюджет попыток.
+	var (
+		mu    sync.Mutex
+		calls int
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		n := calls
+		mu.Unlock()
+		if n == 2 {
+			w.Header().Set("ETag", `"v1"`)
+			w.Header().Set("Content-Length", fmt.Sprint(len(payload)))
+			w.Write(payload[:20000])
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	c := New(Config{MaxConcurrency: 1, Timeout: 5 * time.Second, OutputDir: t.TempDir(), IgnoreRobots: true,
+		Retry: downloader.RetryPolicy{MaxAttempts: 4, BaseDelay: time.Millisecond}})
+	if err := c.Start(ts.URL + "/big.bin"); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if st := c.Stats(); calls != 4 || st.Failed != 1 || st.Retries != 3 {
+		t.Errorf("%d requests, stats %+v; want 4 requests (MaxAttempts) and 3 retries", calls, st)
+	}
+}
@@ -0,0 +1,33 @@
+package crawler
+
+import (
+	"errors"
+	"log"
+
+	"gowget/internal/downloader"
+	"gowget/internal/robots"
+)
+
+// fetchRobots скачивает и разбирает robots.txt. Как в RFC 9309: нет файла (4xx) —
+// можно всё, сервер недоступен (5xx, сетевая ошибка) — нельзя ничего.
+func (c *Crawler) fetchRobots(origin string) *robots.Rules {
+	robotsURL := origin + "/robots.txt"
+	resp, err := c.client.Fetch(robotsURL)
+	if err != nil {
+		var se *downloader.StatusError
+		if errors.As(err, &se) && se.Code < 500 {
+			return robots.AllowAll()
+		}
+		log.Printf("[ERROR] fetching %s: %v; skipping the host", robotsURL, err)
+		return robots.DisallowAll()
+	}
+	defer resp.Body.Close()
+
+	rules := robots.Parse(resp.Body, downloader.UserAgent)
+	if d := rules.CrawlDelay(); d >= robots.MaxCrawlDelay {
+		log.Printf("[ROBOTS] %s asks for a crawl delay of %v or more; capping it at %v", origin, d, robots.MaxCrawlDelay)
+	} else if d > 0 {
+		log.Printf("[ROBOTS] %s asks for a crawl delay of %v", origin, d)
+	}
+	return rules
+}
@@ -3,38 +3,150 @@ package downloader
 import (
 	"fmt"
 	"io"
+	"log"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 )
 
+// UserAgent — как мы представляемся серверам и под каким именем ищем правила в robots.txt.
+const UserAgent = "GoWget/1.0"
+
+// StatusError — сервер ответил кодом 4xx или 5xx.
+type StatusError struct {
+	Code       int
+	RetryAfter time.Duration // из заголовка Retry-After (429, 503), 0 — не указан
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("server returned error status: %d", e.Code)
+}
+
 type Client struct {
 	httpClient *http.Client
+	retry      RetryPolicy
+	retries    atomic.Int64
+	sleep      func(time.Duration) // подменяется в тестах
+}
+
+// Pool — настройки пула соединений.
+type Pool struct {
+	MaxConnsPerHost     int // 0 — без ограничения
+	MaxIdleConnsPerHost int // сколько соединений с хостом держать открытыми между запросами
 }
 
-func New(timeout time.Duration) *Client {
+func New(timeout time.Duration, retry RetryPolicy, pool Pool) *Client {
+	// По умолчанию http.Transport держит только 2 простаивающих соединения на хост:
+	// при большей параллельности остальные закрывались бы после каждого запроса.
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxConnsPerHost = pool.MaxConnsPerHost
+	if pool.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = pool.MaxIdleConnsPerHost
+	}
+
 	return &Client{
 		httpClient: &http.Client{
-			Timeout: timeout,
+			Timeout:   timeout,
+			Transport: transport,
 		},
+		retry: retry,
+		sleep: time.Sleep,
 	}
 }
 
+// Retries — сколько повторных запросов клиент сделал за всё время.
+func (c *Client) Retries() int64 {
+	return c.retries.Load()
+}
+
 // Response оборачивает ответ сервера
 type Response struct {
-	Body        io.ReadCloser
-	ContentType string
-	StatusCode  int
-	FinalURL    string // Важно для редиректов
+	Body         io.ReadCloser
+	ContentType  string
+	StatusCode   int
+	FinalURL     string // Важно для редиректов
+	Offset       int64  // с какого байта файла начинается Body: >0 только для 206 Partial Content
+	ETag         string
+	LastModified string
+	Attempts     int // сколько попыток на URL сделано к этому ответу, 1 — с первого раза
+}
+
+// Validator — значение для If-Range при докачке: сильный ETag, иначе Last-Modified.
+// Пустая строка — докачать этот ответ потом нельзя.
+func (r *Response) Validator() string {
+	if r.ETag != "" && !strings.HasPrefix(r.ETag, "W/") {
+		return r.ETag
+	}
+	return r.LastModified
 }
 
 func (c *Client) Fetch(url string) (*Response, error) {
+	var attempts int
+	return c.FetchFrom(url, 0, "", &attempts, nil)
+}
+
+// FetchFrom запрашивает файл начиная с байта offset, если он не изменился с тех пор,
+// как его начали качать (validator — ETag или Last-Modified из первого ответа).
+// Если сервер не поддерживает Range или файл изменился, придёт весь файл
+// с Offset = 0 — вызывающий должен начать запись заново.
+// Временные сбои повторяются по RetryPolicy клиента. *attempts — сколько попыток на этот
+// URL уже сделано, в том числе прежними вызовами FetchFrom (докачка, перезапуск после
+// обрыва тела); FetchFrom прибавляет к нему свои и не выходит за MaxAttempts в сумме.
+// wait, если задан, вызывается перед каждой попыткой, включая повторы: так ограничение
+// частоты запросов к хосту и Crawl-delay действуют и на них.
+func (c *Client) FetchFrom(url string, offset int64, validator string, attempts *int, wait func()) (*Response, error) {
+	if *attempts > 0 && *attempts >= c.retry.MaxAttempts {
+		return nil, fmt.Errorf("no attempts left for %s after %d", url, *attempts)
+	}
+	for {
+		*attempts++
+		if wait != nil {
+			wait()
+		}
+		resp, err := c.fetchOnce(url, offset, validator)
+		if err == nil {
+			resp.Attempts = *attempts
+			return resp, nil
+		}
+		if !c.Backoff(url, *attempts, err) {
+			if *attempts > 1 {
+				err = fmt.Errorf("giving up after %d attempts: %w", *attempts, err)
+			}
+			return nil, err
+		}
+	}
+}
+
+// Backoff решает, повторять ли загрузку url, которая заняла attempts попыток
+// и закончилась ошибкой err, — например, обрывом уже при чтении тела ответа.
+// Если повторять стоит, засчитывает повтор, выдерживает паузу по RetryPolicy
+// и возвращает true.
+func (c *Client) Backoff(url string, attempts int, err error) bool {
+	delay, ok := c.retry.delay(attempts, err)
+	if !ok {
+		return false
+	}
+	c.retries.Add(1)
+	log.Printf("[RETRY] %s: %v; attempt %d/%d in %v", url, err, attempts+1, c.retry.MaxAttempts, delay.Round(time.Millisecond))
+	c.sleep(delay)
+	return true
+}
+
+// fetchOnce — одна попытка FetchFrom.
+func (c *Client) fetchOnce(url string, offset int64, validator string) (*Response, error) {
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	
 	// Притворяемся обычным браузером
-	req.Header.Set("User-Agent", "GoWget/1.0")
+	req.Header.Set("User-Agent", UserAgent)
+	if offset > 0 && validator != "" {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		req.Header.Set("If-Range", validator)
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -43,13 +155,41 @@ func (c *Client) Fetch(url string) (*Response, error) {
 
 	if resp.StatusCode >= 400 {
 		resp.Body.Close()
-		return nil, fmt.Errorf("server returned error status: %d", resp.StatusCode)
+		return nil, &StatusError{
+			Code:       resp.StatusCode,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		}
 	}
 
-	return &Response{
-		Body:        resp.Body,
-		ContentType: resp.Header.Get("Content-Type"),
-		StatusCode:  resp.StatusCode,
-		FinalURL:    resp.Request.URL.String(),
-	}, nil
+	r := &Response{
+		Body:         resp.Body,
+		ContentType:  resp.Header.Get("Content-Type"),
+		StatusCode:   resp.StatusCode,
+		FinalURL:     resp.Request.URL.String(),
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+	if resp.StatusCode == http.StatusPartialContent {
+		start, ok := rangeStart(resp.Header.Get("Content-Range"))
+		if !ok || start != offset {
+			resp.Body.Close()
+			return nil, fmt.Errorf("unexpected Content-Range %q for offset %d", resp.Header.Get("Content-Range"), offset)
+		}
+		r.Offset = start
+	}
+	return r, nil
+}
+
+// rangeStart достаёт первый байт из «Content-Range: bytes 100-199/200».
+func rangeStart(h string) (int64, bool) {
+	spec, ok := strings.CutPrefix(h, "bytes ")
+	if !ok {
+		return 0, false
+	}
+	first, _, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(first, 10, 64)
+	return n, err == nil && n >= 0
 }
\ No newline at end of file
@@ -0,0 +1,100 @@
+package downloader
+
+import (
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy — сколько раз и с какими паузами повторять запрос после временного сбоя:
+// таймаута, обрыва соединения, 429 или 5xx. Остальные 4xx не повторяются.
+// Нулевое значение — без повторов.
+type RetryPolicy struct {
+	MaxAttempts int           // попыток на один URL, включая первую
+	BaseDelay   time.Duration // пауза перед первым повтором; дальше удваивается
+	MaxDelay    time.Duration // потолок паузы; Retry-After больше него — сдаёмся сразу
+}
+
+// DefaultRetryPolicy — 4 попытки с паузами около 1, 2 и 4 секунд.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   time.Second,
+	MaxDelay:    30 * time.Second,
+}
+
+// delay возвращает паузу перед попыткой attempt+1 и false, если повторять не нужно.
+func (p RetryPolicy) delay(attempt int, err error) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts || !retryable(err) {
+		return 0, false
+	}
+
+	var se *StatusError
+	if errors.As(err, &se) && se.RetryAfter > 0 {
+		if p.MaxDelay > 0 && se.RetryAfter > p.MaxDelay {
+			return 0, false
+		}
+		return se.RetryAfter, true
+	}
+	return p.backoff(attempt), true
+}
+
+// backoff — экспоненциальная пауза с «половинным» джиттером: от d/2 до d.
+// Случайная половина не даёт параллельным загрузкам повторять запросы хором.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < attempt && d > 0; i++ {
+		d *= 2
+		if p.MaxDelay > 0 && d >= p.MaxDelay {
+			break
+		}
+	}
+	if p.MaxDelay > 0 && (d > p.MaxDelay || d < 0) {
+		d = p.MaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+// retryable — стоит ли повторять запрос, закончившийся ошибкой err.
+func retryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var se *StatusError
+	if errors.As(err, &se) {
+		return se.Code == http.StatusTooManyRequests || se.Code >= 500
+	}
+	var ne net.Error
+	if errors.As(err, &ne) && ne.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// parseRetryAfter разбирает Retry-After: число секунд или HTTP-дату.
+func parseRetryAfter(h string, now time.Time) time.Duration {
+	h = strings.TrimSpace(h)
+	if h == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(h); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(h); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,181 @@
+package downloader
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flaky отвечает кодами из codes по очереди, а когда они кончаются — 200 и «ok».
+func flaky(t *testing.T, header http.Header, codes ...int) (*httptest.Server, *atomic.Int32) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1))
+		if n <= len(codes) {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(codes[n-1])
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+// newTestClient — клиент, который не спит, а записывает паузы.
+func newTestClient(p RetryPolicy) (*Client, *[]time.Duration) {
+	var delays []time.Duration
+	c := New(time.Second, p, Pool{})
+	c.sleep = func(d time.Duration) { delays = append(delays, d) }
+	return c, &delays
+}
+
+var testPolicy = RetryPolicy{MaxAttempts: 4, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+func TestFetch_RetriesServerErrors(t *testing.T) {
+	srv, calls := flaky(t, nil, http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusTooManyRequests)
+	c, delays := newTestClient(testPolicy)
+
+	resp, err := c.Fetch(srv.URL)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	defer resp.Body.Close()
+	if body, _ := io.ReadAll(resp.Body); string(body) != "ok" {
+		t.Errorf("body = %q", body)
+	}
+	if resp.Attempts != 4 || calls.Load() != 4 || c.Retries() != 3 {
+		t.Errorf("attempts = %d, calls = %d, retries = %d, want 4, 4, 3", resp.Attempts, calls.Load(), c.Retries())
+	}
+
+	// Пауза растёт вдвое и лежит в [d/2, d].
+	for i, d := range *delays {
+		hi := testPolicy.BaseDelay << i
+		if d < hi/2 || d > hi {
+			t.Errorf("delay %d = %v, want between %v and %v", i, d, hi/2, hi)
+		}
+	}
+}
+
+func TestFetch_GivesUp(t *testing.T) {
+	srv, calls := flaky(t, nil, 500, 500, 500, 500, 500)
+	c, _ := newTestClient(testPolicy)
+
+	_, err := c.Fetch(srv.URL)
+	var se *StatusError
+	if !errors.As(err, &se) || se.Code != 500 {
+		t.Fatalf("err = %v, want StatusError 500", err)
+	}
+	if calls.Load() != 4 {
+		t.Errorf("calls = %d, want MaxAttempts", calls.Load())
+	}
+}
+
+func TestFetchFrom_SharesAttemptBudget(t *testing.T) {
+	srv, calls := flaky(t, nil, 500, 500, 500, 500, 500)
+	c, _ := newTestClient(testPolicy)
+
+	// Две попытки на этот URL уже потрачены — осталось две из MaxAttempts.
+	attempts := 2
+	if _, err := c.FetchFrom(srv.URL, 0, "", &attempts, nil); err == nil {
+		t.Fatal("no error")
+	}
+	if calls.Load() != 2 || attempts != 4 {
+		t.Errorf("calls = %d, attempts = %d, want 2 and 4", calls.Load(), attempts)
+	}
+
+	// Бюджет исчерпан — запроса нет вовсе.
+	if _, err := c.FetchFrom(srv.URL, 0, "", &attempts, nil); err == nil || calls.Load() != 2 {
+		t.Errorf("err = %v after %d calls, want a refusal without a request", err, calls.Load())
+	}
+}
+
+func TestFetch_ClientErrorsNotRetried(t *testing.T) {
+	for _, code := range []int{http.StatusNotFound, http.StatusForbidden, http.StatusRequestedRangeNotSatisfiable} {
+		srv, calls := flaky(t, nil, code)
+		c, _ := newTestClient(testPolicy)
+		if _, err := c.Fetch(srv.URL); err == nil {
+			t.Errorf("%d: no error", code)
+		}
+		if calls.Load() != 1 || c.Retries() != 0 {
+			t.Errorf("%d was retried: %d calls", code, calls.Load())
+		}
+	}
+}
+
+func TestFetch_RetryAfter(t *testing.T) {
+	srv, _ := flaky(t, http.Header{"Retry-After": {"1"}}, http.StatusTooManyRequests)
+	c, delays := newTestClient(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Second})
+	resp, err := c.Fetch(srv.URL)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	resp.Body.Close()
+	if len(*delays) != 1 || (*delays)[0] != time.Second {
+		t.Errorf("delays = %v, want the server's 1s", *delays)
+	}
+
+	// Просят ждать дольше MaxDelay — не ждём.
+	srv, calls := flaky(t, http.Header{"Retry-After": {"120"}}, http.StatusServiceUnavailable)
+	c, _ = newTestClient(testPolicy)
+	var se *StatusError
+	if _, err := c.Fetch(srv.URL); !errors.As(err, &se) || se.RetryAfter != 2*time.Minute {
+		t.Errorf("err = %v, want StatusError with Retry-After", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("calls = %d, want 1", calls.Load())
+	}
+}
+
+func TestFetch_RetriesTimeoutsAndResets(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1: // обрыв соединения до ответа
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+		case 2: // ответ дольше таймаута клиента
+			time.Sleep(300 * time.Millisecond)
+		default:
+			w.Write([]byte("ok"))
+		}
+	}))
+	defer srv.Close()
+
+	c, _ := newTestClient(testPolicy)
+	c.httpClient.Timeout = 100 * time.Millisecond
+	resp, err := c.Fetch(srv.URL)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	resp.Body.Close()
+	if resp.Attempts != 3 {
+		t.Errorf("attempts = %d, want 3", resp.Attempts)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		h    string
+		want time.Duration
+	}{
+		{"", 0},
+		{"30", 30 * time.Second},
+		{"-5", 0},
+		{"Sun, 18 Oct 2026 12:01:30 GMT", 90 * time.Second},
+		{"Sun, 18 Oct 2026 11:00:00 GMT", 0}, // уже прошло
+		{"soon", 0},
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.h, now); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.h, got, tt.want)
+		}
+	}
+}
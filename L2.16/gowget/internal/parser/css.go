@@ -0,0 +1,90 @@
+package parser
+
+import (
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// cssRef находит в CSS ссылки: url(...) в любом виде и @import "...".
+// Комментарии тоже попадают в выборку, чтобы оставить их нетронутыми.
+var cssRef = regexp.MustCompile(`/\*[\s\S]*?\*/` +
+	`|url\(\s*(?:"([^"]*)"|'([^']*)'|([^)"'\s]*))\s*\)` +
+	`|@import\s+(?:"([^"]*)"|'([^']*)')`)
+
+// ProcessCSS находит в таблице стилей ресурсы (картинки, шрифты, @import)
+// и переписывает ссылки на них на локальные.
+func (p *Processor) ProcessCSS(baseURL *url.URL, r io.Reader) (*Result, error) {
+	css, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var links []*url.URL
+	out := rewriteCSS(string(css), baseURL, &links, p)
+	return &Result{
+		Content: []byte(out),
+		Links:   links,
+	}, nil
+}
+
+// rewriteCSS переписывает ссылки в CSS-тексте: таблице стилей, блоке <style> или атрибуте style.
+// baseURL — адрес документа, в котором этот CSS лежит: относительно него резолвятся
+// и строятся ссылки.
+func rewriteCSS(css string, baseURL *url.URL, links *[]*url.URL, p *Processor) string {
+	return cssRef.ReplaceAllStringFunc(css, func(m string) string {
+		if strings.HasPrefix(m, "/*") {
+			return m
+		}
+
+		sub := cssRef.FindStringSubmatch(m)
+		var ref, quote string
+		switch {
+		case sub[1] != "" || sub[4] != "":
+			ref, quote = sub[1]+sub[4], `"`
+		case sub[2] != "" || sub[5] != "":
+			ref, quote = sub[2]+sub[5], `'`
+		default:
+			ref = sub[3]
+		}
+
+		local, ok := localRef(ref, baseURL, links, p)
+		if !ok {
+			return m
+		}
+		if strings.HasPrefix(m, "@import") {
+			return "@import " + quote + local + quote
+		}
+		return "url(" + quote + local + quote + ")"
+	})
+}
+
+// localRef добавляет ресурс ref в очередь и возвращает путь к его локальной копии.
+// Пустые ссылки, data:, якоря вроде url(#gradient) и чужие хосты не трогаем;
+// ресурс, который качать нельзя, остаётся абсолютным адресом на сервере.
+func localRef(ref string, baseURL *url.URL, links *[]*url.URL, p *Processor) (string, bool) {
+	if ref == "" || strings.HasPrefix(ref, "#") || strings.HasPrefix(ref, "data:") {
+		return "", false
+	}
+	link, err := baseURL.Parse(ref)
+	if err != nil || link.Hostname() != baseURL.Hostname() {
+		return "", false
+	}
+
+	if p.skip(link) {
+		return link.String(), true
+	}
+
+	local, err := p.fs.ComputeRelativePath(baseURL, link, false)
+	if err != nil {
+		return "", false
+	}
+	*links = append(*links, link)
+
+	// Якорь нужен SVG-спрайтам и шрифтам: font.svg#icons.
+	if link.Fragment != "" {
+		local += "#" + link.Fragment
+	}
+	return local, true
+}
@@ -0,0 +1,107 @@
+package parser
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+
+	"gowget/internal/storage"
+)
+
+func linkStrings(links []*url.URL) []string {
+	var out []string
+	for _, l := range links {
+		out = append(out, l.String())
+	}
+	return out
+}
+
+func TestProcessCSS(t *testing.T) {
+	base, _ := url.Parse("http://example.com/static/css/site.css")
+	css := `@import "reset.css";
+@import url('/fonts/fonts.css');
+/* url(old.png) */
+body { background: url(../img/bg.png) no-repeat; }
+.icon { background-image: url( "icons.svg#star" ); }
+.logo { background: url(data:image/png;base64,AAAA); }
+.ext { background: url(https://cdn.example.org/x.png); }
+.grad { fill: url(#g); }
+`
+	res, err := New(storage.New(t.TempDir()), nil).ProcessCSS(base, strings.NewReader(css))
+	if err != nil {
+		t.Fatalf("ProcessCSS: %v", err)
+	}
+
+	want := `@import "reset.css";
+@import url('../../fonts/fonts.css');
+/* url(old.png) */
+body { background: url(../img/bg.png) no-repeat; }
+.icon { background-image: url("icons.svg#star"); }
+.logo { background: url(data:image/png;base64,AAAA); }
+.ext { background: url(https://cdn.example.org/x.png); }
+.grad { fill: url(#g); }
+`
+	if got := string(res.Content); got != want {
+		t.Errorf("rewritten CSS:\n%s\nwant:\n%s", got, want)
+	}
+
+	wantLinks := []string{
+		"http://example.com/static/css/reset.css",
+		"http://example.com/fonts/fonts.css",
+		"http://example.com/static/img/bg.png",
+		"http://example.com/static/css/icons.svg#star",
+	}
+	if got := linkStrings(res.Links); !slices.Equal(got, wantLinks) {
+		t.Errorf("links = %v, want %v", got, wantLinks)
+	}
+}
+
+func TestProcess_InlineStyles(t *testing.T) {
+	base, _ := url.Parse("http://example.com/docs/page.html")
+	page := `<html><head><style>h1 { background: url("/img/h1.png") }</style></head>` +
+		`<body><div style="background-image: url('bg.jpg')">x</div></body></html>`
+
+	res, err := New(storage.New(t.TempDir()), nil).Process(base, strings.NewReader(page))
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+
+	got := string(res.Content)
+	for _, want := range []string{`url("../img/h1.png")`, `url(&#39;bg.jpg&#39;)`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("page does not contain %s:\n%s", want, got)
+		}
+	}
+	wantLinks := []string{"http://example.com/img/h1.png", "http://example.com/docs/bg.jpg"}
+	if got := linkStrings(res.Links); !slices.Equal(got, wantLinks) {
+		t.Errorf("links = %v, want %v", got, wantLinks)
+	}
+}
+
+func TestProcess_DisallowedLinksStayRemote(t *testing.T) {
+	base, _ := url.Parse("http://example.com/docs/page.html")
+	page := `<html><head><style>h1 { background: url("/private/h1.png") }</style></head>` +
+		`<body><a href="/private/secret.html">S</a><a href="next.html">N</a><img src="/private/logo.png"></body></html>`
+	allowed := func(u *url.URL) bool { return !strings.HasPrefix(u.Path, "/private/") }
+
+	res, err := New(storage.New(t.TempDir()), allowed).Process(base, strings.NewReader(page))
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+
+	got := string(res.Content)
+	for _, want := range []string{
+		`url("http://example.com/private/h1.png")`,
+		`href="http://example.com/private/secret.html"`,
+		`src="http://example.com/private/logo.png"`,
+		`href="next.html"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("page does not contain %s:\n%s", want, got)
+		}
+	}
+	if got := linkStrings(res.Links); !slices.Equal(got, []string{"http://example.com/docs/next.html"}) {
+		t.Errorf("links = %v, want only the allowed page", got)
+	}
+}
@@ -12,16 +12,24 @@ import (
 )
 
 type Result struct {
-	ModifiedHTML []byte
-	Links        []*url.URL
+	Content []byte // документ с переписанными ссылками
+	Links   []*url.URL
 }
 
 type Processor struct {
-	fs *storage.FileSystem
+	fs      *storage.FileSystem
+	allowed func(*url.URL) bool // nil — качать можно всё
 }
 
-func New(fs *storage.FileSystem) *Processor {
-	return &Processor{fs: fs}
+// New создаёт обработчик. allowed решает, можно ли качать ссылку; ссылки, которые
+// качать нельзя (например, закрытые robots.txt), остаются абсолютными адресами на сервере.
+func New(fs *storage.FileSystem, allowed func(*url.URL) bool) *Processor {
+	return &Processor{fs: fs, allowed: allowed}
+}
+
+// skip — ссылку качать нельзя, и переписывать её на локальную копию незачем.
+func (p *Processor) skip(link *url.URL) bool {
+	return p.allowed != nil && !p.allowed(link)
 }
 
 // Process парсит HTML, находит ссылки для скачивания и переписывает их на локальные.
@@ -37,7 +45,8 @@ func (p *Processor) Process(baseURL *url.URL, r io.Reader) (*Result, error) {
 	f = func(n *html.Node) {
 		if n.Type == html.ElementNode {
 			// Обрабатываем теги, содержащие ссылки
-			processNode(n, baseURL, &links, p.fs)
+			processNode(n, baseURL, &links, p)
+			processStyle(n, baseURL, &links, p)
 		}
 		for c := n.FirstChild; c != nil; c = c.NextSibling {
 			f(c)
@@ -51,12 +60,12 @@ func (p *Processor) Process(baseURL *url.URL, r io.Reader) (*Result, error) {
 	}
 
 	return &Result{
-		ModifiedHTML: buf.Bytes(),
-		Links:        links,
+		Content: buf.Bytes(),
+		Links:   links,
 	}, nil
 }
 
-func processNode(n *html.Node, baseURL *url.URL, links *[]*url.URL, fs *storage.FileSystem) {
+func processNode(n *html.Node, baseURL *url.URL, links *[]*url.URL, p *Processor) {
 	var attrKey string
 	var isResource bool
 
@@ -86,6 +95,12 @@ func processNode(n *html.Node, baseURL *url.URL, links *[]*url.URL, fs *storage.
 				continue // Внешние ссылки оставляем как есть
 			}
 
+			// Закрытая ссылка не скачается: ведём её на сервер, а не на несуществующий файл
+			if p.skip(parsedLink) {
+				n.Attr[i].Val = parsedLink.String()
+				break
+			}
+
 			// Определяем, является ли целью HTML (грубая проверка, можно улучшить через HEAD запрос)
 			isHTML := !isResource && (filepath.Ext(parsedLink.Path) == "" || filepath.Ext(parsedLink.Path) == ".html")
 
@@ -93,11 +108,27 @@ func processNode(n *html.Node, baseURL *url.URL, links *[]*url.URL, fs *storage.
 			*links = append(*links, parsedLink)
 
 			// Переписываем ссылку на локальную относительную
-			relPath, err := fs.ComputeRelativePath(baseURL, parsedLink, isHTML)
+			relPath, err := p.fs.ComputeRelativePath(baseURL, parsedLink, isHTML)
 			if err == nil {
 				n.Attr[i].Val = relPath
 			}
 			break
 		}
 	}
+}
+
+// processStyle переписывает ссылки в CSS внутри страницы: в блоках <style> и атрибутах style.
+func processStyle(n *html.Node, baseURL *url.URL, links *[]*url.URL, p *Processor) {
+	if n.Data == "style" {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == html.TextNode {
+				c.Data = rewriteCSS(c.Data, baseURL, links, p)
+			}
+		}
+	}
+	for i, a := range n.Attr {
+		if a.Key == "style" {
+			n.Attr[i].Val = rewriteCSS(a.Val, baseURL, links, p)
+		}
+	}
 }
\ No newline at end of file
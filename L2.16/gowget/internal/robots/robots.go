@@ -0,0 +1,155 @@
+// Package robots разбирает robots.txt (RFC 9309) для одного User-Agent.
+package robots
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSize — сколько байт robots.txt читается; остальное игнорируется, как у поисковиков.
+const maxSize = 500 << 10
+
+// MaxCrawlDelay — потолок Crawl-delay: паузы в минуты и часы остановили бы обход хоста,
+// а на время паузы загрузка занимает слот хоста.
+const MaxCrawlDelay = 30 * time.Second
+
+type rule struct {
+	allow   bool
+	pattern string
+}
+
+// Rules — правила robots.txt, относящиеся к нашему агенту.
+type Rules struct {
+	rules []rule
+	delay time.Duration
+}
+
+// AllowAll — правила для сайта без robots.txt.
+func AllowAll() *Rules { return &Rules{} }
+
+// DisallowAll — правила для сайта, robots.txt которого недоступен из-за ошибки сервера.
+func DisallowAll() *Rules { return &Rules{rules: []rule{{allow: false, pattern: "/"}}} }
+
+// Parse читает robots.txt и оставляет группы для userAgent ("GoWget/1.0" — берётся
+// токен до "/", регистр не важен). Если таких групп нет — группы для "*".
+func Parse(r io.Reader, userAgent string) *Rules {
+	token, _, _ := strings.Cut(userAgent, "/")
+	token = strings.ToLower(strings.TrimSpace(token))
+
+	var (
+		own, star          Rules
+		hasOwn             bool
+		agents             []string // агенты текущей группы
+		inRules            bool     // у группы уже есть правила — следующий User-agent начинает новую
+		ownGroup, anyGroup bool
+	)
+	sc := bufio.NewScanner(io.LimitReader(r, maxSize))
+	for sc.Scan() {
+		line, _, _ := strings.Cut(sc.Text(), "#")
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		if key == "user-agent" {
+			if inRules {
+				agents, inRules = nil, false
+			}
+			agents = append(agents, strings.ToLower(value))
+			ownGroup, anyGroup = false, false
+			for _, a := range agents {
+				ownGroup = ownGroup || a == token
+				anyGroup = anyGroup || a == "*"
+			}
+			hasOwn = hasOwn || ownGroup
+			continue
+		}
+		if len(agents) == 0 {
+			continue // правила вне группы
+		}
+		inRules = true
+
+		var dst []*Rules
+		if ownGroup {
+			dst = append(dst, &own)
+		}
+		if anyGroup {
+			dst = append(dst, &star)
+		}
+		for _, d := range dst {
+			switch key {
+			case "allow", "disallow":
+				// Пустой Disallow ничего не запрещает.
+				if value != "" {
+					d.rules = append(d.rules, rule{allow: key == "allow", pattern: value})
+				}
+			case "crawl-delay":
+				if sec, err := strconv.ParseFloat(value, 64); err == nil && sec > 0 {
+					// Сравниваем до перевода в Duration: огромное значение её переполнило бы.
+					d.delay = MaxCrawlDelay
+					if sec < MaxCrawlDelay.Seconds() {
+						d.delay = time.Duration(sec * float64(time.Second))
+					}
+				}
+			}
+		}
+	}
+	if hasOwn {
+		return &own
+	}
+	return &star
+}
+
+// Allowed сообщает, можно ли скачивать путь (с query, например "/a?b=1").
+// Побеждает самое длинное совпавшее правило; при равной длине — Allow.
+func (r *Rules) Allowed(path string) bool {
+	if path == "" {
+		path = "/"
+	}
+	if path == "/robots.txt" {
+		return true
+	}
+	best, allowed := -1, true
+	for _, ru := range r.rules {
+		if !match(ru.pattern, path) {
+			continue
+		}
+		if n := len(ru.pattern); n > best || (n == best && ru.allow) {
+			best, allowed = n, ru.allow
+		}
+	}
+	return allowed
+}
+
+// CrawlDelay — пауза между запросами к хосту, не больше MaxCrawlDelay; 0 — не задана.
+func (r *Rules) CrawlDelay() time.Duration { return r.delay }
+
+// match сопоставляет путь с шаблоном: префикс, "*" — любая последовательность,
+// "$" в конце — конец пути.
+func match(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	rest := path[len(parts[0]):]
+	for i, part := range parts[1:] {
+		last := i == len(parts)-2
+		if last && anchored {
+			return strings.HasSuffix(rest, part)
+		}
+		j := strings.Index(rest, part)
+		if j < 0 {
+			return false
+		}
+		rest = rest[j+len(part):]
+	}
+	return !anchored || rest == ""
+}
@@ -0,0 +1,95 @@
+package robots
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse_GroupSelection(t *testing.T) {
+	txt := `
+# общие правила
+User-agent: *
+Disallow: /
+
+User-agent: Googlebot
+User-agent: gowget
+Disallow: /private/
+Allow: /private/public-*.html$
+Crawl-delay: 0.5
+
+User-agent: other
+Disallow: /gowget-must-not-see-this
+`
+	r := Parse(strings.NewReader(txt), "GoWget/1.0")
+
+	tests := map[string]bool{
+		"/":                         true,
+		"/index.html":               true,
+		"/private/":                 false,
+		"/private/secret.html":      false,
+		"/private/public-a.html":    true,
+		"/private/public-a.html?x":  false, // $ — конец пути
+		"/gowget-must-not-see-this": true,
+		"/robots.txt":               true,
+	}
+	for path, want := range tests {
+		if got := r.Allowed(path); got != want {
+			t.Errorf("Allowed(%q) = %v, want %v", path, got, want)
+		}
+	}
+	if r.CrawlDelay() != 500*time.Millisecond {
+		t.Errorf("CrawlDelay = %v", r.CrawlDelay())
+	}
+}
+
+func TestParse_CrawlDelayCapped(t *testing.T) {
+	tests := map[string]time.Duration{
+		"30":    MaxCrawlDelay,
+		"86400": MaxCrawlDelay,
+		"1e300": MaxCrawlDelay, // не переполняет Duration
+		"2.5":   2500 * time.Millisecond,
+		"-1":    0,
+		"soon":  0,
+	}
+	for value, want := range tests {
+		r := Parse(strings.NewReader("User-agent: *\nCrawl-delay: "+value+"\n"), "GoWget/1.0")
+		if got := r.CrawlDelay(); got != want {
+			t.Errorf("Crawl-delay: %s gives %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestParse_FallsBackToStar(t *testing.T) {
+	r := Parse(strings.NewReader("User-agent: *\nDisallow: /tmp\nDisallow:\n\nUser-agent: bingbot\nDisallow: /"), "GoWget/1.0")
+	if r.Allowed("/tmp/a") || r.Allowed("/tmpfile") {
+		t.Error("/tmp prefix must be disallowed")
+	}
+	if !r.Allowed("/docs/") {
+		t.Error("/docs/ must be allowed")
+	}
+}
+
+func TestAllowed_LongestMatchWins(t *testing.T) {
+	r := Parse(strings.NewReader("User-agent: *\nAllow: /shop\nDisallow: /shop/cart\nAllow: /shop/cart/help\nDisallow: /*.pdf$\nAllow: /*.pdf$"), "GoWget/1.0")
+	tests := map[string]bool{
+		"/shop/item":        true,
+		"/shop/cart/1":      false,
+		"/shop/cart/help/1": true,
+		"/docs/a.pdf":       true, // одинаковая длина — побеждает Allow
+	}
+	for path, want := range tests {
+		if got := r.Allowed(path); got != want {
+			t.Errorf("Allowed(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestDefaults(t *testing.T) {
+	if !AllowAll().Allowed("/anything") {
+		t.Error("AllowAll disallows")
+	}
+	if DisallowAll().Allowed("/anything") {
+		t.Error("DisallowAll allows")
+	}
+}
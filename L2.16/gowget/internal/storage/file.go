@@ -27,16 +27,26 @@ func (fs *FileSystem) Save(u *url.URL, reader io.Reader, isHTML bool) (string, e
 		return "", fmt.Errorf("failed to create directory: %w", err)
 	}
 
-	f, err := os.Create(absPath)
+	// Пишем во временный .part и переименовываем только целиком записанный файл,
+	// чтобы прерванная загрузка не испортила уже скачанную раньше копию.
+	part := absPath + partSuffix
+	f, err := os.Create(part)
 	if err != nil {
 		return "", fmt.Errorf("failed to create file: %w", err)
 	}
-	defer f.Close()
 
-	if _, err := io.Copy(f, reader); err != nil {
+	_, err = io.Copy(f, reader)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(part)
 		return "", fmt.Errorf("failed to write file content: %w", err)
 	}
 
+	if err := os.Rename(part, absPath); err != nil {
+		return "", fmt.Errorf("failed to move file into place: %w", err)
+	}
 	return localPath, nil
 }
 
@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	partSuffix      = ".part"           // недокачанный файл
+	validatorSuffix = ".part.validator" // ETag или Last-Modified, с которым начали качать .part
+)
+
+// Partial возвращает размер недокачанного файла для u и валидатор для If-Range.
+// size = 0 — докачивать нечего: файла нет или без валидатора его нельзя продолжить.
+// Докачиваются только не-HTML файлы: HTML всё равно переписывается целиком.
+func (fs *FileSystem) Partial(u *url.URL) (size int64, validator string) {
+	absPath := filepath.Join(fs.BaseDir, fs.GetLocalPath(u, false))
+	v, err := os.ReadFile(absPath + validatorSuffix)
+	if err != nil {
+		return 0, ""
+	}
+	info, err := os.Stat(absPath + partSuffix)
+	if err != nil || info.Size() == 0 {
+		return 0, ""
+	}
+	return info.Size(), strings.TrimSpace(string(v))
+}
+
+// DiscardPartial удаляет недокачанный файл для u.
+func (fs *FileSystem) DiscardPartial(u *url.URL) {
+	absPath := filepath.Join(fs.BaseDir, fs.GetLocalPath(u, false))
+	os.Remove(absPath + partSuffix)
+	os.Remove(absPath + validatorSuffix)
+}
+
+// SaveFrom сохраняет не-HTML файл, тело которого начинается с байта offset.
+// offset > 0 дописывает .part, оставшийся от прошлой попытки; offset = 0 начинает
+// заново. Если запись прервалась, а validator известен, .part остаётся для докачки;
+// готовый файл атомарно переименовывается на место.
+func (fs *FileSystem) SaveFrom(u *url.URL, reader io.Reader, offset int64, validator string) (string, error) {
+	localPath := fs.GetLocalPath(u, false)
+	absPath := filepath.Join(fs.BaseDir, localPath)
+	part := absPath + partSuffix
+
+	if err := os.MkdirAll(filepath.Dir(absPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	f, err := fs.openPart(absPath, offset, validator)
+	if err != nil {
+		return "", err
+	}
+
+	n, err := io.Copy(f, reader)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		if validator == "" {
+			fs.DiscardPartial(u)
+			return "", fmt.Errorf("failed to write file content: %w", err)
+		}
+		return "", fmt.Errorf("download interrupted after %d bytes, kept %s for resume: %w", offset+n, part, err)
+	}
+
+	if err := os.Rename(part, absPath); err != nil {
+		return "", fmt.Errorf("failed to move file into place: %w", err)
+	}
+	os.Remove(absPath + validatorSuffix)
+	return localPath, nil
+}
+
+// openPart открывает .part для дозаписи с offset или создаёт его заново
+// вместе с файлом валидатора.
+func (fs *FileSystem) openPart(absPath string, offset int64, validator string) (*os.File, error) {
+	part := absPath + partSuffix
+	if offset == 0 {
+		if validator == "" {
+			os.Remove(absPath + validatorSuffix)
+		} else if err := os.WriteFile(absPath+validatorSuffix, []byte(validator+"\n"), 0644); err != nil {
+			return nil, fmt.Errorf("failed to save resume validator: %w", err)
+		}
+		f, err := os.Create(part)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create file: %w", err)
+		}
+		return f, nil
+	}
+
+	f, err := os.OpenFile(part, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reopen partial file: %w", err)
+	}
+	info, err := f.Stat()
+	if err == nil && info.Size() != offset {
+		err = errors.New("size changed since the resume was planned")
+	}
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to resume %s at %d: %w", part, offset, err)
+	}
+	return f, nil
+}
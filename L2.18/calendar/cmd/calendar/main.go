@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"flag"
+	"io"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"calendar/internal/accesslog"
+	"calendar/internal/blob"
+	"calendar/internal/domain"
+	"calendar/internal/holiday"
+	"calendar/internal/repository"
+	"calendar/internal/transport"
+	"calendar/internal/usecase"
+)
+
+func main() {
+	addrFlag := flag.String("addr", ":8080", "HTTP listen address")
+	legacyErrorsFlag := flag.Bool("legacy-errors", false, `Answer errors as {"error": "..."} instead of application/problem+json, with the old API's status codes (503 not found, 400 invalid input, 500 otherwise; quota errors keep 429/422)`)
+	cacheSizeFlag := flag.Int("cache-size", 4096, "Number of cached event range queries (0 disables the cache)")
+	cacheTTLFlag := flag.Duration("cache-ttl", time.Minute, "How long a cached event range query stays valid")
+	attachmentsDirFlag := flag.String("attachments-dir", "attachments", "Directory for event attachments")
+	maxAttachmentFlag := flag.Int64("max-attachment-size", usecase.DefaultMaxAttachmentSize, "Maximum attachment size in bytes")
+	trashRetentionFlag := flag.Duration("trash-retention", usecase.DefaultTrashRetention, "How long deleted events stay restorable in the trash")
+	purgeIntervalFlag := flag.Duration("trash-purge-interval", time.Hour, "How often expired events are purged from the trash")
+	maxEventsFlag := flag.Int("max-events", usecase.DefaultQuota.MaxEvents, "Maximum events per user (0 means unlimited)")
+	maxEventsPerDayFlag := flag.Int("max-events-per-day", usecase.DefaultQuota.MaxEventsPerDay, "Maximum events per user on one day (0 means unlimited)")
+	maxTitleFlag := flag.Int("max-title-length", usecase.DefaultQuota.MaxTitleLength, "Maximum event title length in characters (0 means unlimited)")
+	holidaysFlag := flag.String("holidays", "ru", `Holiday calendar: "ru" (built-in), "weekends" (Saturdays and Sundays only) or a .json/.ics file`)
+	accessLogFlag := flag.String("access-log", "", "Access log file (empty writes to stderr)")
+	accessLogFormatFlag := flag.String("access-log-format", "json", "Access log format: json or text")
+	accessLogMaxSizeFlag := flag.Int64("access-log-max-size", 100, "Rotate the access log file after this many megabytes (0 disables)")
+	accessLogMaxAgeFlag := flag.Duration("access-log-max-age", 24*time.Hour, "Rotate the access log file after this long (0 disables)")
+	accessLogBackupsFlag := flag.Int("access-log-max-backups", 7, "Number of rotated access log files to keep (0 keeps all)")
+	accessLogBufferFlag := flag.Int("access-log-buffer", 8192, "Access log lines buffered before new ones are dropped")
+	adminTokenFlag := flag.String("admin-token", os.Getenv("CALENDAR_ADMIN_TOKEN"), "Bearer token for /admin endpoints (empty disables them; defaults to $CALENDAR_ADMIN_TOKEN)")
+
+	flag.Parse()
+
+	var repo repository.EventRepository = repository.NewShardedStorage(0)
+	if *cacheSizeFlag > 0 {
+		cached := repository.NewCachedStorage(repo, *cacheSizeFlag, *cacheTTLFlag)
+		expvar.Publish("event_cache", expvar.Func(func() any { return cached.Stats() }))
+		repo = cached
+	}
+
+	blobs, err := blob.NewLocalStore(*attachmentsDirFlag)
+	if err != nil {
+		log.Fatalf("Attachments: %v", err)
+	}
+
+	holidays, err := holiday.Load(*holidaysFlag)
+	if err != nil {
+		log.Fatalf("Holidays: %v", err)
+	}
+
+	var sink io.Writer = os.Stderr
+	if *accessLogFlag != "" {
+		rf, err := accesslog.NewRotatingFile(*accessLogFlag, accesslog.RotateOptions{
+			MaxSize:    *accessLogMaxSizeFlag << 20,
+			MaxAge:     *accessLogMaxAgeFlag,
+			MaxBackups: *accessLogBackupsFlag,
+		})
+		if err != nil {
+			log.Fatalf("Access log: %v", err)
+		}
+		sink = rf
+	}
+	accessOut := accesslog.NewAsyncWriter(sink, *accessLogBufferFlag)
+	expvar.Publish("access_log", expvar.Func(func() any { return accessOut.Stats() }))
+
+	var accessHandler slog.Handler
+	switch *accessLogFormatFlag {
+	case "json":
+		accessHandler = slog.NewJSONHandler(accessOut, nil)
+	case "text":
+		accessHandler = slog.NewTextHandler(accessOut, nil)
+	default:
+		log.Fatalf("Access log: unknown format %q", *accessLogFormatFlag)
+	}
+
+	uc := usecase.NewEventUseCase(repo,
+		usecase.WithAttachments(repository.NewLocalAttachmentStorage(), blobs),
+		usecase.WithAttachmentLimits(*maxAttachmentFlag, usecase.DefaultAttachmentTypes...),
+		usecase.WithTrashRetention(*trashRetentionFlag),
+		usecase.WithHolidays(holidays),
+		usecase.WithQuota(domain.Quota{
+			MaxEvents:       *maxEventsFlag,
+			MaxEventsPerDay: *maxEventsPerDayFlag,
+			MaxTitleLength:  *maxTitleFlag,
+		}),
+	)
+	handler := transport.NewHandler(uc,
+		transport.WithLegacyErrors(*legacyErrorsFlag),
+		transport.WithAdminToken(*adminTokenFlag),
+		transport.WithAccessLog(slog.New(accessHandler)),
+	)
+
+	// Метрики (в том числе попадания и промахи кеша и потерянные строки журнала) — на /debug/vars, остальное — API.
+	mux := http.NewServeMux()
+	mux.Handle("/debug/vars", expvar.Handler())
+	mux.Handle("/", transport.NewRouter(handler))
+
+	srv := &http.Server{
+		Addr:              *addrFlag,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go uc.PurgeTrashEvery(ctx, *purgeIntervalFlag)
+
+	shutdownDone := make(chan struct{})
+	go func() {
+		defer close(shutdownDone)
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Shutdown failed: %v", err)
+		}
+	}()
+
+	log.Printf("Calendar server listening on %s", *addrFlag)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("Server failed: %v", err)
+	}
+	// ListenAndServe возвращается сразу, а Shutdown ждёт активные запросы —
+	// журнал закрываем только после них, иначе их строки потеряются.
+	<-shutdownDone
+	if err := accessOut.Close(); err != nil {
+		log.Printf("Access log close failed: %v", err)
+	}
+	log.Printf("Server stopped")
+}
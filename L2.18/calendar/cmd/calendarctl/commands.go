@@ -0,0 +1,374 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"calendar/internal/domain"
+)
+
+const (
+	dateLayout     = "2006-01-02"
+	dateTimeLayout = "2006-01-02 15:04"
+)
+
+// formatDate выводит дату, а если у неё есть время суток — и время, как сервер.
+func formatDate(t time.Time) string {
+	if t.Hour() == 0 && t.Minute() == 0 {
+		return t.Format(dateLayout)
+	}
+	return t.Format(dateTimeLayout)
+}
+
+// newFlagSet создаёт набор флагов подкоманды; --json можно указать и после имени команды.
+func (a *app) newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.BoolVar(&a.json, "json", a.json, "print JSON instead of tables")
+	return fs
+}
+
+// tagsFlag — список тегов через запятую: -tags work,urgent.
+type tagsFlag []string
+
+func (t *tagsFlag) String() string { return strings.Join(*t, ",") }
+
+func (t *tagsFlag) Set(v string) error {
+	for _, tag := range strings.Split(v, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			*t = append(*t, tag)
+		}
+	}
+	return nil
+}
+
+// attendeesFlag — ID приглашённых пользователей через запятую: -attendees 2,3.
+type attendeesFlag []domain.Attendee
+
+func (f *attendeesFlag) String() string {
+	ids := make([]string, 0, len(*f))
+	for _, a := range *f {
+		ids = append(ids, strconv.Itoa(a.UserID))
+	}
+	return strings.Join(ids, ",")
+}
+
+func (f *attendeesFlag) Set(v string) error {
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		id, err := strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("invalid user ID %q", s)
+		}
+		*f = append(*f, domain.Attendee{UserID: id})
+	}
+	return nil
+}
+
+// attrFlags регистрирует флаги атрибутов события для add и edit.
+func attrFlags(fs *flag.FlagSet) *domain.EventAttrs {
+	var attrs domain.EventAttrs
+	fs.Var((*tagsFlag)(&attrs.Tags), "tags", "comma-separated event tags")
+	fs.StringVar(&attrs.Category, "category", "", "event category")
+	fs.IntVar(&attrs.Priority, "priority", 0, "event priority (0 = none, higher is more important)")
+	fs.IntVar(&attrs.Duration, "duration", 0, "event duration in minutes (0 = none)")
+	fs.Var((*attendeesFlag)(&attrs.Attendees), "attendees", "comma-separated IDs of invited users")
+	return &attrs
+}
+
+// filterFlags регистрирует фильтры выборки для day, week, month и export.
+func filterFlags(fs *flag.FlagSet) *domain.EventFilter {
+	var f domain.EventFilter
+	fs.Var((*tagsFlag)(&f.Tags), "tags", "only events with these comma-separated tags")
+	fs.BoolVar(&f.MatchAll, "all-tags", false, "require all -tags instead of any of them")
+	fs.StringVar(&f.Category, "category", "", "only events of this category")
+	fs.IntVar(&f.MinPriority, "min-priority", 0, "only events with at least this priority")
+	return &f
+}
+
+// required проверяет, что обязательные флаги заданы.
+func (a *app) required(fs *flag.FlagSet, names ...string) error {
+	for _, name := range names {
+		if fs.Lookup(name).Value.String() == "" {
+			fmt.Fprintf(a.stderr, "%s: -%s is required\n", fs.Name(), name)
+			return errUsage
+		}
+	}
+	return nil
+}
+
+func cmdAdd(a *app, args []string) error {
+	fs := a.newFlagSet("add")
+	date := fs.String("date", "", "event date (YYYY-MM-DD [HH:MM] or e.g. tomorrow, next friday, завтра)")
+	title := fs.String("title", "", "event title")
+	attrs := attrFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := a.required(fs, "date", "title"); err != nil {
+		return err
+	}
+
+	id, resolved, err := a.api.CreateEvent(a.cfg.UserID, *date, *title, *attrs)
+	if err != nil {
+		return err
+	}
+	return a.printResult(map[string]string{"id": id, "date": resolved}, "created "+id+" on "+resolved)
+}
+
+func cmdEdit(a *app, args []string) error {
+	fs := a.newFlagSet("edit")
+	id := fs.String("id", "", "event ID")
+	date := fs.String("date", "", "new event date (YYYY-MM-DD or a relative expression)")
+	title := fs.String("title", "", "new event title")
+	attrs := attrFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := a.required(fs, "id", "date", "title"); err != nil {
+		return err
+	}
+
+	if err := a.api.UpdateEvent(*id, a.cfg.UserID, *date, *title, *attrs); err != nil {
+		return err
+	}
+	return a.printResult(map[string]string{"id": *id, "result": "updated"}, "updated "+*id)
+}
+
+func cmdRemove(a *app, args []string) error {
+	fs := a.newFlagSet("rm")
+	id := fs.String("id", "", "event ID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *id == "" && fs.NArg() > 0 {
+		fs.Set("id", fs.Arg(0))
+	}
+	if err := a.required(fs, "id"); err != nil {
+		return err
+	}
+
+	if err := a.api.DeleteEvent(*id, a.cfg.UserID); err != nil {
+		return err
+	}
+	return a.printResult(map[string]string{"id": *id, "result": "deleted"}, "deleted "+*id)
+}
+
+func cmdRespond(a *app, args []string) error {
+	fs := a.newFlagSet("respond")
+	id := fs.String("id", "", "event ID")
+	status := fs.String("status", "", "answer: accepted, declined or tentative")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := a.required(fs, "id", "status"); err != nil {
+		return err
+	}
+
+	if err := a.api.RespondToInvitation(*id, a.cfg.UserID, domain.AttendeeStatus(*status)); err != nil {
+		return err
+	}
+	return a.printResult(map[string]string{"id": *id, "result": *status}, *status+" "+*id)
+}
+
+// usersFlag — ID пользователей через запятую: -with 2,3.
+type usersFlag []int
+
+func (f *usersFlag) String() string {
+	ids := make([]string, 0, len(*f))
+	for _, id := range *f {
+		ids = append(ids, strconv.Itoa(id))
+	}
+	return strings.Join(ids, ",")
+}
+
+func (f *usersFlag) Set(v string) error {
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		id, err := strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("invalid user ID %q", s)
+		}
+		*f = append(*f, id)
+	}
+	return nil
+}
+
+// cmdSlots ищет время для встречи текущего пользователя с пользователями из -with.
+func cmdSlots(a *app, args []string) error {
+	fs := a.newFlagSet("slots")
+	var with usersFlag
+	fs.Var(&with, "with", "comma-separated IDs of the other participants")
+	q := domain.SlotQuery{}
+	fs.IntVar(&q.Duration, "duration", 30, "meeting duration in minutes")
+	fs.StringVar(&q.From, "from", "today", "first day to search (YYYY-MM-DD or a relative expression)")
+	fs.StringVar(&q.To, "to", "", "last day to search (default: the -from day)")
+	fs.StringVar(&q.Start, "work-start", "", "start of the working day, e.g. 09:00")
+	fs.StringVar(&q.End, "work-end", "", "end of the working day, e.g. 18:00")
+	fs.Var((*tagsFlag)(&q.Workdays), "workdays", "comma-separated working weekdays (default monday-friday)")
+	fs.StringVar(&q.TimeZone, "tz", "", "time zone, e.g. Europe/Moscow")
+	fs.IntVar(&q.Limit, "limit", 0, "number of slots to show (default 10)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if q.To == "" {
+		q.To = q.From
+	}
+
+	q.UserIDs = append([]int{a.cfg.UserID}, with...)
+	slots, err := a.api.FindFreeSlots(q)
+	if err != nil {
+		return err
+	}
+	return a.printSlots(slots)
+}
+
+// periodFlags регистрирует флаги выравнивания периода.
+func periodFlags(fs *flag.FlagSet) *domain.PeriodOptions {
+	var opts domain.PeriodOptions
+	fs.StringVar(&opts.Align, "align", "", "period alignment: rolling or calendar (default from user settings)")
+	fs.StringVar(&opts.WeekStart, "week-start", "", "first day of a calendar week, e.g. monday, sunday, iso")
+	fs.StringVar(&opts.TimeZone, "tz", "", "time zone for period boundaries, e.g. Europe/Moscow")
+	return &opts
+}
+
+// cmdPeriod возвращает подкоманду day/week/month.
+func cmdPeriod(period string) command {
+	return func(a *app, args []string) error {
+		fs := a.newFlagSet(period)
+		date := fs.String("date", "today", "start date (YYYY-MM-DD or a relative expression)")
+		opts := periodFlags(fs)
+		filter := filterFlags(fs)
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if fs.NArg() > 0 {
+			*date = fs.Arg(0)
+		}
+
+		events, err := a.fetch(period, *date, *opts, *filter)
+		if err != nil {
+			return err
+		}
+		return a.printEvents(events)
+	}
+}
+
+func (a *app) fetch(period, date string, opts domain.PeriodOptions, filter domain.EventFilter) ([]domain.Event, error) {
+	switch period {
+	case "day":
+		return a.api.EventsForDay(a.cfg.UserID, date, opts, filter)
+	case "week":
+		return a.api.EventsForWeek(a.cfg.UserID, date, opts, filter)
+	case "month":
+		return a.api.EventsForMonth(a.cfg.UserID, date, opts, filter)
+	}
+	return nil, fmt.Errorf("unknown period %q (want day, week or month)", period)
+}
+
+func cmdExport(a *app, args []string) error {
+	fs := a.newFlagSet("export")
+	date := fs.String("date", "today", "start date (YYYY-MM-DD or a relative expression)")
+	period := fs.String("period", "month", "period to export: day, week or month")
+	out := fs.String("out", "", "output file (default stdout)")
+	opts := periodFlags(fs)
+	filter := filterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	events, err := a.fetch(*period, *date, *opts, *filter)
+	if err != nil {
+		return err
+	}
+	sortEvents(events)
+	if events == nil {
+		events = []domain.Event{}
+	}
+
+	var w io.Writer = a.stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(events)
+}
+
+// importResult — итог импорта одного события.
+type importResult struct {
+	Title string `json:"title"`
+	Date  string `json:"date"`
+	ID    string `json:"id,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+func cmdImport(a *app, args []string) error {
+	fs := a.newFlagSet("import")
+	file := fs.String("file", "-", "dump produced by export (- for stdin)")
+	keepUID := fs.Bool("keep-uid", false, "keep the iCalendar UIDs from the dump (fails for events that still exist)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		*file = fs.Arg(0)
+	}
+
+	var r io.Reader = a.stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return fmt.Errorf("failed to open dump: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var events []domain.Event
+	if err := json.NewDecoder(r).Decode(&events); err != nil {
+		return fmt.Errorf("failed to parse dump: %w", err)
+	}
+
+	// Событие создаётся заново у текущего пользователя, ID назначает сервер. UID из дампа
+	// обычно ещё занят исходным событием, поэтому по умолчанию сервер выдаст новый.
+	results := make([]importResult, 0, len(events))
+	failed := 0
+	for _, e := range events {
+		if !*keepUID {
+			e.UID = ""
+		}
+		res := importResult{Title: e.Title, Date: formatDate(e.Date)}
+		id, _, err := a.api.CreateEvent(a.cfg.UserID, res.Date, e.Title, e.EventAttrs)
+		if err != nil {
+			res.Error = err.Error()
+			failed++
+		} else {
+			res.ID = id
+		}
+		results = append(results, res)
+	}
+
+	if err := a.printImport(results); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d events failed to import", failed, len(events))
+	}
+	return nil
+}
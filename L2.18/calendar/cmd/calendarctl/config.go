@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// config — содержимое конфигурационного файла calendarctl.
+//
+//	{
+//	  "server":  "http://localhost:8080",
+//	  "user_id": 1,
+//	  "token":   "secret",
+//	  "timeout": "10s"
+//	}
+type config struct {
+	Server  string   `json:"server"`
+	UserID  int      `json:"user_id"`
+	Token   string   `json:"token"`
+	Timeout duration `json:"timeout"`
+}
+
+// duration позволяет писать таймаут в конфиге строкой вида "10s".
+type duration time.Duration
+
+func (d *duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("timeout must be a string like \"10s\": %w", err)
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = duration(v)
+	return nil
+}
+
+const defaultServer = "http://localhost:8080"
+
+// defaultConfigPath возвращает путь к конфигу: $CALENDARCTL_CONFIG или
+// <UserConfigDir>/calendarctl/config.json.
+func defaultConfigPath() string {
+	if p := os.Getenv("CALENDARCTL_CONFIG"); p != "" {
+		return p
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "calendarctl", "config.json")
+}
+
+// loadConfig читает конфиг. Отсутствующий файл по умолчанию не считается ошибкой,
+// а явно указанный через --config — считается.
+func loadConfig(path string, explicit bool) (config, error) {
+	cfg := config{Server: defaultServer, Timeout: duration(10 * time.Second)}
+	if path == "" {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && !explicit {
+			return cfg, nil
+		}
+		return cfg, fmt.Errorf("failed to read config: %w", err)
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	if cfg.Server == "" {
+		cfg.Server = defaultServer
+	}
+	return cfg, nil
+}
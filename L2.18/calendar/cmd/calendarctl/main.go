@@ -0,0 +1,128 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"calendar/internal/client"
+)
+
+const usage = `Usage: calendarctl [--config FILE] [--json] <command> [flags]
+
+Commands:
+  add     -date DATE -title TITLE     create an event
+  edit    -id ID -date DATE -title T  update an event
+  rm      -id ID                      delete an event
+  respond -id ID -status S            answer an invitation (S: accepted, declined, tentative)
+  day     [-date DATE]                events for a day
+  week    [-date DATE] [-align A]     events for a week (A: rolling, calendar)
+  month   [-date DATE] [-align A]     events for a month
+  slots   -with ID,ID [-duration M]   find times when you and others are free
+  export  [-date DATE] [-period P]    dump events as JSON (P: day, week, month)
+  import  [-file FILE] [-keep-uid]    create events from an export dump
+
+day, week, month and export also accept -week-start DAY and -tz ZONE,
+and filters -tags A,B [-all-tags], -category C, -min-priority N.
+add and edit accept -tags A,B, -category C, -priority N, -duration M
+and -attendees ID,ID. slots also accepts -from DATE, -to DATE, -work-start HH:MM,
+-work-end HH:MM, -workdays D,D, -tz ZONE and -limit N.
+
+Server address, user and token are read from the config file
+($CALENDARCTL_CONFIG or <user config dir>/calendarctl/config.json).
+`
+
+// app — общее состояние для всех подкоманд.
+type app struct {
+	cfg    config
+	api    *client.Client
+	json   bool
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+type command func(a *app, args []string) error
+
+var commands = map[string]command{
+	"add":     cmdAdd,
+	"edit":    cmdEdit,
+	"rm":      cmdRemove,
+	"respond": cmdRespond,
+	"slots":   cmdSlots,
+	"day":     cmdPeriod("day"),
+	"week":    cmdPeriod("week"),
+	"month":   cmdPeriod("month"),
+	"export":  cmdExport,
+	"import":  cmdImport,
+}
+
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run разбирает аргументы и выполняет подкоманду. Возвращает код выхода.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("calendarctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() { fmt.Fprint(stderr, usage) }
+
+	configPath := fs.String("config", "", "path to config file")
+	jsonOut := fs.Bool("json", false, "print JSON instead of tables")
+	server := fs.String("server", "", "override server address from config")
+	userID := fs.Int("user", 0, "override user_id from config")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n\n", fs.Arg(0))
+		fs.Usage()
+		return 2
+	}
+
+	path, explicit := *configPath, *configPath != ""
+	if !explicit {
+		path = defaultConfigPath()
+	}
+	cfg, err := loadConfig(path, explicit)
+	if err != nil {
+		fmt.Fprintln(stderr, "Error:", err)
+		return 1
+	}
+	if *server != "" {
+		cfg.Server = *server
+	}
+	if *userID != 0 {
+		cfg.UserID = *userID
+	}
+
+	a := &app{
+		cfg:    cfg,
+		api:    client.New(cfg.Server, cfg.Token, time.Duration(cfg.Timeout)),
+		json:   *jsonOut,
+		stdin:  stdin,
+		stdout: stdout,
+		stderr: stderr,
+	}
+
+	if err := cmd(a, fs.Args()[1:]); err != nil {
+		if errors.Is(err, errUsage) || errors.Is(err, flag.ErrHelp) {
+			return 2
+		}
+		fmt.Fprintln(stderr, "Error:", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,335 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"calendar/internal/domain"
+	"calendar/internal/repository"
+	"calendar/internal/transport"
+	"calendar/internal/usecase"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newServer поднимает настоящий роутер календаря поверх in-memory хранилища.
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	uc := usecase.NewEventUseCase(repository.NewLocalStorage())
+	srv := httptest.NewServer(transport.NewRouter(transport.NewHandler(uc)))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func writeConfig(t *testing.T, server string, userID int, token string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	data := fmt.Sprintf(`{"server": %q, "user_id": %d, "token": %q, "timeout": "5s"}`, server, userID, token)
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+	return path
+}
+
+func runCLI(t *testing.T, stdin string, args ...string) (stdout, stderr string, code int) {
+	t.Helper()
+	var out, errOut bytes.Buffer
+	code = run(args, strings.NewReader(stdin), &out, &errOut)
+	return out.String(), errOut.String(), code
+}
+
+func TestCLI_AddEditRemove(t *testing.T) {
+	srv := newServer(t)
+	cfg := writeConfig(t, srv.URL, 1, "")
+
+	out, stderr, code := runCLI(t, "", "--config", cfg, "--json", "add", "-date", "2026-10-16", "-title", "Standup")
+	require.Equal(t, 0, code, stderr)
+	var created map[string]string
+	require.NoError(t, json.Unmarshal([]byte(out), &created))
+	id := created["id"]
+	require.NotEmpty(t, id)
+
+	out, stderr, code = runCLI(t, "", "--config", cfg, "day", "-date", "2026-10-16")
+	require.Equal(t, 0, code, stderr)
+	require.Contains(t, out, "ID")
+	require.Contains(t, out, id)
+	require.Contains(t, out, "Standup")
+
+	out, stderr, code = runCLI(t, "", "--config", cfg, "edit", "-id", id, "-date", "2026-10-18", "-title", "Retro")
+	require.Equal(t, 0, code, stderr)
+	require.Equal(t, "updated "+id+"\n", out)
+
+	out, stderr, code = runCLI(t, "", "--config", cfg, "week", "-date", "2026-10-16", "--json")
+	require.Equal(t, 0, code, stderr)
+	var events []domain.Event
+	require.NoError(t, json.Unmarshal([]byte(out), &events))
+	require.Len(t, events, 1)
+	require.Equal(t, "Retro", events[0].Title)
+
+	out, stderr, code = runCLI(t, "", "--config", cfg, "rm", id)
+	require.Equal(t, 0, code, stderr)
+	require.Equal(t, "deleted "+id+"\n", out)
+
+	out, stderr, code = runCLI(t, "", "--config", cfg, "month", "2026-10-01")
+	require.Equal(t, 0, code, stderr)
+	require.Equal(t, "no events\n", out)
+
+	_, stderr, code = runCLI(t, "", "--config", cfg, "rm", id)
+	require.Equal(t, 1, code)
+	require.Contains(t, stderr, domain.ErrEventNotFound.Error())
+}
+
+func TestCLI_RelativeDates(t *testing.T) {
+	srv := newServer(t)
+	cfg := writeConfig(t, srv.URL, 1, "")
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1).Format(dateLayout)
+
+	out, stderr, code := runCLI(t, "", "--config", cfg, "--json", "add", "-date", "tomorrow", "-title", "Demo")
+	require.Equal(t, 0, code, stderr)
+	var created map[string]string
+	require.NoError(t, json.Unmarshal([]byte(out), &created))
+	require.Equal(t, tomorrow, created["date"], "server must echo the resolved date")
+
+	out, stderr, code = runCLI(t, "", "--config", cfg, "day", "завтра")
+	require.Equal(t, 0, code, stderr)
+	require.Contains(t, out, "Demo")
+	require.Contains(t, out, tomorrow)
+}
+
+func TestCLI_TagsAndFilters(t *testing.T) {
+	srv := newServer(t)
+	cfg := writeConfig(t, srv.URL, 1, "")
+
+	add := func(title string, extra ...string) {
+		args := append([]string{"--config", cfg, "add", "-date", "2026-10-16", "-title", title}, extra...)
+		_, stderr, code := runCLI(t, "", args...)
+		require.Equal(t, 0, code, stderr)
+	}
+	add("Planning", "-tags", "work,urgent", "-category", "meeting", "-priority", "5")
+	add("Lunch", "-tags", "personal", "-priority", "1")
+	add("Sync", "-tags", "work", "-category", "meeting", "-priority", "2")
+
+	titles := func(args ...string) []string {
+		out, stderr, code := runCLI(t, "", append([]string{"--config", cfg, "--json", "day", "-date", "2026-10-16"}, args...)...)
+		require.Equal(t, 0, code, stderr)
+		var events []domain.Event
+		require.NoError(t, json.Unmarshal([]byte(out), &events))
+		res := []string{}
+		for _, e := range events {
+			res = append(res, e.Title)
+		}
+		sort.Strings(res)
+		return res
+	}
+
+	require.Equal(t, []string{"Planning", "Sync"}, titles("-tags", "work"))
+	require.Equal(t, []string{"Lunch", "Planning"}, titles("-tags", "urgent,personal"))
+	require.Equal(t, []string{"Planning"}, titles("-tags", "work,urgent", "-all-tags"))
+	require.Equal(t, []string{"Planning", "Sync"}, titles("-category", "meeting"))
+	require.Equal(t, []string{"Planning", "Sync"}, titles("-min-priority", "2"))
+
+	out, stderr, code := runCLI(t, "", "--config", cfg, "day", "-date", "2026-10-16", "-tags", "urgent")
+	require.Equal(t, 0, code, stderr)
+	require.Contains(t, out, "meeting")
+	require.Contains(t, out, "work,urgent")
+}
+
+func TestCLI_Invitations(t *testing.T) {
+	srv := newServer(t)
+	owner := writeConfig(t, srv.URL, 1, "")
+	guest := writeConfig(t, srv.URL, 2, "")
+
+	out, stderr, code := runCLI(t, "", "--config", owner, "--json", "add", "-date", "2026-10-16", "-title", "Review", "-attendees", "2,3")
+	require.Equal(t, 0, code, stderr)
+	var created map[string]string
+	require.NoError(t, json.Unmarshal([]byte(out), &created))
+	id := created["id"]
+
+	out, stderr, code = runCLI(t, "", "--config", guest, "day", "2026-10-16")
+	require.Equal(t, 0, code, stderr)
+	require.Contains(t, out, "Review")
+
+	out, stderr, code = runCLI(t, "", "--config", guest, "respond", "-id", id, "-status", "accepted")
+	require.Equal(t, 0, code, stderr)
+	require.Equal(t, "accepted "+id+"\n", out)
+
+	out, stderr, code = runCLI(t, "", "--config", owner, "--json", "day", "2026-10-16")
+	require.Equal(t, 0, code, stderr)
+	var events []domain.Event
+	require.NoError(t, json.Unmarshal([]byte(out), &events))
+	require.Len(t, events, 1)
+	require.Equal(t, []domain.Attendee{
+		{UserID: 2, Status: domain.StatusAccepted},
+		{UserID: 3, Status: domain.StatusNeedsAction},
+	}, events[0].Attendees)
+
+	_, stderr, code = runCLI(t, "", "--config", guest, "rm", id)
+	require.Equal(t, 1, code)
+	require.Contains(t, stderr, "403")
+
+	_, stderr, code = runCLI(t, "", "--config", guest, "respond", "-id", id, "-status", "maybe")
+	require.Equal(t, 1, code)
+	require.Contains(t, stderr, "400")
+}
+
+func TestCLI_CalendarWeek(t *testing.T) {
+	srv := newServer(t)
+	cfg := writeConfig(t, srv.URL, 1, "")
+
+	// 2026-10-12 — понедельник, 2026-10-19 — следующий понедельник.
+	for _, day := range []string{"2026-10-11", "2026-10-12", "2026-10-18", "2026-10-19"} {
+		_, stderr, code := runCLI(t, "", "--config", cfg, "add", "-date", day, "-title", day)
+		require.Equal(t, 0, code, stderr)
+	}
+
+	titles := func(out string) []string {
+		var events []domain.Event
+		require.NoError(t, json.Unmarshal([]byte(out), &events))
+		var res []string
+		for _, e := range events {
+			res = append(res, e.Title)
+		}
+		return res
+	}
+
+	out, stderr, code := runCLI(t, "", "--config", cfg, "--json", "week", "-date", "2026-10-14", "-align", "calendar")
+	require.Equal(t, 0, code, stderr)
+	require.Equal(t, []string{"2026-10-12", "2026-10-18"}, titles(out))
+
+	out, stderr, code = runCLI(t, "", "--config", cfg, "--json", "week", "-date", "2026-10-14", "-align", "calendar", "-week-start", "sunday")
+	require.Equal(t, 0, code, stderr)
+	require.Equal(t, []string{"2026-10-11", "2026-10-12"}, titles(out))
+
+	out, stderr, code = runCLI(t, "", "--config", cfg, "--json", "week", "-date", "2026-10-14")
+	require.Equal(t, 0, code, stderr)
+	require.Equal(t, []string{"2026-10-18", "2026-10-19"}, titles(out))
+
+	_, stderr, code = runCLI(t, "", "--config", cfg, "week", "-tz", "Nowhere/Land")
+	require.Equal(t, 1, code)
+	require.Contains(t, stderr, "400")
+	require.Contains(t, stderr, "unknown time zone")
+}
+
+func TestCLI_ExportImport(t *testing.T) {
+	srv := newServer(t)
+	cfg := writeConfig(t, srv.URL, 1, "")
+
+	for _, day := range []string{"2026-10-03", "2026-10-01", "2026-10-20"} {
+		_, stderr, code := runCLI(t, "", "--config", cfg, "add", "-date", day, "-title", "Event "+day)
+		require.Equal(t, 0, code, stderr)
+	}
+
+	dump := filepath.Join(t.TempDir(), "dump.json")
+	_, stderr, code := runCLI(t, "", "--config", cfg, "export", "-date", "2026-10-01", "-period", "month", "-out", dump)
+	require.Equal(t, 0, code, stderr)
+
+	var exported []domain.Event
+	data, err := os.ReadFile(dump)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &exported))
+	require.Len(t, exported, 3)
+	require.Equal(t, "Event 2026-10-01", exported[0].Title, "export must be sorted by date")
+
+	// Импортируем дамп другому пользователю через stdin.
+	out, stderr, code := runCLI(t, string(data), "--config", cfg, "--user", "2", "--json", "import")
+	require.Equal(t, 0, code, stderr)
+	var results []importResult
+	require.NoError(t, json.Unmarshal([]byte(out), &results))
+	require.Len(t, results, 3)
+	for _, r := range results {
+		require.NotEmpty(t, r.ID)
+		require.Empty(t, r.Error)
+	}
+
+	out, stderr, code = runCLI(t, "", "--config", cfg, "--user", "2", "--json", "month", "-date", "2026-10-01")
+	require.Equal(t, 0, code, stderr)
+	var imported []domain.Event
+	require.NoError(t, json.Unmarshal([]byte(out), &imported))
+	require.Len(t, imported, 3)
+	for _, e := range imported {
+		require.Equal(t, 2, e.UserID)
+	}
+}
+
+func TestCLI_ImportKeepsTimeAndDropsUID(t *testing.T) {
+	srv := newServer(t)
+	cfg := writeConfig(t, srv.URL, 1, "")
+	dump := `[{"title": "standup", "date": "2026-10-16T14:30:00Z", "uid": "standup@example.com"}]`
+
+	_, stderr, code := runCLI(t, dump, "--config", cfg, "import", "-keep-uid")
+	require.Equal(t, 0, code, stderr)
+
+	// UID уже занят: с -keep-uid повторный импорт отклоняется, без него — проходит.
+	_, stderr, code = runCLI(t, dump, "--config", cfg, "import", "-keep-uid")
+	require.Equal(t, 1, code)
+	require.Contains(t, stderr, "1 of 1 events failed to import")
+
+	_, stderr, code = runCLI(t, dump, "--config", cfg, "import")
+	require.Equal(t, 0, code, stderr)
+
+	out, stderr, code := runCLI(t, "", "--config", cfg, "--json", "day", "-date", "2026-10-16")
+	require.Equal(t, 0, code, stderr)
+	var imported []domain.Event
+	require.NoError(t, json.Unmarshal([]byte(out), &imported))
+	require.Len(t, imported, 2)
+	uids := map[string]bool{}
+	for _, e := range imported {
+		require.Equal(t, "14:30", e.Date.Format("15:04"), "import must keep the time of day")
+		uids[e.UID] = true
+	}
+	require.Equal(t, map[string]bool{"standup@example.com": true, "": true}, uids)
+}
+
+func TestCLI_ImportReportsFailures(t *testing.T) {
+	srv := newServer(t)
+	cfg := writeConfig(t, srv.URL, 1, "")
+	srv.Close() // сервер недоступен — каждая строка должна завершиться ошибкой
+
+	dump := `[{"title": "a", "date": "2026-10-16T00:00:00Z"}, {"title": "b", "date": "2026-10-17T00:00:00Z"}]`
+	out, stderr, code := runCLI(t, dump, "--config", cfg, "import")
+	require.Equal(t, 1, code)
+	require.Equal(t, 2, strings.Count(out, "error: network error"))
+	require.Contains(t, stderr, "2 of 2 events failed to import")
+}
+
+func TestCLI_SendsToken(t *testing.T) {
+	var gotAuth string
+	uc := usecase.NewEventUseCase(repository.NewLocalStorage())
+	router := transport.NewRouter(transport.NewHandler(uc))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		router.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	cfg := writeConfig(t, srv.URL, 1, "s3cret")
+	_, stderr, code := runCLI(t, "", "--config", cfg, "day", "-date", "2026-10-16")
+	require.Equal(t, 0, code, stderr)
+	require.Equal(t, "Bearer s3cret", gotAuth)
+}
+
+func TestCLI_Usage(t *testing.T) {
+	_, stderr, code := runCLI(t, "")
+	require.Equal(t, 2, code)
+	require.Contains(t, stderr, "Usage: calendarctl")
+
+	_, stderr, code = runCLI(t, "", "frobnicate")
+	require.Equal(t, 2, code)
+	require.Contains(t, stderr, `unknown command "frobnicate"`)
+
+	cfg := writeConfig(t, "http://127.0.0.1:0", 1, "")
+	_, stderr, code = runCLI(t, "", "--config", cfg, "add", "-date", "2026-10-16")
+	require.Equal(t, 2, code)
+	require.Contains(t, stderr, "-title is required")
+
+	_, stderr, code = runCLI(t, "", "--config", filepath.Join(t.TempDir(), "missing.json"), "day")
+	require.Equal(t, 1, code)
+	require.Contains(t, stderr, "failed to read config")
+}
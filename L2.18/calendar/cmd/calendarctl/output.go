@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"calendar/internal/domain"
+)
+
+func (a *app) writeJSON(v interface{}) error {
+	enc := json.NewEncoder(a.stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// printResult печатает итог команды: JSON при --json, иначе строку text.
+func (a *app) printResult(v interface{}, text string) error {
+	if a.json {
+		return a.writeJSON(v)
+	}
+	_, err := fmt.Fprintln(a.stdout, text)
+	return err
+}
+
+func (a *app) printEvents(events []domain.Event) error {
+	sortEvents(events)
+	if a.json {
+		if events == nil {
+			events = []domain.Event{}
+		}
+		return a.writeJSON(events)
+	}
+
+	if len(events) == 0 {
+		_, err := fmt.Fprintln(a.stdout, "no events")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tDATE\tTITLE\tCATEGORY\tPRIORITY\tTAGS")
+	for _, e := range events {
+		priority := "-"
+		if e.Priority != 0 {
+			priority = strconv.Itoa(e.Priority)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", e.ID, formatDate(e.Date), e.Title,
+			orDash(e.Category), priority, orDash(strings.Join(e.Tags, ",")))
+	}
+	return tw.Flush()
+}
+
+func (a *app) printSlots(slots []domain.Slot) error {
+	if a.json {
+		if slots == nil {
+			slots = []domain.Slot{}
+		}
+		return a.writeJSON(slots)
+	}
+
+	if len(slots) == 0 {
+		_, err := fmt.Fprintln(a.stdout, "no free slots")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "DATE\tSTART\tEND\tSCORE")
+	for _, s := range slots {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\n", s.Start.Format(dateLayout), s.Start.Format("15:04"), s.End.Format("15:04"), s.Score)
+	}
+	return tw.Flush()
+}
+
+func (a *app) printImport(results []importResult) error {
+	if a.json {
+		return a.writeJSON(results)
+	}
+
+	tw := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "DATE\tTITLE\tRESULT")
+	for _, r := range results {
+		status := "created " + r.ID
+		if r.Error != "" {
+			status = "error: " + r.Error
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", r.Date, r.Title, status)
+	}
+	return tw.Flush()
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// sortEvents упорядочивает события по дате, затем по ID — сервер отдаёт их в произвольном порядке.
+func sortEvents(events []domain.Event) {
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].Date.Equal(events[j].Date) {
+			return events[i].Date.Before(events[j].Date)
+		}
+		return events[i].ID < events[j].ID
+	})
+}
@@ -0,0 +1,99 @@
+package accesslog
+
+import (
+	"io"
+	"log"
+	"sync"
+	"sync/atomic"
+)
+
+// Stats — счётчики асинхронного журнала с момента создания.
+type Stats struct {
+	Written uint64 `json:"written"`
+	Dropped uint64 `json:"dropped"` // буфер был полон или журнал уже закрыт
+	Errors  uint64 `json:"errors"`  // ошибки записи в приёмник
+	Pending int    `json:"pending"`
+}
+
+// asyncWriter передаёт строки в приёмник из отдельной горутины через очередь
+// ограниченного размера. Write не блокируется: если очередь полна, строка
+// отбрасывается и учитывается в Stats().Dropped.
+type asyncWriter struct {
+	dst   io.Writer
+	lines chan []byte
+	done  chan struct{}
+
+	mu     sync.RWMutex // Write под RLock, Close под Lock: после закрытия канала в него не пишут
+	closed bool
+
+	written, dropped, errors atomic.Uint64
+}
+
+// NewAsyncWriter запускает запись в dst с очередью на size строк.
+func NewAsyncWriter(dst io.Writer, size int) *asyncWriter {
+	w := &asyncWriter{
+		dst:   dst,
+		lines: make(chan []byte, max(size, 1)),
+		done:  make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// Write ставит p в очередь. Вызывающий (slog.Handler) переиспользует буфер,
+// поэтому строка копируется. Ошибка не возвращается никогда: журнал не должен
+// ломать обработку запроса.
+func (w *asyncWriter) Write(p []byte) (int, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		w.dropped.Add(1)
+		return len(p), nil
+	}
+	select {
+	case w.lines <- append([]byte(nil), p...):
+	default:
+		w.dropped.Add(1)
+	}
+	return len(p), nil
+}
+
+// Close дописывает очередь и закрывает dst, если он io.Closer.
+func (w *asyncWriter) Close() error {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.lines)
+	}
+	w.mu.Unlock()
+	<-w.done
+
+	if c, ok := w.dst.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+func (w *asyncWriter) Stats() Stats {
+	return Stats{
+		Written: w.written.Load(),
+		Dropped: w.dropped.Load(),
+		Errors:  w.errors.Load(),
+		Pending: len(w.lines),
+	}
+}
+
+func (w *asyncWriter) run() {
+	defer close(w.done)
+	for p := range w.lines {
+		if _, err := w.dst.Write(p); err != nil {
+			// В журнал сообщить о его же поломке нельзя — пишем в стандартный лог,
+			// но только о первой ошибке, чтобы не засыпать stderr; остальные — в Stats.
+			if w.errors.Add(1) == 1 {
+				log.Printf("Access log write failed: %v", err)
+			}
+			continue
+		}
+		w.written.Add(1)
+	}
+}
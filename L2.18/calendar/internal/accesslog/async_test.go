@@ -0,0 +1,94 @@
+package accesslog
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+)
+
+// gateWriter блокирует запись, пока не закрыт release.
+type gateWriter struct {
+	release chan struct{}
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	closed  bool
+}
+
+func (g *gateWriter) Write(p []byte) (int, error) {
+	<-g.release
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.buf.Write(p)
+}
+
+func (g *gateWriter) Close() error {
+	g.closed = true
+	return nil
+}
+
+func TestAsyncWriter_DropsWhenFull(t *testing.T) {
+	dst := &gateWriter{release: make(chan struct{})}
+	w := NewAsyncWriter(dst, 2)
+
+	// Первая строка уходит в горутину и застревает в dst, ещё две ждут в очереди,
+	// остальные отбрасываются — Write при этом не блокируется.
+	buf := []byte("line\n")
+	for range 10 {
+		if n, err := w.Write(buf); n != len(buf) || err != nil {
+			t.Fatalf("Write = %d, %v", n, err)
+		}
+		copy(buf, "XXXX") // вызывающий переиспользует буфер
+	}
+	close(dst.release)
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	st := w.Stats()
+	if st.Written+st.Dropped != 10 || st.Written < 2 || st.Written > 3 {
+		t.Errorf("stats = %+v", st)
+	}
+	if !dst.closed {
+		t.Error("destination was not closed")
+	}
+	if got := dst.buf.String(); got[:5] != "line\n" {
+		t.Errorf("first line = %q, the buffer must be copied", got)
+	}
+	if st.Pending != 0 {
+		t.Errorf("pending after Close = %d", st.Pending)
+	}
+}
+
+func TestAsyncWriter_CloseDrainsQueue(t *testing.T) {
+	var dst bytes.Buffer
+	w := NewAsyncWriter(&dst, 100)
+	for range 50 {
+		w.Write([]byte("x"))
+	}
+	w.Close()
+	if dst.Len() != 50 {
+		t.Errorf("written %d bytes, want 50", dst.Len())
+	}
+
+	// После Close строки только считаются потерянными.
+	w.Write([]byte("late"))
+	if st := w.Stats(); st.Written != 50 || st.Dropped != 1 {
+		t.Errorf("stats = %+v", st)
+	}
+	w.Close()
+}
+
+type failWriter struct{}
+
+func (failWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestAsyncWriter_CountsErrors(t *testing.T) {
+	w := NewAsyncWriter(failWriter{}, 10)
+	w.Write([]byte("a"))
+	w.Write([]byte("b"))
+	w.Close()
+	if st := w.Stats(); st.Errors != 2 || st.Written != 0 {
+		t.Errorf("stats = %+v", st)
+	}
+}
@@ -0,0 +1,171 @@
+// Package accesslog — приёмники для журнала запросов: файл с ротацией по размеру
+// и возрасту и асинхронный буфер, который не блокирует обработку запросов.
+package accesslog
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupLayout — метка времени в имени ротированного файла: access-20261018-090000.log.
+const backupLayout = "20060102-150405"
+
+// RotateOptions — когда начинать новый файл. Нулевое значение поля отключает ограничение.
+type RotateOptions struct {
+	MaxSize    int64         // байт в одном файле
+	MaxAge     time.Duration // сколько пишется один файл, считая с его открытия
+	MaxBackups int           // сколько ротированных файлов хранить
+}
+
+// rotatingFile дописывает строки в path. Когда файл превышает MaxSize или пишется
+// дольше MaxAge, он переименовывается в path с меткой времени и открывается заново.
+type rotatingFile struct {
+	path string
+	opts RotateOptions
+	now  func() time.Time
+
+	mu     sync.Mutex
+	f      *os.File
+	size   int64
+	opened time.Time
+}
+
+// NewRotatingFile открывает (или создаёт) файл журнала path вместе с каталогом.
+func NewRotatingFile(path string, opts RotateOptions) (*rotatingFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("create log directory: %w", err)
+	}
+	rf := &rotatingFile{path: path, opts: opts, now: time.Now}
+	if err := rf.open(); err != nil {
+		return nil, err
+	}
+	return rf, nil
+}
+
+// Write пишет p целиком в текущий файл; строка никогда не делится между файлами.
+func (rf *rotatingFile) Write(p []byte) (int, error) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if rf.f == nil {
+		return 0, os.ErrClosed
+	}
+	if rf.due(int64(len(p))) {
+		if err := rf.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := rf.f.Write(p)
+	rf.size += int64(n)
+	return n, err
+}
+
+func (rf *rotatingFile) Close() error {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+	if rf.f == nil {
+		return nil
+	}
+	err := rf.f.Close()
+	rf.f = nil
+	return err
+}
+
+// due сообщает, пора ли начать новый файл перед записью n байт. Пустой файл
+// не ротируется, даже если одна строка больше MaxSize.
+func (rf *rotatingFile) due(n int64) bool {
+	if rf.size == 0 {
+		return false
+	}
+	if rf.opts.MaxSize > 0 && rf.size+n > rf.opts.MaxSize {
+		return true
+	}
+	return rf.opts.MaxAge > 0 && rf.now().Sub(rf.opened) >= rf.opts.MaxAge
+}
+
+func (rf *rotatingFile) open() error {
+	f, err := os.OpenFile(rf.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return fmt.Errorf("open log file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("open log file: %w", err)
+	}
+	rf.f, rf.size, rf.opened = f, info.Size(), rf.now()
+	return nil
+}
+
+func (rf *rotatingFile) rotate() error {
+	if err := rf.f.Close(); err != nil {
+		return fmt.Errorf("rotate log file: %w", err)
+	}
+	rf.f = nil
+	if err := os.Rename(rf.path, rf.backupName()); err != nil {
+		return fmt.Errorf("rotate log file: %w", err)
+	}
+	if err := rf.open(); err != nil {
+		return err
+	}
+	rf.prune()
+	return nil
+}
+
+// backupName — имя для ротированного файла. Если за секунду файлов несколько,
+// к метке добавляется порядковый номер.
+func (rf *rotatingFile) backupName() string {
+	ext := filepath.Ext(rf.path)
+	base := strings.TrimSuffix(rf.path, ext) + "-" + rf.now().Format(backupLayout)
+	name := base + ext
+	for i := 1; ; i++ {
+		if _, err := os.Lstat(name); errors.Is(err, os.ErrNotExist) {
+			return name
+		}
+		name = fmt.Sprintf("%s.%d%s", base, i, ext)
+	}
+}
+
+// prune удаляет самые старые ротированные файлы сверх MaxBackups.
+// Ошибки удаления не мешают писать журнал.
+func (rf *rotatingFile) prune() {
+	if rf.opts.MaxBackups <= 0 {
+		return
+	}
+	dir := filepath.Dir(rf.path)
+	ext := filepath.Ext(rf.path)
+	prefix := strings.TrimSuffix(filepath.Base(rf.path), ext) + "-"
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	stamped := len(prefix) + len(backupLayout)
+	var backups []string
+	for _, e := range entries {
+		name := strings.TrimSuffix(e.Name(), ext)
+		if e.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(e.Name(), ext) || len(name) < stamped {
+			continue
+		}
+		if _, err := time.Parse(backupLayout, name[len(prefix):stamped]); err == nil {
+			backups = append(backups, name)
+		}
+	}
+	if len(backups) <= rf.opts.MaxBackups {
+		return
+	}
+	// Сначала по метке времени, внутри одной секунды — по номеру:
+	// -090000 < -090000.1 < -090000.2 < ... < -090000.10.
+	slices.SortFunc(backups, func(a, b string) int {
+		return cmp.Or(strings.Compare(a[:stamped], b[:stamped]), cmp.Compare(len(a), len(b)), strings.Compare(a, b))
+	})
+	for _, name := range backups[:len(backups)-rf.opts.MaxBackups] {
+		os.Remove(filepath.Join(dir, name+ext))
+	}
+}
@@ -0,0 +1,138 @@
+package accesslog
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func readDir(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	return string(data)
+}
+
+func TestRotatingFile_BySize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "access.log")
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+
+	rf, err := NewRotatingFile(path, RotateOptions{MaxSize: 10})
+	if err != nil {
+		t.Fatalf("NewRotatingFile: %v", err)
+	}
+	rf.now = func() time.Time { return now }
+
+	for _, line := range []string{"aaaa\n", "bbbb\n", "cccc\n", "dddd\n", "eeee\n"} {
+		if _, err := rf.Write([]byte(line)); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := rf.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	want := []string{"access-20261018-090000.1.log", "access-20261018-090000.log", "access.log"}
+	if got := readDir(t, dir); !slices.Equal(got, want) {
+		t.Fatalf("files = %v, want %v", got, want)
+	}
+	if got := readFile(t, filepath.Join(dir, "access-20261018-090000.log")); got != "aaaa\nbbbb\n" {
+		t.Errorf("first backup = %q", got)
+	}
+	if got := readFile(t, filepath.Join(dir, "access-20261018-090000.1.log")); got != "cccc\ndddd\n" {
+		t.Errorf("second backup = %q", got)
+	}
+	if got := readFile(t, path); got != "eeee\n" {
+		t.Errorf("current file = %q", got)
+	}
+}
+
+func TestRotatingFile_ByAge(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "access.log")
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+
+	rf, err := NewRotatingFile(path, RotateOptions{MaxAge: time.Hour})
+	if err != nil {
+		t.Fatalf("NewRotatingFile: %v", err)
+	}
+	rf.now = func() time.Time { return now }
+	rf.opened = now
+	defer rf.Close()
+
+	rf.Write([]byte("first\n"))
+	now = now.Add(59 * time.Minute)
+	rf.Write([]byte("second\n"))
+	now = now.Add(time.Minute)
+	rf.Write([]byte("third\n"))
+
+	if got := readFile(t, filepath.Join(dir, "access-20261018-100000.log")); got != "first\nsecond\n" {
+		t.Errorf("backup = %q", got)
+	}
+	if got := readFile(t, path); got != "third\n" {
+		t.Errorf("current file = %q", got)
+	}
+}
+
+func TestRotatingFile_MaxBackups(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "access.log")
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+
+	// Чужой файл с похожим именем не считается резервной копией.
+	if err := os.WriteFile(filepath.Join(dir, "access-old.log"), nil, 0o640); err != nil {
+		t.Fatal(err)
+	}
+	rf, err := NewRotatingFile(path, RotateOptions{MaxSize: 1, MaxBackups: 2})
+	if err != nil {
+		t.Fatalf("NewRotatingFile: %v", err)
+	}
+	rf.now = func() time.Time { return now }
+	defer rf.Close()
+
+	for i := range 5 {
+		now = now.Add(time.Second)
+		rf.Write([]byte{'0' + byte(i)})
+	}
+
+	want := []string{"access-20261018-090004.log", "access-20261018-090005.log", "access-old.log", "access.log"}
+	if got := readDir(t, dir); !slices.Equal(got, want) {
+		t.Fatalf("files = %v, want %v", got, want)
+	}
+	if got := readFile(t, filepath.Join(dir, "access-20261018-090005.log")); got != "3" {
+		t.Errorf("newest backup = %q", got)
+	}
+}
+
+func TestRotatingFile_AppendsToExisting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "access.log")
+	for _, line := range []string{"one\n", "two\n"} {
+		rf, err := NewRotatingFile(path, RotateOptions{})
+		if err != nil {
+			t.Fatalf("NewRotatingFile: %v", err)
+		}
+		rf.Write([]byte(line))
+		rf.Close()
+	}
+	if got := readFile(t, path); got != "one\ntwo\n" {
+		t.Errorf("file = %q", got)
+	}
+}
@@ -0,0 +1,19 @@
+// Package blob хранит содержимое файлов (вложений) по ключу.
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+// Store — хранилище содержимого. Ключи выдаёт вызывающий; Put с существующим ключом
+// заменяет содержимое. Реализация не должна оставлять частично записанных данных:
+// если чтение r завершилось ошибкой, ключа в хранилище нет.
+type Store interface {
+	Put(ctx context.Context, key string, r io.Reader) (size int64, err error)
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
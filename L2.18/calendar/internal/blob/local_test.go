@@ -0,0 +1,82 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalStore(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewLocalStore(filepath.Join(dir, "blobs"))
+	if err != nil {
+		t.Fatalf("NewLocalStore() error = %v", err)
+	}
+
+	n, err := s.Put(t.Context(), "abc123", strings.NewReader("hello"))
+	if err != nil || n != 5 {
+		t.Fatalf("Put() = %d, %v", n, err)
+	}
+	rc, err := s.Get(t.Context(), "abc123")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	data, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(data) != "hello" {
+		t.Errorf("Get() = %q, want %q", data, "hello")
+	}
+
+	if err := s.Delete(t.Context(), "abc123"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := s.Get(t.Context(), "abc123"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after Delete error = %v, want %v", err, ErrNotFound)
+	}
+	if err := s.Delete(t.Context(), "abc123"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second Delete() error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestLocalStore_RejectsUnsafeKeys(t *testing.T) {
+	s, _ := NewLocalStore(t.TempDir())
+	for _, key := range []string{"", "ab", "../etc/passwd", "a/b/c", `..\x`, "abc.txt"} {
+		if _, err := s.Put(t.Context(), key, strings.NewReader("x")); err == nil {
+			t.Errorf("Put(%q) succeeded, want error", key)
+		}
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("connection reset") }
+
+func TestLocalStore_FailedPutLeavesNothing(t *testing.T) {
+	dir := t.TempDir()
+	s, _ := NewLocalStore(dir)
+
+	r := io.MultiReader(strings.NewReader("partial"), failingReader{})
+	if _, err := s.Put(t.Context(), "abc123", r); err == nil {
+		t.Fatal("Put() succeeded, want error")
+	}
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	if _, err := s.Put(ctx, "def456", strings.NewReader("data")); !errors.Is(err, context.Canceled) {
+		t.Errorf("Put(canceled) error = %v, want %v", err, context.Canceled)
+	}
+
+	var files []string
+	filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if len(files) != 0 {
+		t.Errorf("files left after failed Put: %v", files)
+	}
+}
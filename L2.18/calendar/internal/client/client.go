@@ -0,0 +1,208 @@
+package client
+
+import (
+	"bytes"
+	"calendar/internal/domain"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client — HTTP-клиент к API календаря (см. transport.NewRouter).
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+func New(baseURL, token string, timeout time.Duration) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// APIError — ошибка, которую вернул сервер: problem+json (Code — код из каталога
+// domain) или старый формат {"error": ...}, где Code пуст.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("server returned %d: %s", e.StatusCode, e.Message)
+}
+
+type eventRequest struct {
+	ID     string `json:"id,omitempty"`
+	UserID int    `json:"user_id,omitempty"`
+	Date   string `json:"date,omitempty"`
+	Event  string `json:"event,omitempty"`
+	domain.EventAttrs
+}
+
+type envelope struct {
+	Result json.RawMessage `json:"result"`
+	Date   string          `json:"date"`
+	Error  string          `json:"error"`
+}
+
+// CreateEvent создаёт событие и возвращает его ID и дату, как её понял сервер
+// (date может быть относительной: «tomorrow», «завтра»).
+func (c *Client) CreateEvent(userID int, date, title string, attrs domain.EventAttrs) (id, resolved string, err error) {
+	req := eventRequest{UserID: userID, Date: date, Event: title, EventAttrs: attrs}
+
+	var res struct {
+		ID string `json:"id"`
+	}
+	env, err := c.do(http.MethodPost, "/create_event", nil, req, &res)
+	if err != nil {
+		return "", "", err
+	}
+	return res.ID, env.Date, nil
+}
+
+func (c *Client) UpdateEvent(id string, userID int, date, title string, attrs domain.EventAttrs) error {
+	req := eventRequest{ID: id, UserID: userID, Date: date, Event: title, EventAttrs: attrs}
+	_, err := c.do(http.MethodPost, "/update_event", nil, req, nil)
+	return err
+}
+
+func (c *Client) DeleteEvent(id string, userID int) error {
+	req := eventRequest{ID: id, UserID: userID}
+	_, err := c.do(http.MethodPost, "/delete_event", nil, req, nil)
+	return err
+}
+
+// RespondToInvitation отправляет ответ участника на приглашение.
+func (c *Client) RespondToInvitation(id string, userID int, status domain.AttendeeStatus) error {
+	req := struct {
+		ID     string                `json:"id"`
+		UserID int                   `json:"user_id"`
+		Status domain.AttendeeStatus `json:"status"`
+	}{id, userID, status}
+	_, err := c.do(http.MethodPost, "/respond_invitation", nil, req, nil)
+	return err
+}
+
+// FindFreeSlots возвращает лучшие интервалы, когда свободны все пользователи запроса.
+func (c *Client) FindFreeSlots(q domain.SlotQuery) ([]domain.Slot, error) {
+	var slots []domain.Slot
+	if _, err := c.do(http.MethodPost, "/find_slots", nil, q, &slots); err != nil {
+		return nil, err
+	}
+	return slots, nil
+}
+
+func (c *Client) EventsForDay(userID int, date string, opts domain.PeriodOptions, filter domain.EventFilter) ([]domain.Event, error) {
+	return c.events("/events_for_day", userID, date, opts, filter)
+}
+
+func (c *Client) EventsForWeek(userID int, date string, opts domain.PeriodOptions, filter domain.EventFilter) ([]domain.Event, error) {
+	return c.events("/events_for_week", userID, date, opts, filter)
+}
+
+func (c *Client) EventsForMonth(userID int, date string, opts domain.PeriodOptions, filter domain.EventFilter) ([]domain.Event, error) {
+	return c.events("/events_for_month", userID, date, opts, filter)
+}
+
+func (c *Client) events(path string, userID int, date string, opts domain.PeriodOptions, filter domain.EventFilter) ([]domain.Event, error) {
+	q := url.Values{}
+	q.Set("user_id", strconv.Itoa(userID))
+	q.Set("date", date)
+	setIfNotEmpty(q, "align", opts.Align)
+	setIfNotEmpty(q, "week_start", opts.WeekStart)
+	setIfNotEmpty(q, "tz", opts.TimeZone)
+	setIfNotEmpty(q, "tags", strings.Join(filter.Tags, ","))
+	setIfNotEmpty(q, "category", filter.Category)
+	if filter.MatchAll {
+		q.Set("tag_match", "all")
+	}
+	if filter.MinPriority != 0 {
+		q.Set("min_priority", strconv.Itoa(filter.MinPriority))
+	}
+
+	var events []domain.Event
+	if _, err := c.do(http.MethodGet, path, q, nil, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func setIfNotEmpty(q url.Values, key, value string) {
+	if value != "" {
+		q.Set(key, value)
+	}
+}
+
+// do выполняет запрос и распаковывает поле result ответа в out (если out != nil).
+func (c *Client) do(method, path string, query url.Values, body, out interface{}) (*envelope, error) {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, u, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("network error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 && strings.HasPrefix(resp.Header.Get("Content-Type"), "application/problem+json") {
+		var p struct {
+			Code   string `json:"code"`
+			Detail string `json:"detail"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&p); err != nil {
+			return nil, &APIError{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+		}
+		return nil, &APIError{StatusCode: resp.StatusCode, Code: p.Code, Message: p.Detail}
+	}
+
+	var env envelope
+	if err := json.NewDecoder(resp.Body).Decode(&env); err != nil {
+		if resp.StatusCode >= 400 {
+			return nil, &APIError{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+		}
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 || env.Error != "" {
+		return nil, &APIError{StatusCode: resp.StatusCode, Message: env.Error}
+	}
+
+	if out == nil || len(env.Result) == 0 {
+		return &env, nil
+	}
+	if err := json.Unmarshal(env.Result, out); err != nil {
+		return nil, fmt.Errorf("failed to decode result: %w", err)
+	}
+	return &env, nil
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// Agenda — события пользователя за период, сгруппированные по дням.
+// Days содержит каждый день периода, в том числе дни без событий.
+type Agenda struct {
+	UserID int         `json:"user_id"`
+	From   time.Time   `json:"from"`
+	To     time.Time   `json:"to"`
+	Days   []AgendaDay `json:"days"`
+}
+
+type AgendaDay struct {
+	Date    time.Time `json:"date"`
+	Kind    DayKind   `json:"kind"`              // по производственному календарю
+	Holiday string    `json:"holiday,omitempty"` // название праздника или переноса
+	Events  []Event   `json:"events"`
+}
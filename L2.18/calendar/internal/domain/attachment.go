@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// Attachment — файл, приложенный к событию (повестка, слайды). Содержимое лежит
+// в хранилище файлов под ключом ID, здесь — только описание.
+type Attachment struct {
+	ID          string    `json:"id"`
+	EventID     string    `json:"event_id"`
+	Name        string    `json:"name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	UploadedBy  int       `json:"uploaded_by"`
+	UploadedAt  time.Time `json:"uploaded_at"`
+}
@@ -0,0 +1,40 @@
+package domain
+
+// AttendeeStatus — ответ участника на приглашение.
+type AttendeeStatus string
+
+const (
+	StatusNeedsAction AttendeeStatus = "needs-action"
+	StatusAccepted    AttendeeStatus = "accepted"
+	StatusDeclined    AttendeeStatus = "declined"
+	StatusTentative   AttendeeStatus = "tentative"
+)
+
+func (s AttendeeStatus) Valid() bool {
+	switch s {
+	case StatusNeedsAction, StatusAccepted, StatusDeclined, StatusTentative:
+		return true
+	}
+	return false
+}
+
+// Attendee — приглашённый пользователь. Организатор события (Event.UserID) в списке не состоит.
+type Attendee struct {
+	UserID int            `json:"user_id"`
+	Status AttendeeStatus `json:"status"`
+}
+
+// Виды уведомлений участникам.
+const (
+	NotifyInvited   = "invited"   // пользователя добавили в участники
+	NotifyUpdated   = "updated"   // организатор изменил событие
+	NotifyCancelled = "cancelled" // организатор удалил событие
+	NotifyResponded = "responded" // участник ответил на приглашение (уходит организатору)
+)
+
+// Notification — сообщение об изменении встречи для одного пользователя.
+type Notification struct {
+	Kind  string `json:"kind"`
+	Event Event  `json:"event"`
+	From  int    `json:"from"` // кто совершил изменение
+}
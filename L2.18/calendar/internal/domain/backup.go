@@ -0,0 +1,13 @@
+package domain
+
+// Snapshot — всё содержимое хранилища событий на один момент: живые события и корзина.
+type Snapshot struct {
+	Events  []Event
+	Deleted []DeletedEvent
+}
+
+// BackupSummary — сколько событий попало в дамп или загружено из него.
+type BackupSummary struct {
+	Events  int `json:"events"`
+	Deleted int `json:"deleted"`
+}
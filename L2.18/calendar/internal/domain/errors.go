@@ -2,8 +2,79 @@ package domain
 
 import "errors"
 
+// ErrorKind — класс ошибки; по нему транспорт выбирает код ответа.
+type ErrorKind int
+
+const (
+	KindInternal ErrorKind = iota
+	KindInvalid
+	KindNotFound
+	KindForbidden
+	KindTimeout
+	KindCanceled
+	KindTooLarge
+	KindUnsupportedType
+	KindQuotaExceeded
+	KindUnprocessable
+	KindConflict
+)
+
+// Error — ошибка из каталога. Code — стабильный машинно-читаемый код, часть API:
+// клиенты различают ошибки по нему, поэтому коды не переименовываются.
+// Message — короткое описание для человека; подробности добавляются обёрткой через %w.
+type Error struct {
+	Code    string
+	Kind    ErrorKind
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
 var (
-	ErrEventNotFound = errors.New("event not found")
-	ErrDateInvalid   = errors.New("date parameter is invalid or missing")
-	ErrOwnerMismatch = errors.New("user does not own this event")
-)
\ No newline at end of file
+	ErrEventNotFound    = &Error{Code: "event_not_found", Kind: KindNotFound, Message: "event not found"}
+	ErrDateInvalid      = &Error{Code: "date_invalid", Kind: KindInvalid, Message: "date parameter is invalid or missing"}
+	ErrOwnerMismatch    = &Error{Code: "owner_mismatch", Kind: KindForbidden, Message: "user does not own this event"}
+	ErrPeriodInvalid    = &Error{Code: "period_invalid", Kind: KindInvalid, Message: "period options are invalid"}
+	ErrAttrsInvalid     = &Error{Code: "attrs_invalid", Kind: KindInvalid, Message: "event attributes are invalid"}
+	ErrNotInvited       = &Error{Code: "not_invited", Kind: KindForbidden, Message: "user is not invited to this event"}
+	ErrStatusInvalid    = &Error{Code: "status_invalid", Kind: KindInvalid, Message: "invitation status is invalid"}
+	ErrSlotQueryInvalid = &Error{Code: "slot_query_invalid", Kind: KindInvalid, Message: "free slot query is invalid"}
+
+	ErrAttachmentNotFound = &Error{Code: "attachment_not_found", Kind: KindNotFound, Message: "attachment not found"}
+	ErrAttachmentTooLarge = &Error{Code: "attachment_too_large", Kind: KindTooLarge, Message: "attachment is too large"}
+	ErrAttachmentType     = &Error{Code: "attachment_type_unsupported", Kind: KindUnsupportedType, Message: "attachment type is not allowed"}
+
+	ErrEventQuota   = &Error{Code: "event_quota_exceeded", Kind: KindQuotaExceeded, Message: "user has too many events"}
+	ErrDailyQuota   = &Error{Code: "daily_event_quota_exceeded", Kind: KindQuotaExceeded, Message: "user has too many events on this day"}
+	ErrTitleTooLong = &Error{Code: "title_too_long", Kind: KindUnprocessable, Message: "event title is too long"}
+	ErrUIDConflict  = &Error{Code: "uid_conflict", Kind: KindConflict, Message: "user already has an event with this UID"}
+
+	ErrAdminRequired = &Error{Code: "admin_required", Kind: KindForbidden, Message: "admin token is missing or invalid"}
+	ErrBackupInvalid = &Error{Code: "backup_invalid", Kind: KindInvalid, Message: "backup is invalid"}
+	ErrImportInvalid = &Error{Code: "import_invalid", Kind: KindInvalid, Message: "import file is invalid"}
+
+	ErrShiftInvalid = &Error{Code: "shift_invalid", Kind: KindInvalid, Message: "holiday shift must be next or previous"}
+	ErrDaysInvalid  = &Error{Code: "days_invalid", Kind: KindInvalid, Message: "working day count is out of range"}
+
+	// Ошибки уровня запроса, не связанные с конкретной операцией.
+	ErrRequestInvalid = &Error{Code: "request_invalid", Kind: KindInvalid, Message: "request is invalid"}
+	ErrTimeout        = &Error{Code: "timeout", Kind: KindTimeout, Message: "request deadline exceeded"}
+	ErrCanceled       = &Error{Code: "canceled", Kind: KindCanceled, Message: "request canceled by client"}
+	ErrInternal       = &Error{Code: "internal", Kind: KindInternal, Message: "internal error"}
+)
+
+// Catalogue — все ошибки каталога, например для документации API.
+var Catalogue = []*Error{
+	ErrEventNotFound, ErrDateInvalid, ErrOwnerMismatch, ErrPeriodInvalid, ErrAttrsInvalid,
+	ErrNotInvited, ErrStatusInvalid, ErrSlotQueryInvalid, ErrAttachmentNotFound, ErrAttachmentTooLarge, ErrAttachmentType,
+	ErrEventQuota, ErrDailyQuota, ErrTitleTooLong, ErrUIDConflict, ErrAdminRequired, ErrBackupInvalid, ErrImportInvalid, ErrShiftInvalid, ErrDaysInvalid,
+	ErrRequestInvalid, ErrTimeout, ErrCanceled, ErrInternal,
+}
+
+// AsError находит ошибку каталога в цепочке err. Для ошибок вне каталога ok = false.
+func AsError(err error) (e *Error, ok bool) {
+	ok = errors.As(err, &e)
+	return e, ok
+}
@@ -7,4 +7,87 @@ type Event struct {
 	UserID int       `json:"user_id"`
 	Title  string    `json:"title"`
 	Date   time.Time `json:"date"`
-}
\ No newline at end of file
+	EventAttrs
+}
+
+// EventAttrs — необязательные атрибуты события, задаваемые при создании и изменении.
+type EventAttrs struct {
+	Tags     []string `json:"tags,omitempty"`
+	Category string   `json:"category,omitempty"`
+	Priority int      `json:"priority,omitempty"` // 0 — без приоритета, чем больше, тем важнее
+	Duration int      `json:"duration,omitempty"` // длительность в минутах, 0 — не задана
+	UID      string   `json:"uid,omitempty"`      // iCalendar UID, под ним событие видят CalDAV-клиенты
+
+	// Shift переносит событие с нерабочего дня (ShiftNext или ShiftPrevious).
+	// Применяется к дате при создании и изменении и в событии не хранится.
+	Shift string `json:"shift,omitempty"`
+
+	// Attendees — приглашённые пользователи. При создании и изменении статус,
+	// присланный клиентом, игнорируется: им управляет только сам участник.
+	Attendees []Attendee `json:"attendees,omitempty"`
+}
+
+// HasAttendee сообщает, приглашён ли пользователь на событие.
+func (e Event) HasAttendee(userID int) bool {
+	for _, a := range e.Attendees {
+		if a.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxDuration — наибольшая длительность события в минутах: год с запасом на високосный.
+// Событие, начавшееся раньше интервала, может пересекать его, только если началось
+// не раньше чем за MaxDuration минут до него.
+const MaxDuration = 366 * 24 * 60
+
+// DefaultDuration — сколько считается занятым событие со временем, но без длительности.
+const DefaultDuration = time.Hour
+
+// Span возвращает интервал, который событие занимает в календаре. Событие ровно
+// в полночь без длительности считается событием на весь день, как и в повестке.
+func (e Event) Span() (start, end time.Time) {
+	if e.Duration > 0 {
+		return e.Date, e.Date.Add(time.Duration(e.Duration) * time.Minute)
+	}
+	if e.Date.Hour() == 0 && e.Date.Minute() == 0 && e.Date.Second() == 0 {
+		y, m, d := e.Date.Date()
+		return e.Date, time.Date(y, m, d+1, 0, 0, 0, 0, e.Date.Location())
+	}
+	return e.Date, e.Date.Add(DefaultDuration)
+}
+
+// EventFilter ограничивает выборку событий. Пустой фильтр пропускает всё.
+type EventFilter struct {
+	Tags        []string // пусто — теги не важны
+	MatchAll    bool     // true — нужны все Tags, false — хотя бы один
+	Category    string
+	MinPriority int
+}
+
+func (f EventFilter) Match(e Event) bool {
+	if f.Category != "" && e.Category != f.Category {
+		return false
+	}
+	if e.Priority < f.MinPriority {
+		return false
+	}
+	if len(f.Tags) == 0 {
+		return true
+	}
+
+	has := make(map[string]bool, len(e.Tags))
+	for _, t := range e.Tags {
+		has[t] = true
+	}
+	for _, t := range f.Tags {
+		if has[t] && !f.MatchAll {
+			return true
+		}
+		if !has[t] && f.MatchAll {
+			return false
+		}
+	}
+	return f.MatchAll
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// DayKind — вид дня в производственном календаре.
+type DayKind string
+
+const (
+	DayWorking DayKind = "working"
+	DayShort   DayKind = "short"   // предпраздничный сокращённый рабочий день
+	DayWeekend DayKind = "weekend" // выходной, в том числе перенесённый
+	DayHoliday DayKind = "holiday" // нерабочий праздничный день
+)
+
+// Working сообщает, рабочий ли это день (сокращённый тоже рабочий).
+func (k DayKind) Working() bool {
+	return k == DayWorking || k == DayShort
+}
+
+// CalendarDay — день производственного календаря. Name — название праздника
+// или причина переноса, если есть.
+type CalendarDay struct {
+	Date time.Time `json:"date"`
+	Kind DayKind   `json:"kind"`
+	Name string    `json:"name,omitempty"`
+}
+
+// Направления сдвига события с нерабочего дня.
+const (
+	ShiftNone     = ""
+	ShiftNext     = "next"     // на ближайший следующий рабочий день
+	ShiftPrevious = "previous" // на ближайший предыдущий рабочий день
+)
@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+// Форматы импорта.
+const (
+	ImportCSV  = "csv"
+	ImportJSON = "json"
+)
+
+// ImportOptions — как читать импортируемый файл.
+type ImportOptions struct {
+	Format string // ImportCSV или ImportJSON
+	// Columns сопоставляет полям события (date, title, tags, category, priority,
+	// duration, attendees, uid, shift) заголовки столбцов CSV. Поле без сопоставления
+	// ищется в столбце со своим именем.
+	Columns   map[string]string
+	Delimiter rune // разделитель CSV, по умолчанию запятая
+	DryRun    bool // только проверить строки, ничего не создавая
+}
+
+// ImportResult — итог одной строки. Row считается с 1 без строки заголовка CSV.
+type ImportResult struct {
+	Row   int       `json:"row"`
+	OK    bool      `json:"ok"`
+	ID    string    `json:"id,omitempty"` // пусто при пробном импорте
+	Date  time.Time `json:"date,omitzero"`
+	Code  string    `json:"code,omitempty"` // код ошибки из каталога
+	Error string    `json:"error,omitempty"`
+}
+
+// ImportReport — итог импорта целиком.
+type ImportReport struct {
+	DryRun    bool           `json:"dry_run"`
+	Total     int            `json:"total"`
+	Succeeded int            `json:"succeeded"`
+	Failed    int            `json:"failed"`
+	Rows      []ImportResult `json:"rows"`
+}
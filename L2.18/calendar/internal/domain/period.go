@@ -0,0 +1,29 @@
+package domain
+
+// Способы выравнивания периода для недельных и месячных запросов.
+const (
+	AlignRolling  = "rolling"  // скользящее окно: date..date+7 дней / date..date+1 месяц
+	AlignCalendar = "calendar" // календарная неделя (с учётом начала недели) или календарный месяц
+)
+
+// PeriodOptions задаёт, как интерпретировать дату запроса.
+// Пустые поля означают «взять из настроек пользователя, иначе по умолчанию»:
+// rolling, неделя с понедельника (ISO), UTC.
+type PeriodOptions struct {
+	Align     string `json:"align,omitempty"`
+	WeekStart string `json:"week_start,omitempty"` // monday..sunday или iso
+	TimeZone  string `json:"time_zone,omitempty"`  // имя зоны IANA, например Europe/Moscow
+}
+
+// UserSettings — настройки пользователя, применяемые к его запросам по умолчанию.
+type UserSettings struct {
+	UserID int `json:"user_id"`
+	PeriodOptions
+}
+
+// Виды периодов для повестки.
+const (
+	PeriodDay   = "day"
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+)
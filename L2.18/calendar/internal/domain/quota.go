@@ -0,0 +1,20 @@
+package domain
+
+// Quota — ограничения на одного пользователя. Ноль — без ограничения.
+type Quota struct {
+	MaxEvents       int `json:"max_events"`         // событий, которые пользователь организует
+	MaxEventsPerDay int `json:"max_events_per_day"` // из них на один день
+	MaxTitleLength  int `json:"max_title_length"`   // в символах
+}
+
+// UserUsage — сколько событий сейчас организует пользователь (без корзины).
+type UserUsage struct {
+	UserID int `json:"user_id"`
+	Events int `json:"events"`
+}
+
+// UsageReport — квоты и их текущее использование, самые активные пользователи первыми.
+type UsageReport struct {
+	Quota Quota       `json:"quota"`
+	Users []UserUsage `json:"users"`
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// SlotQuery — запрос на поиск времени, когда свободны все участники.
+// Первый пользователь в UserIDs считается организатором: его настройки задают часовой пояс по умолчанию.
+type SlotQuery struct {
+	UserIDs  []int    `json:"user_ids"`
+	Duration int      `json:"duration"`             // длительность встречи в минутах
+	From     string   `json:"from"`                 // первый день окна поиска (YYYY-MM-DD или относительная дата)
+	To       string   `json:"to"`                   // последний день окна включительно
+	Start    string   `json:"work_start,omitempty"` // начало рабочего дня, "09:00" по умолчанию
+	End      string   `json:"work_end,omitempty"`   // конец рабочего дня, "18:00" по умолчанию
+	Workdays []string `json:"workdays,omitempty"`   // рабочие дни недели, по умолчанию пн–пт
+	TimeZone string   `json:"time_zone,omitempty"`
+	Step     int      `json:"step,omitempty"`  // шаг начала слотов в минутах, 30 по умолчанию
+	Limit    int      `json:"limit,omitempty"` // сколько лучших слотов вернуть, 10 по умолчанию
+}
+
+// Slot — найденный интервал. Score — чем больше, тем лучше: слоты с запасом
+// свободного времени до и после ценятся выше, чем зажатые между встречами.
+type Slot struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Score int       `json:"score"`
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// EventStats — сводка по событиям пользователя за период. Время — в минутах.
+// Booked — сумма длительностей событий со временем (без длительности считается
+// DefaultDuration); Busy — то же, но пересекающиеся события учтены один раз.
+// События на весь день занятым временем не считаются и идут отдельно в AllDay.
+type EventStats struct {
+	UserID        int            `json:"user_id"`
+	From          time.Time      `json:"from"`
+	To            time.Time      `json:"to"`
+	Events        int            `json:"events"`
+	AllDay        int            `json:"all_day"`
+	BookedMinutes int            `json:"booked_minutes"`
+	BusyMinutes   int            `json:"busy_minutes"`
+	Days          []DayStats     `json:"days"`         // каждый день периода, в том числе пустые
+	Weekdays      []WeekdayStats `json:"weekdays"`     // семь дней, начиная с начала недели пользователя
+	BusiestDays   []DayStats     `json:"busiest_days"` // самые загруженные дни, по убыванию
+	Tags          []GroupStats   `json:"tags"`
+	Categories    []GroupStats   `json:"categories"`
+}
+
+type DayStats struct {
+	Date          time.Time `json:"date"`
+	Events        int       `json:"events"`
+	BookedMinutes int       `json:"booked_minutes"`
+}
+
+type WeekdayStats struct {
+	Weekday       string `json:"weekday"`
+	Events        int    `json:"events"`
+	BookedMinutes int    `json:"booked_minutes"`
+}
+
+// GroupStats — события с одним тегом или одной категорией.
+type GroupStats struct {
+	Name          string `json:"name"`
+	Events        int    `json:"events"`
+	BookedMinutes int    `json:"booked_minutes"`
+}
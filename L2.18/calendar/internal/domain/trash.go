@@ -0,0 +1,10 @@
+package domain
+
+import "time"
+
+// DeletedEvent — событие в корзине организатора. Его можно восстановить,
+// пока оно не пролежало там дольше срока хранения.
+type DeletedEvent struct {
+	Event
+	DeletedAt time.Time `json:"deleted_at"`
+}
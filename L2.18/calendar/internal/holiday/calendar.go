@@ -0,0 +1,124 @@
+// Package holiday — производственные календари: выходные, праздники, переносы
+// и сокращённые дни, а также арифметика рабочих дней.
+package holiday
+
+import (
+	"calendar/internal/domain"
+	"time"
+)
+
+// date — день без времени и часового пояса; ключ календаря.
+type date struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func dateOf(t time.Time) date {
+	y, m, d := t.Date()
+	return date{y, m, d}
+}
+
+// entry — явно заданный день календаря.
+type entry struct {
+	kind domain.DayKind
+	name string
+}
+
+// annual — праздник, повторяющийся каждый год в один и тот же день.
+type annual struct {
+	month time.Month
+	day   int
+	name  string
+}
+
+// Calendar — производственный календарь. Явно заданные дни важнее всего; для годов,
+// по которым явных данных нет, действуют ежегодные праздники. Остальные дни
+// определяются днями недели. Calendar не меняется после создания, его можно
+// использовать из нескольких горутин.
+type Calendar struct {
+	name    string
+	weekend [7]bool
+	days    map[date]entry
+	years   map[int]bool // годы с полными явными данными — ежегодные праздники к ним не применяются
+	annual  []annual
+}
+
+// Weekends — календарь без праздников: выходные суббота и воскресенье.
+func Weekends() *Calendar {
+	return &Calendar{
+		name:    "weekends",
+		weekend: [7]bool{time.Saturday: true, time.Sunday: true},
+		days:    map[date]entry{},
+		years:   map[int]bool{},
+	}
+}
+
+func (c *Calendar) Name() string { return c.name }
+
+// Day возвращает вид дня, на который приходится t (в часовом поясе t).
+func (c *Calendar) Day(t time.Time) domain.CalendarDay {
+	y, m, d := t.Date()
+	day := domain.CalendarDay{Date: time.Date(y, m, d, 0, 0, 0, 0, t.Location()), Kind: domain.DayWorking}
+
+	if e, ok := c.days[date{y, m, d}]; ok {
+		day.Kind, day.Name = e.kind, e.name
+		return day
+	}
+	if !c.years[y] {
+		for _, a := range c.annual {
+			if a.month == m && a.day == d {
+				day.Kind, day.Name = domain.DayHoliday, a.name
+				return day
+			}
+		}
+	}
+	if c.weekend[t.Weekday()] {
+		day.Kind = domain.DayWeekend
+	}
+	return day
+}
+
+// IsWorkingDay сообщает, рабочий ли день t (сокращённый тоже рабочий).
+func (c *Calendar) IsWorkingDay(t time.Time) bool {
+	return c.Day(t).Kind.Working()
+}
+
+// AddWorkingDays сдвигает t на n рабочих дней вперёд (n < 0 — назад), сохраняя
+// время суток. Сам t не считается: пятница + 1 — это понедельник. При n = 0
+// возвращается t, если он рабочий, иначе ближайший следующий рабочий день.
+func (c *Calendar) AddWorkingDays(t time.Time, n int) time.Time {
+	if n == 0 {
+		return c.nextWorking(t, 1)
+	}
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for n > 0 {
+		t = t.AddDate(0, 0, step)
+		if c.IsWorkingDay(t) {
+			n--
+		}
+	}
+	return t
+}
+
+// Shift переносит t с нерабочего дня на ближайший рабочий в направлении dir
+// (domain.ShiftNext или domain.ShiftPrevious). Рабочий день не меняется.
+func (c *Calendar) Shift(t time.Time, dir string) time.Time {
+	if dir == domain.ShiftPrevious {
+		return c.nextWorking(t, -1)
+	}
+	return c.nextWorking(t, 1)
+}
+
+// nextWorking возвращает t, если он рабочий, иначе идёт по дням с шагом step.
+// Явных нерабочих дней конечное число, а выходных меньше семи в неделе,
+// поэтому цикл всегда завершается.
+func (c *Calendar) nextWorking(t time.Time, step int) time.Time {
+	for !c.IsWorkingDay(t) {
+		t = t.AddDate(0, 0, step)
+	}
+	return t
+}
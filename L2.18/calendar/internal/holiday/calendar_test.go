@@ -0,0 +1,91 @@
+package holiday
+
+import (
+	"testing"
+	"time"
+
+	"calendar/internal/domain"
+)
+
+func day(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestRU_Day(t *testing.T) {
+	ru := RU()
+	tests := []struct {
+		date time.Time
+		kind domain.DayKind
+	}{
+		{day(2026, time.January, 1), domain.DayHoliday},
+		{day(2026, time.January, 9), domain.DayWeekend}, // перенос с 3 января
+		{day(2026, time.January, 12), domain.DayWorking},
+		{day(2026, time.March, 9), domain.DayWeekend},
+		{day(2026, time.April, 30), domain.DayShort},
+		{day(2026, time.May, 9), domain.DayHoliday}, // суббота, но праздник
+		{day(2026, time.October, 17), domain.DayWeekend},
+		{day(2026, time.October, 19), domain.DayWorking},
+		{day(2025, time.November, 1), domain.DayShort}, // рабочая суббота
+		{day(2030, time.June, 12), domain.DayHoliday},  // год без переносов — только праздники
+		{day(2030, time.June, 13), domain.DayWorking},
+	}
+	for _, tt := range tests {
+		if got := ru.Day(tt.date); got.Kind != tt.kind {
+			t.Errorf("Day(%s) = %s, want %s", tt.date.Format(time.DateOnly), got.Kind, tt.kind)
+		}
+	}
+	if got := ru.Day(day(2026, time.June, 12)).Name; got != "День России" {
+		t.Errorf("June 12 name = %q", got)
+	}
+}
+
+func TestDay_KeepsLocation(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 22:00 UTC 31 декабря — уже 1 января в Москве.
+	got := RU().Day(time.Date(2025, time.December, 31, 22, 0, 0, 0, time.UTC).In(moscow))
+	if want := time.Date(2026, time.January, 1, 0, 0, 0, 0, moscow); !got.Date.Equal(want) || got.Kind != domain.DayHoliday {
+		t.Errorf("Day = %+v, want holiday on %s", got, want)
+	}
+}
+
+func TestAddWorkingDays(t *testing.T) {
+	ru := RU()
+	at := func(m time.Month, d, h int) time.Time { return time.Date(2026, m, d, h, 30, 0, 0, time.UTC) }
+	tests := []struct {
+		from time.Time
+		n    int
+		want time.Time
+	}{
+		{at(time.October, 16, 10), 1, at(time.October, 19, 10)},                   // пятница + 1 = понедельник
+		{at(time.October, 19, 10), -1, at(time.October, 16, 10)},                  // и обратно
+		{at(time.October, 19, 10), 0, at(time.October, 19, 10)},                   // рабочий день не меняется
+		{at(time.October, 18, 10), 0, at(time.October, 19, 10)},                   // выходной — на следующий рабочий
+		{at(time.December, 30, 9), 1, at(time.January, 11, 9).AddDate(1, 0, 0)},   // 31.12 перенесён, 1–8 января праздники, 9–10 выходные
+		{at(time.May, 7, 9), 2, at(time.May, 12, 9)},                              // 8 мая сокращённый, 9–11 нерабочие
+		{at(time.January, 12, 9), -1, at(time.December, 30, 9).AddDate(-1, 0, 0)}, // 31.12.2025 нерабочий
+	}
+	for _, tt := range tests {
+		if got := ru.AddWorkingDays(tt.from, tt.n); !got.Equal(tt.want) {
+			t.Errorf("AddWorkingDays(%s, %d) = %s, want %s", tt.from, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestShift(t *testing.T) {
+	ru := RU()
+	sunday := time.Date(2026, time.March, 8, 15, 0, 0, 0, time.UTC)
+
+	if got, want := ru.Shift(sunday, domain.ShiftNext), time.Date(2026, time.March, 10, 15, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Shift(next) = %s, want %s", got, want)
+	}
+	if got, want := ru.Shift(sunday, domain.ShiftPrevious), time.Date(2026, time.March, 6, 15, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Shift(previous) = %s, want %s", got, want)
+	}
+	tuesday := time.Date(2026, time.March, 10, 15, 0, 0, 0, time.UTC)
+	if got := ru.Shift(tuesday, domain.ShiftPrevious); !got.Equal(tuesday) {
+		t.Errorf("working day moved to %s", got)
+	}
+}
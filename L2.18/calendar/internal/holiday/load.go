@@ -0,0 +1,234 @@
+package holiday
+
+import (
+	"bufio"
+	"calendar/internal/domain"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Load возвращает календарь по описанию из флага: "ru" — встроенный российский,
+// "weekends" — только субботы и воскресенья, иначе путь к файлу .json или .ics.
+func Load(spec string) (*Calendar, error) {
+	switch strings.ToLower(spec) {
+	case "ru":
+		return RU(), nil
+	case "weekends", "none", "":
+		return Weekends(), nil
+	}
+
+	f, err := os.Open(spec)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var c *Calendar
+	switch ext := strings.ToLower(filepath.Ext(spec)); ext {
+	case ".json":
+		c, err = LoadJSON(f)
+	case ".ics", ".ical":
+		c, err = LoadICS(f)
+	default:
+		return nil, fmt.Errorf("holiday calendar %s: unknown format %q (want .json or .ics)", spec, ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("holiday calendar %s: %w", spec, err)
+	}
+	if c.name == "" {
+		c.name = strings.TrimSuffix(filepath.Base(spec), filepath.Ext(spec))
+	}
+	return c, nil
+}
+
+// jsonCalendar — формат файла календаря:
+//
+//	{"name": "ru-2027", "weekend": ["saturday", "sunday"],
+//	 "days": [{"date": "2027-01-01", "kind": "holiday", "name": "Новый год"}, ...]}
+//
+// weekend необязателен (по умолчанию суббота и воскресенье). kind — holiday, weekend,
+// working (рабочий выходной) или short (сокращённый день).
+type jsonCalendar struct {
+	Name    string   `json:"name"`
+	Weekend []string `json:"weekend"`
+	Days    []struct {
+		Date string         `json:"date"`
+		Kind domain.DayKind `json:"kind"`
+		Name string         `json:"name"`
+	} `json:"days"`
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+}
+
+// LoadJSON читает календарь в формате jsonCalendar.
+func LoadJSON(r io.Reader) (*Calendar, error) {
+	var jc jsonCalendar
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&jc); err != nil {
+		return nil, err
+	}
+
+	c := Weekends()
+	c.name = jc.Name
+	if jc.Weekend != nil {
+		c.weekend = [7]bool{}
+		for _, name := range jc.Weekend {
+			wd, ok := weekdayNames[strings.ToLower(name)]
+			if !ok {
+				return nil, fmt.Errorf("unknown weekday %q", name)
+			}
+			c.weekend[wd] = true
+		}
+		if c.weekend == [7]bool{true, true, true, true, true, true, true} {
+			return nil, errors.New("every day of the week is a weekend")
+		}
+	}
+
+	for i, d := range jc.Days {
+		t, err := time.Parse(time.DateOnly, d.Date)
+		if err != nil {
+			return nil, fmt.Errorf("day %d: invalid date %q", i+1, d.Date)
+		}
+		switch d.Kind {
+		case domain.DayHoliday, domain.DayWeekend, domain.DayWorking, domain.DayShort:
+		default:
+			return nil, fmt.Errorf("day %d (%s): unknown kind %q", i+1, d.Date, d.Kind)
+		}
+		if err := c.set(dateOf(t), entry{d.Kind, d.Name}); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// LoadICS читает праздники из iCalendar: каждый VEVENT — нерабочие дни с DTSTART
+// по DTEND (не включая его), SUMMARY — название. Вид дня можно задать в CATEGORIES
+// (holiday, weekend, working, short); по умолчанию — праздник.
+func LoadICS(r io.Reader) (*Calendar, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	c := Weekends()
+	var (
+		inEvent    bool
+		start, end time.Time
+		kind       domain.DayKind
+		summary    string
+	)
+	for n, line := range lines {
+		name, value, ok := splitProperty(line)
+		if !ok {
+			continue
+		}
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			inEvent, start, end, kind, summary = true, time.Time{}, time.Time{}, domain.DayHoliday, ""
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if start.IsZero() {
+				return nil, fmt.Errorf("line %d: VEVENT without DTSTART", n+1)
+			}
+			if end.IsZero() || !end.After(start) {
+				end = start.AddDate(0, 0, 1)
+			}
+			for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+				if err := c.set(dateOf(d), entry{kind, summary}); err != nil {
+					return nil, err
+				}
+			}
+			inEvent = false
+		case !inEvent:
+			if name == "X-WR-CALNAME" {
+				c.name = unescapeText(value)
+			}
+		case name == "DTSTART" || name == "DTEND":
+			t, err := parseICSDate(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %s: %w", n+1, name, err)
+			}
+			if name == "DTSTART" {
+				start = t
+			} else {
+				end = t
+			}
+		case name == "SUMMARY":
+			summary = unescapeText(value)
+		case name == "CATEGORIES":
+			for _, cat := range strings.Split(value, ",") {
+				switch k := domain.DayKind(strings.ToLower(strings.TrimSpace(cat))); k {
+				case domain.DayHoliday, domain.DayWeekend, domain.DayWorking, domain.DayShort:
+					kind = k
+				}
+			}
+		}
+	}
+	return c, nil
+}
+
+// set добавляет явный день. Один и тот же день дважды — ошибка в данных.
+func (c *Calendar) set(d date, e entry) error {
+	if _, dup := c.days[d]; dup {
+		return fmt.Errorf("day %04d-%02d-%02d is listed twice", d.year, d.month, d.day)
+	}
+	c.days[d] = e
+	c.years[d.year] = true
+	return nil
+}
+
+// unfold читает строки iCalendar, склеивая продолжения (строки, начинающиеся с пробела или табуляции).
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, sc.Err()
+}
+
+// splitProperty разбирает «NAME;PARAM=...:VALUE»; параметры не нужны и отбрасываются.
+// Двоеточие в кавычках параметра не считается.
+func splitProperty(line string) (name, value string, ok bool) {
+	quoted := false
+	for i, r := range line {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ':' && !quoted:
+			name, _, _ = strings.Cut(line[:i], ";")
+			return strings.ToUpper(name), line[i+1:], true
+		}
+	}
+	return "", "", false
+}
+
+// parseICSDate понимает DATE (20260101) и DATE-TIME (20260101T000000[Z]); берётся только дата.
+func parseICSDate(v string) (time.Time, error) {
+	if len(v) < 8 {
+		return time.Time{}, fmt.Errorf("invalid date %q", v)
+	}
+	t, err := time.Parse("20060102", v[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", v)
+	}
+	return t, nil
+}
+
+func unescapeText(s string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(s)
+}
@@ -0,0 +1,123 @@
+package holiday
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"calendar/internal/domain"
+)
+
+func TestLoadJSON(t *testing.T) {
+	c, err := LoadJSON(strings.NewReader(`{
+		"name": "il",
+		"weekend": ["Friday", "saturday"],
+		"days": [
+			{"date": "2026-09-12", "kind": "holiday", "name": "Rosh Hashanah"},
+			{"date": "2026-10-17", "kind": "working"}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("LoadJSON: %v", err)
+	}
+	if c.Name() != "il" {
+		t.Errorf("name = %q", c.Name())
+	}
+	tests := []struct {
+		date time.Time
+		kind domain.DayKind
+	}{
+		{day(2026, time.September, 12), domain.DayHoliday},
+		{day(2026, time.October, 16), domain.DayWeekend}, // пятница
+		{day(2026, time.October, 17), domain.DayWorking}, // суббота, но рабочая
+		{day(2026, time.October, 18), domain.DayWorking}, // воскресенье — обычный рабочий день
+	}
+	for _, tt := range tests {
+		if got := c.Day(tt.date).Kind; got != tt.kind {
+			t.Errorf("Day(%s) = %s, want %s", tt.date.Format(time.DateOnly), got, tt.kind)
+		}
+	}
+}
+
+func TestLoadJSON_Invalid(t *testing.T) {
+	for _, body := range []string{
+		`{"days": [{"date": "2026-13-01", "kind": "holiday"}]}`,
+		`{"days": [{"date": "2026-01-01", "kind": "vacation"}]}`,
+		`{"days": [{"date": "2026-01-01", "kind": "holiday"}, {"date": "2026-01-01", "kind": "short"}]}`,
+		`{"weekend": ["caturday"]}`,
+		`{"weekend": ["mon", "tue"]}`,
+		`{"weekend": ["monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"]}`,
+		`{"holidays": []}`,
+	} {
+		if _, err := LoadJSON(strings.NewReader(body)); err == nil {
+			t.Errorf("LoadJSON(%s): expected an error", body)
+		}
+	}
+}
+
+func TestLoadICS(t *testing.T) {
+	ics := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"X-WR-CALNAME:Company holidays",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20261230",
+		"DTEND;VALUE=DATE:20270102",
+		"SUMMARY:Winter\\, shutdown",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART:20261107T000000Z",
+		"SUMMARY:Make-up working",
+		"  Saturday",
+		"CATEGORIES:WORKING",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	c, err := LoadICS(strings.NewReader(ics))
+	if err != nil {
+		t.Fatalf("LoadICS: %v", err)
+	}
+	if c.Name() != "Company holidays" {
+		t.Errorf("name = %q", c.Name())
+	}
+	for _, d := range []time.Time{day(2026, time.December, 30), day(2026, time.December, 31), day(2027, time.January, 1)} {
+		if got := c.Day(d); got.Kind != domain.DayHoliday || got.Name != "Winter, shutdown" {
+			t.Errorf("Day(%s) = %+v", d.Format(time.DateOnly), got)
+		}
+	}
+	if got := c.Day(day(2027, time.January, 2)).Kind; got != domain.DayWeekend {
+		t.Errorf("DTEND must be exclusive, got %s", got)
+	}
+	if got := c.Day(day(2026, time.November, 7)); got.Kind != domain.DayWorking || got.Name != "Make-up working Saturday" {
+		t.Errorf("Nov 7 = %+v", got)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "office.json")
+	if err := os.WriteFile(path, []byte(`{"days": [{"date": "2026-10-19", "kind": "holiday"}]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if c.Name() != "office" || c.IsWorkingDay(day(2026, time.October, 19)) {
+		t.Errorf("loaded calendar %q does not mark the holiday", c.Name())
+	}
+
+	if c, err := Load("RU"); err != nil || c.Name() != "ru" {
+		t.Errorf(`Load("RU") = %v, %v`, c, err)
+	}
+	if _, err := Load(filepath.Join(dir, "office.txt")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+	os.WriteFile(filepath.Join(dir, "office.xml"), nil, 0o600)
+	if _, err := Load(filepath.Join(dir, "office.xml")); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
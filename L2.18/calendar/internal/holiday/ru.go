@@ -0,0 +1,77 @@
+package holiday
+
+import (
+	"calendar/internal/domain"
+	"time"
+)
+
+// Нерабочие праздничные дни по статье 112 Трудового кодекса РФ.
+var ruAnnual = []annual{
+	{time.January, 1, "Новогодние каникулы"},
+	{time.January, 2, "Новогодние каникулы"},
+	{time.January, 3, "Новогодние каникулы"},
+	{time.January, 4, "Новогодние каникулы"},
+	{time.January, 5, "Новогодние каникулы"},
+	{time.January, 6, "Новогодние каникулы"},
+	{time.January, 7, "Рождество Христово"},
+	{time.January, 8, "Новогодние каникулы"},
+	{time.February, 23, "День защитника Отечества"},
+	{time.March, 8, "Международный женский день"},
+	{time.May, 1, "Праздник Весны и Труда"},
+	{time.May, 9, "День Победы"},
+	{time.June, 12, "День России"},
+	{time.November, 4, "День народного единства"},
+}
+
+// ruYears — переносы выходных и сокращённые дни по постановлениям Правительства РФ.
+// Для этих лет праздники берутся из ruAnnual, а прочие отличия от обычной недели —
+// отсюда; для остальных лет переносы неизвестны и действуют только праздники.
+var ruYears = map[int][]struct {
+	month time.Month
+	day   int
+	kind  domain.DayKind
+}{
+	2025: {
+		{time.March, 7, domain.DayShort},
+		{time.April, 30, domain.DayShort},
+		{time.May, 2, domain.DayWeekend},
+		{time.May, 8, domain.DayWeekend},
+		{time.June, 11, domain.DayShort},
+		{time.June, 13, domain.DayWeekend},
+		{time.November, 1, domain.DayShort}, // рабочая суббота
+		{time.November, 3, domain.DayWeekend},
+		{time.December, 31, domain.DayWeekend},
+	},
+	2026: {
+		{time.January, 9, domain.DayWeekend},
+		{time.March, 9, domain.DayWeekend},
+		{time.April, 30, domain.DayShort},
+		{time.May, 8, domain.DayShort},
+		{time.May, 11, domain.DayWeekend},
+		{time.June, 11, domain.DayShort},
+		{time.November, 3, domain.DayShort},
+		{time.December, 31, domain.DayWeekend},
+	},
+}
+
+// RU — производственный календарь России. Для 2025–2026 годов учтены переносы
+// выходных и сокращённые дни, для остальных — только праздники и выходные.
+func RU() *Calendar {
+	c := Weekends()
+	c.name = "ru"
+	c.annual = ruAnnual
+	for year, days := range ruYears {
+		for _, a := range ruAnnual {
+			c.days[date{year, a.month, a.day}] = entry{domain.DayHoliday, a.name}
+		}
+		for _, d := range days {
+			name := ""
+			if d.kind == domain.DayWeekend {
+				name = "Перенесённый выходной"
+			}
+			c.days[date{year, d.month, d.day}] = entry{d.kind, name}
+		}
+		c.years[year] = true
+	}
+	return c
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"calendar/internal/domain"
+	"context"
+	"sort"
+	"sync"
+)
+
+// AttachmentRepository хранит описания вложений; содержимое — в blob.Store.
+type AttachmentRepository interface {
+	AddAttachment(ctx context.Context, a domain.Attachment) error
+	GetAttachment(ctx context.Context, id string) (domain.Attachment, error)
+	// ListAttachments возвращает вложения события в порядке загрузки.
+	ListAttachments(ctx context.Context, eventID string) ([]domain.Attachment, error)
+	DeleteAttachment(ctx context.Context, id string) error
+}
+
+type localAttachmentStorage struct {
+	mu      sync.RWMutex
+	byID    map[string]domain.Attachment
+	byEvent map[string]map[string]struct{}
+}
+
+func NewLocalAttachmentStorage() *localAttachmentStorage {
+	return &localAttachmentStorage{
+		byID:    make(map[string]domain.Attachment),
+		byEvent: make(map[string]map[string]struct{}),
+	}
+}
+
+func (s *localAttachmentStorage) AddAttachment(ctx context.Context, a domain.Attachment) error {
+	if err := aborted(ctx, "AddAttachment"); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.byID[a.ID] = a
+	if s.byEvent[a.EventID] == nil {
+		s.byEvent[a.EventID] = make(map[string]struct{})
+	}
+	s.byEvent[a.EventID][a.ID] = struct{}{}
+	return nil
+}
+
+func (s *localAttachmentStorage) GetAttachment(ctx context.Context, id string) (domain.Attachment, error) {
+	if err := aborted(ctx, "GetAttachment"); err != nil {
+		return domain.Attachment{}, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	a, ok := s.byID[id]
+	if !ok {
+		return domain.Attachment{}, domain.ErrAttachmentNotFound
+	}
+	return a, nil
+}
+
+func (s *localAttachmentStorage) ListAttachments(ctx context.Context, eventID string) ([]domain.Attachment, error) {
+	if err := aborted(ctx, "ListAttachments"); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	res := make([]domain.Attachment, 0, len(s.byEvent[eventID]))
+	for id := range s.byEvent[eventID] {
+		res = append(res, s.byID[id])
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(res, func(i, j int) bool {
+		if !res[i].UploadedAt.Equal(res[j].UploadedAt) {
+			return res[i].UploadedAt.Before(res[j].UploadedAt)
+		}
+		return res[i].ID < res[j].ID
+	})
+	return res, nil
+}
+
+func (s *localAttachmentStorage) DeleteAttachment(ctx context.Context, id string) error {
+	if err := aborted(ctx, "DeleteAttachment"); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.byID[id]
+	if !ok {
+		return domain.ErrAttachmentNotFound
+	}
+	delete(s.byID, id)
+	delete(s.byEvent[a.EventID], id)
+	if len(s.byEvent[a.EventID]) == 0 {
+		delete(s.byEvent, a.EventID)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"calendar/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestAttachments(t *testing.T) {
+	repo := NewLocalAttachmentStorage()
+	at := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+
+	for i, a := range []domain.Attachment{
+		{ID: "b", EventID: "evt-1", Name: "slides.pdf", UploadedAt: at.Add(time.Minute)},
+		{ID: "a", EventID: "evt-1", Name: "agenda.txt", UploadedAt: at},
+		{ID: "c", EventID: "evt-2", Name: "notes.md", UploadedAt: at},
+	} {
+		if err := repo.AddAttachment(t.Context(), a); err != nil {
+			t.Fatalf("AddAttachment(%d) error = %v", i, err)
+		}
+	}
+
+	list, err := repo.ListAttachments(t.Context(), "evt-1")
+	if err != nil || len(list) != 2 || list[0].ID != "a" || list[1].ID != "b" {
+		t.Fatalf("ListAttachments() = %+v, %v; want a, b in upload order", list, err)
+	}
+	if got, err := repo.GetAttachment(t.Context(), "c"); err != nil || got.Name != "notes.md" {
+		t.Errorf("GetAttachment() = %+v, %v", got, err)
+	}
+
+	if err := repo.DeleteAttachment(t.Context(), "a"); err != nil {
+		t.Fatalf("DeleteAttachment() error = %v", err)
+	}
+	if err := repo.DeleteAttachment(t.Context(), "a"); err != domain.ErrAttachmentNotFound {
+		t.Errorf("second DeleteAttachment() error = %v, want %v", err, domain.ErrAttachmentNotFound)
+	}
+	if _, err := repo.GetAttachment(t.Context(), "a"); err != domain.ErrAttachmentNotFound {
+		t.Errorf("GetAttachment(deleted) error = %v, want %v", err, domain.ErrAttachmentNotFound)
+	}
+	if list, _ := repo.ListAttachments(t.Context(), "evt-1"); len(list) != 1 || list[0].ID != "b" {
+		t.Errorf("ListAttachments() after delete = %+v, want only b", list)
+	}
+	if list, _ := repo.ListAttachments(t.Context(), "missing"); len(list) != 0 {
+		t.Errorf("ListAttachments(missing) = %+v, want empty", list)
+	}
+}
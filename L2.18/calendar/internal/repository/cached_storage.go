@@ -0,0 +1,351 @@
+package repository
+
+import (
+	"calendar/internal/domain"
+	"container/list"
+	"context"
+	"errors"
+	"hash/fnv"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// cachedStorage — декоратор EventRepository, который кеширует GetByUserAndRange
+// в LRU ограниченного размера с TTL. Остальные чтения идут в хранилище напрямую.
+//
+// Запись сбрасывает только закешированные диапазоны, которые её касаются: события попадают
+// в выборку по дате начала, поэтому у организатора и участников старой версии события
+// удаляются диапазоны с её датой, а у организатора и участников новой — с новой.
+//
+// Изменения одного события выполняются по одной (eventLocks, как в shardedStorage):
+// старая версия читается до записи, и при параллельных изменениях одного события сброс
+// мог бы пропустить промежуточную дату. Записи разных событий друг друга не ждут.
+// Чтение, начавшееся до сброса, не кладёт результат в кеш (см. reads).
+type cachedStorage struct {
+	next EventRepository
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	eventLocks [256]sync.Mutex
+
+	mu      sync.Mutex
+	lru     *list.List // *cacheEntry, в начале — недавно использованные
+	entries map[cacheKey]*list.Element
+	byUser  map[int]map[cacheKey]struct{}
+	reads   map[int]*userReads // чтения из хранилища, которые идут сейчас
+	epoch   uint64             // номер полного сброса (загрузка снимка)
+
+	hits, misses, evictions, invalidations atomic.Uint64
+}
+
+type cacheKey struct {
+	userID   int
+	from, to time.Time // в UTC, чтобы одинаковые моменты давали один ключ
+}
+
+// userReads — идущие чтения пользователя и номер сброса его выборок. Запись есть,
+// только пока идёт хотя бы одно чтение: иначе номер сравнивать не с чем.
+type userReads struct {
+	n   int
+	gen uint64 // меняется при каждой записи, которая касается пользователя
+}
+
+type cacheEntry struct {
+	key     cacheKey
+	events  []domain.Event
+	expires time.Time
+}
+
+// CacheStats — счётчики кеша с момента создания.
+type CacheStats struct {
+	Hits          uint64 `json:"hits"`
+	Misses        uint64 `json:"misses"`
+	Evictions     uint64 `json:"evictions"`     // вытеснено по размеру
+	Invalidations uint64 `json:"invalidations"` // сброшено записями
+	Size          int    `json:"size"`
+}
+
+// NewCachedStorage оборачивает next кешем на size выборок, каждая живёт не дольше ttl.
+func NewCachedStorage(next EventRepository, size int, ttl time.Duration) *cachedStorage {
+	return &cachedStorage{
+		next:    next,
+		size:    max(size, 1),
+		ttl:     ttl,
+		now:     time.Now,
+		lru:     list.New(),
+		entries: make(map[cacheKey]*list.Element),
+		byUser:  make(map[int]map[cacheKey]struct{}),
+		reads:   make(map[int]*userReads),
+	}
+}
+
+// lockEvent сериализует изменения одного события; возвращает функцию разблокировки.
+func (c *cachedStorage) lockEvent(id string) func() {
+	h := fnv.New32a()
+	h.Write([]byte(id))
+	mu := &c.eventLocks[h.Sum32()%uint32(len(c.eventLocks))]
+	mu.Lock()
+	return mu.Unlock
+}
+
+// lockAll останавливает все записи через кеш — на время загрузки снимка.
+func (c *cachedStorage) lockAll() func() {
+	for i := range c.eventLocks {
+		c.eventLocks[i].Lock()
+	}
+	return func() {
+		for i := range c.eventLocks {
+			c.eventLocks[i].Unlock()
+		}
+	}
+}
+
+func (c *cachedStorage) Stats() CacheStats {
+	c.mu.Lock()
+	size := c.lru.Len()
+	c.mu.Unlock()
+	return CacheStats{
+		Hits:          c.hits.Load(),
+		Misses:        c.misses.Load(),
+		Evictions:     c.evictions.Load(),
+		Invalidations: c.invalidations.Load(),
+		Size:          size,
+	}
+}
+
+func (c *cachedStorage) Create(ctx context.Context, e domain.Event) (string, error) {
+	// Create с заданным ID заменяет существующее событие; новому ID прежней версии нет.
+	var old []domain.Event
+	if e.ID != "" {
+		defer c.lockEvent(e.ID)()
+		prev, err := c.next.GetByID(ctx, e.ID)
+		if err != nil && !errors.Is(err, domain.ErrEventNotFound) {
+			return "", err
+		}
+		if err == nil {
+			old = append(old, prev)
+		}
+	}
+
+	id, err := c.next.Create(ctx, e)
+	if err != nil {
+		return "", err
+	}
+	e.ID = id
+	c.invalidate(append(old, e)...)
+	return id, nil
+}
+
+func (c *cachedStorage) Update(ctx context.Context, e domain.Event) error {
+	defer c.lockEvent(e.ID)()
+
+	old, err := c.next.GetByID(ctx, e.ID)
+	if err != nil {
+		return err
+	}
+	if err := c.next.Update(ctx, e); err != nil {
+		return err
+	}
+	c.invalidate(old, e)
+	return nil
+}
+
+func (c *cachedStorage) Delete(ctx context.Context, id string) error {
+	defer c.lockEvent(id)()
+
+	old, err := c.next.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := c.next.Delete(ctx, id); err != nil {
+		return err
+	}
+	c.invalidate(old)
+	return nil
+}
+
+func (c *cachedStorage) SetAttendeeStatus(ctx context.Context, id string, userID int, status domain.AttendeeStatus) error {
+	defer c.lockEvent(id)()
+
+	old, err := c.next.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := c.next.SetAttendeeStatus(ctx, id, userID, status); err != nil {
+		return err
+	}
+	// Статус виден всем, кто видит событие, а дата и список участников не меняются.
+	c.invalidate(old)
+	return nil
+}
+
+func (c *cachedStorage) Restore(ctx context.Context, id string) (domain.Event, error) {
+	defer c.lockEvent(id)()
+
+	e, err := c.next.Restore(ctx, id)
+	if err != nil {
+		return domain.Event{}, err
+	}
+	c.invalidate(e)
+	return e, nil
+}
+
+// Purge не трогает кеш: события в корзине и так не попадают в выборки.
+func (c *cachedStorage) Purge(ctx context.Context, before time.Time) ([]domain.Event, error) {
+	return c.next.Purge(ctx, before)
+}
+
+func (c *cachedStorage) ListDeleted(ctx context.Context, userID int) ([]domain.DeletedEvent, error) {
+	return c.next.ListDeleted(ctx, userID)
+}
+
+func (c *cachedStorage) Snapshot(ctx context.Context) (domain.Snapshot, error) {
+	return c.next.Snapshot(ctx)
+}
+
+// Load меняет неизвестно чьи события, поэтому сбрасывает кеш целиком.
+func (c *cachedStorage) Load(ctx context.Context, snap domain.Snapshot, replace bool) error {
+	defer c.lockAll()()
+
+	err := c.next.Load(ctx, snap, replace)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Хранилище могло успеть измениться и при ошибке.
+	c.epoch++
+	c.invalidations.Add(uint64(c.lru.Len()))
+	c.lru.Init()
+	clear(c.entries)
+	clear(c.byUser)
+	return err
+}
+
+func (c *cachedStorage) CountByUser(ctx context.Context, userID int) (int, error) {
+	return c.next.CountByUser(ctx, userID)
+}
+
+func (c *cachedStorage) CountPerUser(ctx context.Context) (map[int]int, error) {
+	return c.next.CountPerUser(ctx)
+}
+
+func (c *cachedStorage) GetByID(ctx context.Context, id string) (domain.Event, error) {
+	return c.next.GetByID(ctx, id)
+}
+
+func (c *cachedStorage) GetByUserTagsAndRange(ctx context.Context, userID int, tags []string, matchAll bool, from, to time.Time) ([]domain.Event, error) {
+	return c.next.GetByUserTagsAndRange(ctx, userID, tags, matchAll, from, to)
+}
+
+func (c *cachedStorage) GetByUID(ctx context.Context, userID int, uid string) ([]domain.Event, error) {
+	return c.next.GetByUID(ctx, userID, uid)
+}
+
+// GetByUserAndRange отдаёт выборку из кеша или читает её из хранилища и запоминает.
+// Вызывающий получает свою копию среза: его сортировка не должна менять кеш.
+func (c *cachedStorage) GetByUserAndRange(ctx context.Context, userID int, from, to time.Time) ([]domain.Event, error) {
+	if err := aborted(ctx, "GetByUserAndRange"); err != nil {
+		return nil, err
+	}
+	key := cacheKey{userID: userID, from: from.UTC(), to: to.UTC()}
+
+	c.mu.Lock()
+	if events, ok := c.lookup(key); ok {
+		c.mu.Unlock()
+		c.hits.Add(1)
+		return slices.Clone(events), nil
+	}
+	reads := c.reads[userID]
+	if reads == nil {
+		reads = &userReads{}
+		c.reads[userID] = reads
+	}
+	reads.n++
+	gen, epoch := reads.gen, c.epoch
+	c.mu.Unlock()
+	c.misses.Add(1)
+
+	events, err := c.next.GetByUserAndRange(ctx, userID, from, to)
+
+	c.mu.Lock()
+	// Пока читали, пользователь мог измениться: такой результат мог устареть, не кешируем.
+	if err == nil && reads.gen == gen && c.epoch == epoch {
+		c.store(key, slices.Clone(events))
+	}
+	if reads.n--; reads.n == 0 {
+		delete(c.reads, userID)
+	}
+	c.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// lookup возвращает живую запись и поднимает её в начало LRU. Вызывается под c.mu.
+func (c *cachedStorage) lookup(key cacheKey) ([]domain.Event, bool) {
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*cacheEntry)
+	if !c.now().Before(entry.expires) {
+		c.remove(el)
+		return nil, false
+	}
+	c.lru.MoveToFront(el)
+	return entry.events, true
+}
+
+// store добавляет запись и вытесняет самые давние сверх размера. Вызывается под c.mu.
+func (c *cachedStorage) store(key cacheKey, events []domain.Event) {
+	if el, ok := c.entries[key]; ok {
+		c.remove(el)
+	}
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, events: events, expires: c.now().Add(c.ttl)})
+	if c.byUser[key.userID] == nil {
+		c.byUser[key.userID] = make(map[cacheKey]struct{})
+	}
+	c.byUser[key.userID][key] = struct{}{}
+
+	for c.lru.Len() > c.size {
+		c.remove(c.lru.Back())
+		c.evictions.Add(1)
+	}
+}
+
+func (c *cachedStorage) remove(el *list.Element) {
+	key := c.lru.Remove(el).(*cacheEntry).key
+	delete(c.entries, key)
+	delete(c.byUser[key.userID], key)
+	if len(c.byUser[key.userID]) == 0 {
+		delete(c.byUser, key.userID)
+	}
+}
+
+// invalidate сбрасывает у каждого, кто видит версию события, выборки, содержащие её дату.
+func (c *cachedStorage) invalidate(versions ...domain.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, e := range versions {
+		c.invalidateUser(e.UserID, e.Date)
+		for _, a := range e.Attendees {
+			c.invalidateUser(a.UserID, e.Date)
+		}
+	}
+}
+
+// invalidateUser вызывается под c.mu.
+func (c *cachedStorage) invalidateUser(userID int, date time.Time) {
+	if reads := c.reads[userID]; reads != nil {
+		reads.gen++
+	}
+	for key := range c.byUser[userID] {
+		if !date.Before(key.from) && date.Before(key.to) {
+			c.remove(c.entries[key])
+			c.invalidations.Add(1)
+		}
+	}
+}
@@ -0,0 +1,317 @@
+package repository
+
+import (
+	"calendar/internal/domain"
+	"context"
+	"math/rand/v2"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCachedStorage_HitsMissesAndEviction(t *testing.T) {
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	now := day
+	repo := NewCachedStorage(NewLocalStorage(), 2, time.Minute)
+	repo.now = func() time.Time { return now }
+
+	repo.Create(t.Context(), domain.Event{UserID: 1, Date: day})
+	get := func(from time.Time) {
+		t.Helper()
+		if _, err := repo.GetByUserAndRange(t.Context(), 1, from, from.AddDate(0, 0, 1)); err != nil {
+			t.Fatalf("GetByUserAndRange() error = %v", err)
+		}
+	}
+
+	get(day)                                      // промах
+	get(day)                                      // попадание
+	get(day.In(time.FixedZone("UTC+3", 3*60*60))) // тот же момент в другой зоне — тоже попадание
+	get(day.AddDate(0, 0, 1))                     // промах
+	get(day.AddDate(0, 0, 2))                     // промах, вытесняет самый давний (day)
+	get(day)                                      // промах
+	now = now.Add(time.Minute)                    // всё истекло
+	get(day)                                      // промах
+
+	want := CacheStats{Hits: 2, Misses: 5, Evictions: 2, Size: 2}
+	if got := repo.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCachedStorage_InvalidatesOnlyOverlappingRanges(t *testing.T) {
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	repo := NewCachedStorage(NewLocalStorage(), 16, time.Minute)
+	id, _ := repo.Create(t.Context(), domain.Event{UserID: 1, Date: day})
+
+	week := day.AddDate(0, 0, 7)
+	ranges := [][2]time.Time{{day, day.AddDate(0, 0, 1)}, {week, week.AddDate(0, 0, 1)}, {day, day.AddDate(0, 1, 0)}}
+	for _, userID := range []int{1, 2} {
+		for _, r := range ranges {
+			repo.GetByUserAndRange(t.Context(), userID, r[0], r[1])
+		}
+	}
+
+	// Перенос на неделю вперёд с приглашением пользователя 2: у организатора сбрасываются
+	// день (старая дата), неделя (новая) и месяц (обе), у участника — неделя и месяц.
+	repo.Update(t.Context(), domain.Event{ID: id, UserID: 1, Date: week, EventAttrs: domain.EventAttrs{
+		Attendees: []domain.Attendee{{UserID: 2, Status: domain.StatusNeedsAction}},
+	}})
+	if got := repo.Stats(); got.Invalidations != 5 || got.Size != 1 {
+		t.Errorf("Stats() = %+v, want 5 invalidations and 1 cached range", got)
+	}
+
+	// Ответ участника сбрасывает у него неделю и месяц, а день остаётся в кеше.
+	for _, r := range ranges {
+		repo.GetByUserAndRange(t.Context(), 2, r[0], r[1])
+	}
+	before := repo.Stats()
+	repo.SetAttendeeStatus(t.Context(), id, 2, domain.StatusAccepted)
+	if got := repo.Stats(); got.Invalidations-before.Invalidations != 2 || got.Size != 1 {
+		t.Errorf("SetAttendeeStatus: Stats() = %+v, want 2 more invalidations and 1 cached range", got)
+	}
+}
+
+// cacheProbe сравнивает выборки кеша с хранилищем под ним на наборе пользователей и диапазонов.
+func cacheProbe(t *testing.T, cached, truth EventRepository, users int, ranges [][2]time.Time) {
+	t.Helper()
+	for u := 1; u <= users; u++ {
+		for _, r := range ranges {
+			got, err := cached.GetByUserAndRange(t.Context(), u, r[0], r[1])
+			if err != nil {
+				t.Fatalf("GetByUserAndRange() error = %v", err)
+			}
+			want, _ := truth.GetByUserAndRange(t.Context(), u, r[0], r[1])
+			if !sameEvents(got, want) {
+				t.Fatalf("user %d, %s..%s: cache has %v, storage has %v",
+					u, r[0].Format("01-02"), r[1].Format("01-02"), got, want)
+			}
+		}
+	}
+}
+
+func sameEvents(a, b []domain.Event) bool {
+	sorted := func(events []domain.Event) []domain.Event {
+		res := append([]domain.Event{}, events...)
+		sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+		return res
+	}
+	return reflect.DeepEqual(sorted(a), sorted(b))
+}
+
+func probeRanges(day time.Time) [][2]time.Time {
+	var ranges [][2]time.Time
+	for d := 0; d < 14; d++ {
+		from := day.AddDate(0, 0, d)
+		ranges = append(ranges, [2]time.Time{from, from.AddDate(0, 0, 1)}, [2]time.Time{from, from.AddDate(0, 0, 7)})
+	}
+	return append(ranges, [2]time.Time{day, day.AddDate(0, 1, 0)})
+}
+
+// TestCachedStorage_NeverServesStaleData после каждой случайной записи проверяет,
+// что все закешированные выборки совпадают с хранилищем.
+func TestCachedStorage_NeverServesStaleData(t *testing.T) {
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	truth := NewLocalStorage()
+	repo := NewCachedStorage(truth, 1024, time.Hour)
+	ranges := probeRanges(day)
+	rnd := rand.New(rand.NewPCG(1, 2))
+
+	const users = 4
+	randomEvent := func() domain.Event {
+		e := domain.Event{UserID: rnd.IntN(users) + 1, Title: "t", Date: day.AddDate(0, 0, rnd.IntN(14)).Add(time.Duration(rnd.IntN(24)) * time.Hour)}
+		for u := 1; u <= users; u++ {
+			if u != e.UserID && rnd.IntN(3) == 0 {
+				e.Attendees = append(e.Attendees, domain.Attendee{UserID: u, Status: domain.StatusNeedsAction})
+			}
+		}
+		return e
+	}
+
+	var ids []string
+	for step := 0; step < 300; step++ {
+		switch op := rnd.IntN(4); {
+		case op == 0 || len(ids) == 0:
+			id, _ := repo.Create(t.Context(), randomEvent())
+			ids = append(ids, id)
+		case op == 1:
+			e := randomEvent()
+			e.ID = ids[rnd.IntN(len(ids))]
+			if old, err := truth.GetByID(t.Context(), e.ID); err == nil {
+				e.UserID = old.UserID
+				if rnd.IntN(2) == 0 {
+					e.Date = old.Date // меняются только участники
+				}
+			}
+			repo.Update(t.Context(), e)
+		case op == 2:
+			id := ids[rnd.IntN(len(ids))]
+			if e, err := truth.GetByID(t.Context(), id); err == nil && len(e.Attendees) > 0 {
+				repo.SetAttendeeStatus(t.Context(), id, e.Attendees[0].UserID, domain.StatusDeclined)
+			}
+		default:
+			i := rnd.IntN(len(ids))
+			repo.Delete(t.Context(), ids[i])
+			ids = append(ids[:i], ids[i+1:]...)
+		}
+		cacheProbe(t, repo, truth, users, ranges)
+	}
+
+	if s := repo.Stats(); s.Hits == 0 || s.Invalidations == 0 {
+		t.Errorf("Stats() = %+v: the test exercised neither hits nor invalidations", s)
+	}
+}
+
+// TestCachedStorage_ConcurrentWritesLeaveNoStaleRanges: читатели заполняют кеш одновременно
+// с записями; когда всё утихло, ни одна выборка не должна отличаться от хранилища.
+func TestCachedStorage_ConcurrentWritesLeaveNoStaleRanges(t *testing.T) {
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	truth := NewShardedStorage(4)
+	repo := NewCachedStorage(truth, 4096, time.Hour)
+	ranges := probeRanges(day)
+
+	const users, rounds = 4, 200
+	var wg sync.WaitGroup
+	for u := 1; u <= users; u++ {
+		wg.Add(2)
+		go func(userID int) {
+			defer wg.Done()
+			guest := userID%users + 1
+			for i := 0; i < rounds; i++ {
+				e := domain.Event{UserID: userID, Date: day.AddDate(0, 0, i%14), EventAttrs: domain.EventAttrs{
+					Attendees: []domain.Attendee{{UserID: guest, Status: domain.StatusNeedsAction}},
+				}}
+				e.ID, _ = repo.Create(t.Context(), e)
+				e.Date = day.AddDate(0, 0, (i+5)%14)
+				repo.Update(t.Context(), e)
+				repo.SetAttendeeStatus(t.Context(), e.ID, guest, domain.StatusAccepted)
+				if i%3 == 0 {
+					repo.Delete(t.Context(), e.ID)
+				}
+			}
+		}(u)
+		go func(userID int) {
+			defer wg.Done()
+			for i := 0; i < rounds*4; i++ {
+				r := ranges[i%len(ranges)]
+				repo.GetByUserAndRange(t.Context(), userID, r[0], r[1])
+			}
+		}(u)
+	}
+	wg.Wait()
+
+	cacheProbe(t, repo, truth, users, ranges)
+}
+
+// pausedRepo останавливает чтение диапазона после того, как данные уже прочитаны.
+type pausedRepo struct {
+	EventRepository
+	read, resume chan struct{}
+}
+
+func (r *pausedRepo) GetByUserAndRange(ctx context.Context, userID int, from, to time.Time) ([]domain.Event, error) {
+	events, err := r.EventRepository.GetByUserAndRange(ctx, userID, from, to)
+	r.read <- struct{}{}
+	<-r.resume
+	return events, err
+}
+
+// TestCachedStorage_DropsReadRacingWithWrite: чтение, которое застало событие до записи,
+// а закончилось после сброса кеша, не должно оставить в кеше старую версию.
+func TestCachedStorage_DropsReadRacingWithWrite(t *testing.T) {
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	truth := NewLocalStorage()
+	id, _ := truth.Create(t.Context(), domain.Event{UserID: 1, Title: "old", Date: day})
+	paused := &pausedRepo{EventRepository: truth, read: make(chan struct{}, 1), resume: make(chan struct{})}
+	repo := NewCachedStorage(paused, 16, time.Hour)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		repo.GetByUserAndRange(t.Context(), 1, day, day.AddDate(0, 0, 1))
+	}()
+	<-paused.read
+	if err := repo.Update(t.Context(), domain.Event{ID: id, UserID: 1, Title: "new", Date: day}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	close(paused.resume)
+	<-done
+
+	got, _ := repo.GetByUserAndRange(t.Context(), 1, day, day.AddDate(0, 0, 1))
+	if len(got) != 1 || got[0].Title != "new" {
+		t.Errorf("GetByUserAndRange() = %+v, want the updated event", got)
+	}
+}
+
+func TestCachedStorage_ForgetsIdleUsers(t *testing.T) {
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	repo := NewCachedStorage(NewLocalStorage(), 4, time.Minute)
+
+	// Пользователей больше, чем помещается в кеш: их выборки вытесняются,
+	// и от них в кеше не должно оставаться ничего.
+	for userID := 1; userID <= 100; userID++ {
+		id, _ := repo.Create(t.Context(), domain.Event{UserID: userID, Date: day, EventAttrs: domain.EventAttrs{Attendees: []domain.Attendee{{UserID: userID + 1000}}}})
+		repo.GetByUserAndRange(t.Context(), userID, day, day.AddDate(0, 0, 1))
+		repo.Delete(t.Context(), id)
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	if len(repo.reads) != 0 || len(repo.byUser) > 4 {
+		t.Errorf("cache keeps %d read trackers and %d users, want none and at most the cache size", len(repo.reads), len(repo.byUser))
+	}
+}
+
+// slowUpdateRepo останавливает Update события stuck, пока не закроют resume.
+type slowUpdateRepo struct {
+	EventRepository
+	stuck   string
+	entered chan struct{}
+	resume  chan struct{}
+}
+
+func (r *slowUpdateRepo) Update(ctx context.Context, e domain.Event) error {
+	if e.ID == r.stuck {
+		close(r.entered)
+		<-r.resume
+	}
+	return r.EventRepository.Update(ctx, e)
+}
+
+func TestCachedStorage_WritesOfDifferentEventsDoNotWait(t *testing.T) {
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	truth := NewLocalStorage()
+	a, _ := truth.Create(t.Context(), domain.Event{UserID: 1, Date: day})
+	b, _ := truth.Create(t.Context(), domain.Event{UserID: 2, Date: day})
+	slow := &slowUpdateRepo{EventRepository: truth, stuck: a, entered: make(chan struct{}), resume: make(chan struct{})}
+	repo := NewCachedStorage(slow, 16, time.Hour)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		repo.Update(t.Context(), domain.Event{ID: a, UserID: 1, Date: day})
+	}()
+	<-slow.entered
+
+	// Пока запись события a стоит, запись b и создание новых событий проходят.
+	finished := make(chan error, 1)
+	go func() {
+		if err := repo.Update(t.Context(), domain.Event{ID: b, UserID: 2, Date: day.AddDate(0, 0, 1)}); err != nil {
+			finished <- err
+			return
+		}
+		_, err := repo.Create(t.Context(), domain.Event{UserID: 3, Date: day})
+		finished <- err
+	}()
+	select {
+	case err := <-finished:
+		if err != nil {
+			t.Fatalf("write error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("a write of another event waited for the stuck one")
+	}
+	close(slow.resume)
+	<-done
+}
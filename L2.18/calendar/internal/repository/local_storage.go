@@ -2,31 +2,79 @@ package repository
 
 import (
 	"calendar/internal/domain"
+	"calendar/internal/reqlog"
+	"context"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
 
 type EventRepository interface {
-	Create(e domain.Event) (string, error)
-	Update(e domain.Event) error
-	Delete(id string) error
-	GetByUserAndRange(userID int, from, to time.Time) ([]domain.Event, error)
+	Create(ctx context.Context, e domain.Event) (string, error)
+	Update(ctx context.Context, e domain.Event) error
+	// Delete переносит событие в корзину организатора: оно пропадает из GetByID и выборок,
+	// но его можно вернуть через Restore, пока его не удалил Purge.
+	Delete(ctx context.Context, id string) error
+	GetByID(ctx context.Context, id string) (domain.Event, error)
+	// SetAttendeeStatus атомарно меняет статус участника userID в событии id.
+	SetAttendeeStatus(ctx context.Context, id string, userID int, status domain.AttendeeStatus) error
+	// GetByUserAndRange возвращает события, которые пользователь организует или на которые приглашён.
+	GetByUserAndRange(ctx context.Context, userID int, from, to time.Time) ([]domain.Event, error)
+	// GetByUserTagsAndRange возвращает события пользователя с любым из тегов (matchAll = false)
+	// или со всеми тегами (matchAll = true).
+	GetByUserTagsAndRange(ctx context.Context, userID int, tags []string, matchAll bool, from, to time.Time) ([]domain.Event, error)
+	// GetByUID возвращает события, которые пользователь организует под iCalendar UID uid (корзина не считается).
+	GetByUID(ctx context.Context, userID int, uid string) ([]domain.Event, error)
+	// CountByUser возвращает, сколько событий организует пользователь (корзина не считается).
+	CountByUser(ctx context.Context, userID int) (int, error)
+	// CountPerUser возвращает то же для всех организаторов, у которых есть события.
+	CountPerUser(ctx context.Context) (map[int]int, error)
+
+	// ListDeleted возвращает корзину пользователя: недавно удалённые — первыми.
+	ListDeleted(ctx context.Context, userID int) ([]domain.DeletedEvent, error)
+	// Restore возвращает событие из корзины под прежним ID.
+	Restore(ctx context.Context, id string) (domain.Event, error)
+	// Purge окончательно удаляет события, попавшие в корзину раньше before, и возвращает их.
+	Purge(ctx context.Context, before time.Time) ([]domain.Event, error)
+
+	// Snapshot возвращает согласованный срез всего хранилища: запись, идущая параллельно,
+	// попадает в него целиком или не попадает вовсе.
+	Snapshot(ctx context.Context) (domain.Snapshot, error)
+	// Load загружает снимок одной операцией. replace = true — прежнее содержимое удаляется;
+	// иначе записи снимка заменяют события с теми же ID (живые и из корзины), остальное остаётся.
+	Load(ctx context.Context, snap domain.Snapshot, replace bool) error
 }
 
 type localStorage struct {
-	mu       sync.RWMutex
-	events   map[string]domain.Event
-	nextID   int64
+	mu     sync.RWMutex
+	events map[string]domain.Event
+	tags   map[int]map[string]map[string]struct{} // user (организатор или участник) -> tag -> event IDs
+	uids   map[int]map[string]map[string]struct{} // организатор -> UID -> event IDs
+	trash  map[string]domain.DeletedEvent
+	owned  map[int]int // организатор -> число его событий
+	nextID int64
+	now    func() time.Time
 }
 
 func NewLocalStorage() *localStorage {
 	return &localStorage{
 		events: make(map[string]domain.Event),
+		tags:   make(map[int]map[string]map[string]struct{}),
+		uids:   make(map[int]map[string]map[string]struct{}),
+		trash:  make(map[string]domain.DeletedEvent),
+		owned:  make(map[int]int),
+		now:    time.Now,
 	}
 }
 
-func (s *localStorage) Create(e domain.Event) (string, error) {
+func (s *localStorage) Create(ctx context.Context, e domain.Event) (string, error) {
+	if err := aborted(ctx, "Create"); err != nil {
+		return "", err
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -34,40 +82,216 @@ func (s *localStorage) Create(e domain.Event) (string, error) {
 		s.nextID++
 		e.ID = fmt.Sprintf("event_%d", s.nextID)
 	}
+	if old, exists := s.events[e.ID]; exists {
+		s.unindex(old)
+	}
+	// Новое событие с ID из корзины занимает его: восстанавливать прежнее уже некуда.
+	delete(s.trash, e.ID)
 	s.events[e.ID] = e
+	s.index(e)
 	return e.ID, nil
 }
 
-func (s *localStorage) Update(e domain.Event) error {
+func (s *localStorage) Update(ctx context.Context, e domain.Event) error {
+	if err := aborted(ctx, "Update"); err != nil {
+		return err
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if _, exists := s.events[e.ID]; !exists {
+	old, exists := s.events[e.ID]
+	if !exists {
 		return domain.ErrEventNotFound
 	}
+	s.unindex(old)
 	s.events[e.ID] = e
+	s.index(e)
 	return nil
 }
 
-func (s *localStorage) Delete(id string) error {
+func (s *localStorage) Delete(ctx context.Context, id string) error {
+	if err := aborted(ctx, "Delete"); err != nil {
+		return err
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if _, exists := s.events[id]; !exists {
+	old, exists := s.events[id]
+	if !exists {
 		return domain.ErrEventNotFound
 	}
+	s.unindex(old)
 	delete(s.events, id)
+	s.trash[id] = domain.DeletedEvent{Event: old, DeletedAt: s.now()}
+	return nil
+}
+
+func (s *localStorage) ListDeleted(ctx context.Context, userID int) ([]domain.DeletedEvent, error) {
+	if err := aborted(ctx, "ListDeleted"); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []domain.DeletedEvent
+	for _, d := range s.trash {
+		if d.UserID == userID {
+			result = append(result, d)
+		}
+	}
+	sortDeleted(result)
+	return result, nil
+}
+
+func (s *localStorage) Restore(ctx context.Context, id string) (domain.Event, error) {
+	if err := aborted(ctx, "Restore"); err != nil {
+		return domain.Event{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.trash[id]
+	if !ok {
+		return domain.Event{}, domain.ErrEventNotFound
+	}
+	delete(s.trash, id)
+	s.events[id] = d.Event
+	s.index(d.Event)
+	return d.Event, nil
+}
+
+func (s *localStorage) Purge(ctx context.Context, before time.Time) ([]domain.Event, error) {
+	if err := aborted(ctx, "Purge"); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var purged []domain.Event
+	for id, d := range s.trash {
+		if d.DeletedAt.Before(before) {
+			purged = append(purged, d.Event)
+			delete(s.trash, id)
+		}
+	}
+	return purged, nil
+}
+
+func (s *localStorage) Snapshot(ctx context.Context) (domain.Snapshot, error) {
+	if err := aborted(ctx, "Snapshot"); err != nil {
+		return domain.Snapshot{}, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snap := domain.Snapshot{
+		Events:  make([]domain.Event, 0, len(s.events)),
+		Deleted: make([]domain.DeletedEvent, 0, len(s.trash)),
+	}
+	for _, e := range s.events {
+		snap.Events = append(snap.Events, e)
+	}
+	for _, d := range s.trash {
+		snap.Deleted = append(snap.Deleted, d)
+	}
+	return snap, nil
+}
+
+func (s *localStorage) Load(ctx context.Context, snap domain.Snapshot, replace bool) error {
+	if err := aborted(ctx, "Load"); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if replace {
+		s.events = make(map[string]domain.Event)
+		s.tags = make(map[int]map[string]map[string]struct{})
+		s.uids = make(map[int]map[string]map[string]struct{})
+		s.trash = make(map[string]domain.DeletedEvent)
+		s.owned = make(map[int]int)
+	}
+	put := func(id string) {
+		if old, exists := s.events[id]; exists {
+			s.unindex(old)
+			delete(s.events, id)
+		}
+		delete(s.trash, id)
+		s.nextID = max(s.nextID, idSeq(id))
+	}
+	for _, e := range snap.Events {
+		put(e.ID)
+		s.events[e.ID] = e
+		s.index(e)
+	}
+	for _, d := range snap.Deleted {
+		put(d.ID)
+		s.trash[d.ID] = d
+	}
 	return nil
 }
 
-func (s *localStorage) GetByUserAndRange(userID int, start, end time.Time) ([]domain.Event, error) {
+func (s *localStorage) GetByID(ctx context.Context, id string) (domain.Event, error) {
+	if err := aborted(ctx, "GetByID"); err != nil {
+		return domain.Event{}, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	e, exists := s.events[id]
+	if !exists {
+		return domain.Event{}, domain.ErrEventNotFound
+	}
+	return e, nil
+}
+
+func (s *localStorage) SetAttendeeStatus(ctx context.Context, id string, userID int, status domain.AttendeeStatus) error {
+	if err := aborted(ctx, "SetAttendeeStatus"); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, exists := s.events[id]
+	if !exists {
+		return domain.ErrEventNotFound
+	}
+	attendees, err := withStatus(e.Attendees, userID, status)
+	if err != nil {
+		return err
+	}
+	e.Attendees = attendees
+	s.events[id] = e
+	return nil
+}
+
+func (s *localStorage) GetByUserAndRange(ctx context.Context, userID int, start, end time.Time) ([]domain.Event, error) {
+	if err := aborted(ctx, "GetByUserAndRange"); err != nil {
+		return nil, err
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	var result []domain.Event
+	n := 0
 	for _, event := range s.events {
-		if event.UserID == userID {
-			if (event.Date.Equal(start) || event.Date.After(start)) && event.Date.Before(end) {
+		if n++; n%scanCheckEvery == 0 {
+			if err := aborted(ctx, "GetByUserAndRange"); err != nil {
+				return nil, err
+			}
+		}
+		if event.UserID == userID || event.HasAttendee(userID) {
+			if inRange(event, start, end) {
 				result = append(result, event)
 			}
 		}
@@ -75,3 +299,242 @@ func (s *localStorage) GetByUserAndRange(userID int, start, end time.Time) ([]do
 	return result, nil
 }
 
+func (s *localStorage) GetByUserTagsAndRange(ctx context.Context, userID int, tags []string, matchAll bool, start, end time.Time) ([]domain.Event, error) {
+	if err := aborted(ctx, "GetByUserTagsAndRange"); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []domain.Event
+	for id := range lookupTags(s.tags[userID], tags, matchAll) {
+		if event := s.events[id]; inRange(event, start, end) {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+func (s *localStorage) GetByUID(ctx context.Context, userID int, uid string) ([]domain.Event, error) {
+	if err := aborted(ctx, "GetByUID"); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []domain.Event
+	for id := range s.uids[userID][uid] {
+		result = append(result, s.events[id])
+	}
+	return result, nil
+}
+
+func (s *localStorage) CountByUser(ctx context.Context, userID int) (int, error) {
+	if err := aborted(ctx, "CountByUser"); err != nil {
+		return 0, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.owned[userID], nil
+}
+
+func (s *localStorage) CountPerUser(ctx context.Context) (map[int]int, error) {
+	if err := aborted(ctx, "CountPerUser"); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts := make(map[int]int, len(s.owned))
+	for userID, n := range s.owned {
+		counts[userID] = n
+	}
+	return counts, nil
+}
+
+// aborted возвращает ошибку контекста, если запрос уже отменён или истёк его дедлайн:
+// начинать (или продолжать) работу для него бессмысленно.
+func aborted(ctx context.Context, op string) error {
+	if err := ctx.Err(); err != nil {
+		reqlog.Printf(ctx, "[REPO] %s aborted: %v", op, err)
+		return err
+	}
+	return nil
+}
+
+// scanCheckEvery — как часто долгий перебор событий проверяет отмену запроса.
+const scanCheckEvery = 1024
+
+func inRange(e domain.Event, start, end time.Time) bool {
+	return (e.Date.Equal(start) || e.Date.After(start)) && e.Date.Before(end)
+}
+
+// idSeq возвращает номер сгенерированного ID вида event_N (0 для прочих ID): после загрузки
+// снимка счётчик продолжается с наибольшего номера, чтобы новые ID не совпали с загруженными.
+func idSeq(id string) int64 {
+	n, err := strconv.ParseInt(strings.TrimPrefix(id, "event_"), 10, 64)
+	if err != nil || !strings.HasPrefix(id, "event_") {
+		return 0
+	}
+	return n
+}
+
+// sortDeleted упорядочивает корзину: недавно удалённые первыми, при равенстве — по ID.
+func sortDeleted(events []domain.DeletedEvent) {
+	slices.SortFunc(events, func(a, b domain.DeletedEvent) int {
+		if c := b.DeletedAt.Compare(a.DeletedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+}
+
+// withStatus возвращает копию списка участников с новым статусом userID:
+// сохранённые события могли уже уйти вызывающему, поэтому исходный срез не меняем.
+func withStatus(attendees []domain.Attendee, userID int, status domain.AttendeeStatus) ([]domain.Attendee, error) {
+	res := append([]domain.Attendee(nil), attendees...)
+	for i := range res {
+		if res[i].UserID == userID {
+			res[i].Status = status
+			return res, nil
+		}
+	}
+	return nil, domain.ErrNotInvited
+}
+
+// visibleTo — все пользователи, которые видят событие: организатор и участники.
+func visibleTo(e domain.Event) []int {
+	users := make([]int, 0, len(e.Attendees)+1)
+	users = append(users, e.UserID)
+	for _, a := range e.Attendees {
+		users = append(users, a.UserID)
+	}
+	return users
+}
+
+func (s *localStorage) index(e domain.Event) {
+	s.owned[e.UserID]++
+	indexUID(s.uids, e)
+	for _, userID := range visibleTo(e) {
+		indexTags(s.tags, userID, e)
+	}
+}
+
+func (s *localStorage) unindex(e domain.Event) {
+	if s.owned[e.UserID]--; s.owned[e.UserID] == 0 {
+		delete(s.owned, e.UserID)
+	}
+	unindexUID(s.uids, e)
+	for _, userID := range visibleTo(e) {
+		unindexTags(s.tags, userID, e)
+	}
+}
+
+// indexTags добавляет теги события в индекс пользователя userID.
+func indexTags(idx map[int]map[string]map[string]struct{}, userID int, e domain.Event) {
+	if len(e.Tags) == 0 {
+		return
+	}
+	byTag := idx[userID]
+	if byTag == nil {
+		byTag = make(map[string]map[string]struct{})
+		idx[userID] = byTag
+	}
+	for _, tag := range e.Tags {
+		if byTag[tag] == nil {
+			byTag[tag] = make(map[string]struct{})
+		}
+		byTag[tag][e.ID] = struct{}{}
+	}
+}
+
+// unindexTags убирает событие из индекса userID и чистит опустевшие записи.
+func unindexTags(idx map[int]map[string]map[string]struct{}, userID int, e domain.Event) {
+	byTag := idx[userID]
+	if byTag == nil {
+		return
+	}
+	for _, tag := range e.Tags {
+		delete(byTag[tag], e.ID)
+		if len(byTag[tag]) == 0 {
+			delete(byTag, tag)
+		}
+	}
+	if len(byTag) == 0 {
+		delete(idx, userID)
+	}
+}
+
+// indexUID добавляет событие в индекс UID его организатора.
+func indexUID(idx map[int]map[string]map[string]struct{}, e domain.Event) {
+	if e.UID == "" {
+		return
+	}
+	byUID := idx[e.UserID]
+	if byUID == nil {
+		byUID = make(map[string]map[string]struct{})
+		idx[e.UserID] = byUID
+	}
+	if byUID[e.UID] == nil {
+		byUID[e.UID] = make(map[string]struct{})
+	}
+	byUID[e.UID][e.ID] = struct{}{}
+}
+
+// unindexUID убирает событие из индекса UID и чистит опустевшие записи.
+func unindexUID(idx map[int]map[string]map[string]struct{}, e domain.Event) {
+	byUID := idx[e.UserID]
+	if byUID == nil {
+		return
+	}
+	delete(byUID[e.UID], e.ID)
+	if len(byUID[e.UID]) == 0 {
+		delete(byUID, e.UID)
+	}
+	if len(byUID) == 0 {
+		delete(idx, e.UserID)
+	}
+}
+
+// lookupTags объединяет (или пересекает при matchAll) множества ID из индекса тегов.
+func lookupTags(byTag map[string]map[string]struct{}, tags []string, matchAll bool) map[string]struct{} {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	if !matchAll {
+		ids := make(map[string]struct{})
+		for _, tag := range tags {
+			for id := range byTag[tag] {
+				ids[id] = struct{}{}
+			}
+		}
+		return ids
+	}
+
+	// Пересечение начинаем с самого маленького множества.
+	smallest := byTag[tags[0]]
+	for _, tag := range tags[1:] {
+		if len(byTag[tag]) < len(smallest) {
+			smallest = byTag[tag]
+		}
+	}
+	ids := make(map[string]struct{})
+	for id := range smallest {
+		all := true
+		for _, tag := range tags {
+			if _, ok := byTag[tag][id]; !ok {
+				all = false
+				break
+			}
+		}
+		if all {
+			ids[id] = struct{}{}
+		}
+	}
+	return ids
+}
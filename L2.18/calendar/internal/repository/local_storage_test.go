@@ -39,7 +39,7 @@ func TestCreate(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			repo := NewLocalStorage()
-			id, err := repo.Create(tt.event)
+			id, err := repo.Create(t.Context(), tt.event)
 			if err != nil {
 				t.Fatalf("Create() error = %v", err)
 			}
@@ -79,7 +79,7 @@ func TestUpdate(t *testing.T) {
 		Title:  "Original",
 		Date:   now,
 	}
-	id, err := repo.Create(event)
+	id, err := repo.Create(t.Context(), event)
 	if err != nil {
 		t.Fatalf("Create() error = %v", err)
 	}
@@ -113,7 +113,7 @@ func TestUpdate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := repo.Update(tt.event)
+			err := repo.Update(t.Context(), tt.event)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Update() error = %v, wantErr = %t", err, tt.wantErr)
 				return
@@ -136,7 +136,7 @@ func TestUpdate(t *testing.T) {
 func TestDelete(t *testing.T) {
 	repo := NewLocalStorage()
 	event := domain.Event{UserID: 1, Title: "To delete", Date: time.Now()}
-	id, _ := repo.Create(event)
+	id, _ := repo.Create(t.Context(), event)
 
 	tests := []struct {
 		name    string
@@ -151,10 +151,10 @@ func TestDelete(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			testRepo := NewLocalStorage()
 			if tt.id == id {
-				testRepo.Create(event)
+				testRepo.Create(t.Context(), event)
 			}
 
-			err := testRepo.Delete(tt.id)
+			err := testRepo.Delete(t.Context(), tt.id)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Delete() error = %v, wantErr = %t", err, tt.wantErr)
 			}
@@ -181,7 +181,7 @@ func TestGetByUserAndRange(t *testing.T) {
 	}
 	var ids []string
 	for _, e := range events {
-		id, _ := repo.Create(e)
+		id, _ := repo.Create(t.Context(), e)
 		ids = append(ids, id)
 	}
 
@@ -217,7 +217,7 @@ func TestGetByUserAndRange(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := repo.GetByUserAndRange(tt.userID, tt.start, tt.end)
+			got, err := repo.GetByUserAndRange(t.Context(), tt.userID, tt.start, tt.end)
 			if err != nil {
 				t.Fatalf("GetByUserAndRange() error = %v", err)
 			}
@@ -232,3 +232,182 @@ func TestGetByUserAndRange(t *testing.T) {
 		})
 	}
 }
+
+func TestGetByUserTagsAndRange(t *testing.T) {
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	repo := NewLocalStorage()
+
+	events := []domain.Event{
+		{UserID: 1, ID: "a", Title: "work+urgent", Date: day, EventAttrs: domain.EventAttrs{Tags: []string{"work", "urgent"}}},
+		{UserID: 1, ID: "b", Title: "work", Date: day, EventAttrs: domain.EventAttrs{Tags: []string{"work"}}},
+		{UserID: 1, ID: "c", Title: "home", Date: day, EventAttrs: domain.EventAttrs{Tags: []string{"home"}}},
+		{UserID: 1, ID: "d", Title: "work, next month", Date: day.AddDate(0, 1, 0), EventAttrs: domain.EventAttrs{Tags: []string{"work"}}},
+		{UserID: 2, ID: "e", Title: "other user", Date: day, EventAttrs: domain.EventAttrs{Tags: []string{"work"}}},
+		{UserID: 1, ID: "f", Title: "untagged", Date: day},
+	}
+	for _, e := range events {
+		if _, err := repo.Create(t.Context(), e); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		tags     []string
+		matchAll bool
+		want     map[string]bool
+	}{
+		{name: "single tag", tags: []string{"work"}, want: map[string]bool{"a": true, "b": true}},
+		{name: "any of", tags: []string{"urgent", "home"}, want: map[string]bool{"a": true, "c": true}},
+		{name: "all of", tags: []string{"work", "urgent"}, matchAll: true, want: map[string]bool{"a": true}},
+		{name: "all of with unknown tag", tags: []string{"work", "missing"}, matchAll: true, want: map[string]bool{}},
+		{name: "unknown tag", tags: []string{"missing"}, want: map[string]bool{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.GetByUserTagsAndRange(t.Context(), 1, tt.tags, tt.matchAll, day, day.AddDate(0, 0, 1))
+			if err != nil {
+				t.Fatalf("GetByUserTagsAndRange() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("got %d events, want %d", len(got), len(tt.want))
+			}
+			for _, e := range got {
+				if !tt.want[e.ID] {
+					t.Errorf("unexpected event ID: %s", e.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestTagIndexFollowsUpdatesAndDeletes(t *testing.T) {
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	repo := NewLocalStorage()
+
+	id, _ := repo.Create(t.Context(), domain.Event{UserID: 1, Title: "t", Date: day, EventAttrs: domain.EventAttrs{Tags: []string{"old"}}})
+
+	count := func(tag string) int {
+		got, err := repo.GetByUserTagsAndRange(t.Context(), 1, []string{tag}, false, day, day.AddDate(0, 0, 1))
+		if err != nil {
+			t.Fatalf("GetByUserTagsAndRange() error = %v", err)
+		}
+		return len(got)
+	}
+
+	if err := repo.Update(t.Context(), domain.Event{ID: id, UserID: 1, Title: "t", Date: day, EventAttrs: domain.EventAttrs{Tags: []string{"new"}}}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if count("old") != 0 || count("new") != 1 {
+		t.Errorf("index not updated: old=%d new=%d", count("old"), count("new"))
+	}
+
+	if err := repo.Delete(t.Context(), id); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if count("new") != 0 {
+		t.Errorf("deleted event still indexed")
+	}
+	if len(repo.tags) != 0 {
+		t.Errorf("empty index entries left behind: %v", repo.tags)
+	}
+}
+
+func TestAttendeesSeeInvitations(t *testing.T) {
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	repo := NewLocalStorage()
+
+	id, _ := repo.Create(t.Context(), domain.Event{UserID: 1, Title: "sync", Date: day, EventAttrs: domain.EventAttrs{
+		Tags:      []string{"work"},
+		Attendees: []domain.Attendee{{UserID: 2, Status: domain.StatusNeedsAction}},
+	}})
+
+	for _, userID := range []int{1, 2} {
+		got, _ := repo.GetByUserAndRange(t.Context(), userID, day, day.AddDate(0, 0, 1))
+		if len(got) != 1 || got[0].ID != id {
+			t.Errorf("GetByUserAndRange(%d) = %v, want event %s", userID, got, id)
+		}
+		got, _ = repo.GetByUserTagsAndRange(t.Context(), userID, []string{"work"}, false, day, day.AddDate(0, 0, 1))
+		if len(got) != 1 {
+			t.Errorf("GetByUserTagsAndRange(%d) returned %d events, want 1", userID, len(got))
+		}
+	}
+	if got, _ := repo.GetByUserAndRange(t.Context(), 3, day, day.AddDate(0, 0, 1)); len(got) != 0 {
+		t.Errorf("uninvited user sees %d events", len(got))
+	}
+}
+
+func TestSetAttendeeStatus(t *testing.T) {
+	repo := NewLocalStorage()
+	id, _ := repo.Create(t.Context(), domain.Event{UserID: 1, Title: "sync", EventAttrs: domain.EventAttrs{
+		Attendees: []domain.Attendee{{UserID: 2, Status: domain.StatusNeedsAction}},
+	}})
+	before, _ := repo.GetByID(t.Context(), id)
+
+	tests := []struct {
+		name    string
+		id      string
+		userID  int
+		wantErr error
+	}{
+		{name: "invited user", id: id, userID: 2},
+		{name: "not invited", id: id, userID: 3, wantErr: domain.ErrNotInvited},
+		{name: "missing event", id: "missing", userID: 2, wantErr: domain.ErrEventNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := repo.SetAttendeeStatus(t.Context(), tt.id, tt.userID, domain.StatusAccepted)
+			if err != tt.wantErr {
+				t.Fatalf("SetAttendeeStatus() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	after, err := repo.GetByID(t.Context(), id)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if after.Attendees[0].Status != domain.StatusAccepted {
+		t.Errorf("status = %q, want accepted", after.Attendees[0].Status)
+	}
+	if before.Attendees[0].Status != domain.StatusNeedsAction {
+		t.Errorf("previously returned event was modified in place")
+	}
+}
+
+func TestListDeletedNewestFirst(t *testing.T) {
+	repo := NewLocalStorage()
+	clock := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	repo.now = func() time.Time { return clock }
+
+	for _, id := range []string{"a", "b", "c"} {
+		repo.Create(t.Context(), domain.Event{ID: id, UserID: 1})
+	}
+	for _, id := range []string{"b", "c", "a"} {
+		if err := repo.Delete(t.Context(), id); err != nil {
+			t.Fatalf("Delete(%s) error = %v", id, err)
+		}
+		if id != "c" {
+			clock = clock.Add(time.Minute) // c и a удалены в один момент
+		}
+	}
+
+	trash, err := repo.ListDeleted(t.Context(), 1)
+	if err != nil {
+		t.Fatalf("ListDeleted() error = %v", err)
+	}
+	var got []string
+	for _, d := range trash {
+		got = append(got, d.ID)
+	}
+	if want := []string{"a", "c", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ListDeleted() = %v, want %v", got, want)
+	}
+
+	purged, _ := repo.Purge(t.Context(), clock.Add(-time.Minute))
+	if len(purged) != 1 || purged[0].ID != "b" {
+		t.Errorf("Purge() = %v, want only b", purged)
+	}
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"calendar/internal/domain"
+	"context"
+	"sync"
+)
+
+type SettingsRepository interface {
+	GetSettings(ctx context.Context, userID int) (domain.UserSettings, error)
+	SaveSettings(ctx context.Context, s domain.UserSettings) error
+}
+
+type localSettingsStorage struct {
+	mu       sync.RWMutex
+	settings map[int]domain.UserSettings
+}
+
+func NewLocalSettingsStorage() *localSettingsStorage {
+	return &localSettingsStorage{
+		settings: make(map[int]domain.UserSettings),
+	}
+}
+
+// GetSettings возвращает сохранённые настройки; если их нет — пустые (значения по умолчанию).
+func (s *localSettingsStorage) GetSettings(ctx context.Context, userID int) (domain.UserSettings, error) {
+	if err := aborted(ctx, "GetSettings"); err != nil {
+		return domain.UserSettings{}, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if st, ok := s.settings[userID]; ok {
+		return st, nil
+	}
+	return domain.UserSettings{UserID: userID}, nil
+}
+
+func (s *localSettingsStorage) SaveSettings(ctx context.Context, st domain.UserSettings) error {
+	if err := aborted(ctx, "SaveSettings"); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.settings[st.UserID] = st
+	return nil
+}
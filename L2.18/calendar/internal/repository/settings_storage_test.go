@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"calendar/internal/domain"
+	"reflect"
+	"testing"
+)
+
+func TestSettings(t *testing.T) {
+	repo := NewLocalSettingsStorage()
+
+	got, err := repo.GetSettings(t.Context(), 1)
+	if err != nil {
+		t.Fatalf("GetSettings() error = %v", err)
+	}
+	if want := (domain.UserSettings{UserID: 1}); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetSettings() for unknown user = %+v, want %+v", got, want)
+	}
+
+	saved := domain.UserSettings{
+		UserID: 1,
+		PeriodOptions: domain.PeriodOptions{
+			Align:     domain.AlignCalendar,
+			WeekStart: "sunday",
+			TimeZone:  "Europe/Moscow",
+		},
+	}
+	if err := repo.SaveSettings(t.Context(), saved); err != nil {
+		t.Fatalf("SaveSettings() error = %v", err)
+	}
+
+	got, err = repo.GetSettings(t.Context(), 1)
+	if err != nil {
+		t.Fatalf("GetSettings() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, saved) {
+		t.Errorf("GetSettings() = %+v, want %+v", got, saved)
+	}
+
+	if other, _ := repo.GetSettings(t.Context(), 2); other.Align != "" {
+		t.Errorf("settings leaked to another user: %+v", other)
+	}
+}
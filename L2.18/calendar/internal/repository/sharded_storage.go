@@ -0,0 +1,647 @@
+package repository
+
+import (
+	"calendar/internal/domain"
+	"context"
+	"fmt"
+	"hash/fnv"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// shardedStorage — in-memory хранилище, разбитое на шарды по ID пользователя.
+// У каждого шарда свой мьютекс, поэтому записи разных пользователей не ждут друг друга.
+//
+// Событие живёт в шарде организатора. Приглашённые хранят в своих шардах только ссылку
+// (ID события -> организатор) и свой индекс тегов. Два шардовых мьютекса никогда
+// не берутся одновременно, так что взаимных блокировок нет. Запись одного события
+// затрагивает несколько шардов, поэтому изменения одного ID сериализуются через eventLocks.
+//
+// Удалённое событие лежит в корзине шарда своего организатора, приглашения на него снимаются.
+type shardedStorage struct {
+	shards     []*shard
+	eventLocks [256]sync.Mutex
+	owners     sync.Map // ID события -> ID организатора
+	deleted    sync.Map // ID события в корзине -> ID организатора
+	nextID     atomic.Int64
+	now        func() time.Time
+}
+
+type shard struct {
+	mu      sync.RWMutex
+	events  map[string]domain.Event                // события, которые организуют пользователи шарда
+	own     map[int]map[string]struct{}            // организатор -> ID его событий
+	invites map[int]map[string]int                 // участник -> ID события -> организатор
+	tags    map[int]map[string]map[string]struct{} // пользователь шарда -> tag -> event IDs
+	uids    map[int]map[string]map[string]struct{} // организатор -> UID -> event IDs
+	trash   map[string]domain.DeletedEvent         // удалённые события организаторов шарда
+}
+
+// NewShardedStorage создаёт хранилище из n шардов (n <= 0 — по 4 шарда на ядро).
+func NewShardedStorage(n int) *shardedStorage {
+	if n <= 0 {
+		n = 4 * runtime.GOMAXPROCS(0)
+	}
+	s := &shardedStorage{shards: make([]*shard, n), now: time.Now}
+	for i := range s.shards {
+		s.shards[i] = &shard{
+			events:  make(map[string]domain.Event),
+			own:     make(map[int]map[string]struct{}),
+			invites: make(map[int]map[string]int),
+			tags:    make(map[int]map[string]map[string]struct{}),
+			uids:    make(map[int]map[string]map[string]struct{}),
+			trash:   make(map[string]domain.DeletedEvent),
+		}
+	}
+	return s
+}
+
+func (s *shardedStorage) shardFor(userID int) *shard {
+	return s.shards[uint(userID)%uint(len(s.shards))]
+}
+
+func (s *shardedStorage) lockEvent(id string) func() {
+	h := fnv.New32a()
+	h.Write([]byte(id))
+	mu := &s.eventLocks[h.Sum32()%uint32(len(s.eventLocks))]
+	mu.Lock()
+	return mu.Unlock
+}
+
+func (s *shardedStorage) owner(id string) (int, bool) {
+	v, ok := s.owners.Load(id)
+	if !ok {
+		return 0, false
+	}
+	return v.(int), true
+}
+
+func (s *shardedStorage) Create(ctx context.Context, e domain.Event) (string, error) {
+	if err := aborted(ctx, "Create"); err != nil {
+		return "", err
+	}
+
+	if e.ID == "" {
+		e.ID = fmt.Sprintf("event_%d", s.nextID.Add(1))
+	}
+	defer s.lockEvent(e.ID)()
+
+	// Событие с явным ID перезаписывает прежнее, как и в localStorage.
+	s.evict(e.ID)
+	s.owners.Store(e.ID, e.UserID)
+	s.shardFor(e.UserID).put(e)
+	s.addInvites(e)
+	return e.ID, nil
+}
+
+// evict убирает всё, что хранится под id: живое событие с приглашениями и запись в корзине
+// (новое событие с ID из корзины занимает его — восстанавливать прежнее уже некуда).
+// Вызывается под блокировкой id.
+func (s *shardedStorage) evict(id string) {
+	if ownerID, exists := s.owner(id); exists {
+		if old, ok := s.shardFor(ownerID).remove(id); ok {
+			s.dropInvites(old)
+		}
+		s.owners.Delete(id)
+	}
+	if v, ok := s.deleted.LoadAndDelete(id); ok {
+		s.shardFor(v.(int)).untrash(id)
+	}
+}
+
+// lockAll берёт блокировки всех ID по порядку и останавливает все записи, кроме смены
+// статуса участника, которая меняет один шард атомарно.
+func (s *shardedStorage) lockAll() func() {
+	for i := range s.eventLocks {
+		s.eventLocks[i].Lock()
+	}
+	return func() {
+		for i := range s.eventLocks {
+			s.eventLocks[i].Unlock()
+		}
+	}
+}
+
+func (s *shardedStorage) Snapshot(ctx context.Context) (domain.Snapshot, error) {
+	if err := aborted(ctx, "Snapshot"); err != nil {
+		return domain.Snapshot{}, err
+	}
+
+	defer s.lockAll()()
+
+	var snap domain.Snapshot
+	for _, sh := range s.shards {
+		sh.mu.RLock()
+		for _, e := range sh.events {
+			snap.Events = append(snap.Events, e)
+		}
+		for _, d := range sh.trash {
+			snap.Deleted = append(snap.Deleted, d)
+		}
+		sh.mu.RUnlock()
+	}
+	return snap, nil
+}
+
+func (s *shardedStorage) Load(ctx context.Context, snap domain.Snapshot, replace bool) error {
+	if err := aborted(ctx, "Load"); err != nil {
+		return err
+	}
+
+	defer s.lockAll()()
+
+	if replace {
+		for _, sh := range s.shards {
+			sh.mu.Lock()
+			clear(sh.events)
+			clear(sh.own)
+			clear(sh.invites)
+			clear(sh.tags)
+			clear(sh.uids)
+			clear(sh.trash)
+			sh.mu.Unlock()
+		}
+		s.owners.Clear()
+		s.deleted.Clear()
+	}
+
+	var seq int64
+	for _, e := range snap.Events {
+		s.evict(e.ID)
+		s.owners.Store(e.ID, e.UserID)
+		s.shardFor(e.UserID).put(e)
+		s.addInvites(e)
+		seq = max(seq, idSeq(e.ID))
+	}
+	for _, d := range snap.Deleted {
+		s.evict(d.ID)
+		s.shardFor(d.UserID).putTrash(d)
+		s.deleted.Store(d.ID, d.UserID)
+		seq = max(seq, idSeq(d.ID))
+	}
+	// Create берёт номер до блокировки, поэтому счётчик двигаем только вперёд.
+	for {
+		cur := s.nextID.Load()
+		if seq <= cur || s.nextID.CompareAndSwap(cur, seq) {
+			break
+		}
+	}
+	return nil
+}
+
+func (s *shardedStorage) Update(ctx context.Context, e domain.Event) error {
+	if err := aborted(ctx, "Update"); err != nil {
+		return err
+	}
+
+	defer s.lockEvent(e.ID)()
+
+	ownerID, exists := s.owner(e.ID)
+	if !exists {
+		return domain.ErrEventNotFound
+	}
+
+	var (
+		old domain.Event
+		ok  bool
+	)
+	if ownerID == e.UserID {
+		old, ok = s.shardFor(ownerID).replace(e)
+	} else {
+		// Сменился организатор — событие переезжает в другой шард.
+		if old, ok = s.shardFor(ownerID).remove(e.ID); ok {
+			s.owners.Store(e.ID, e.UserID)
+			s.shardFor(e.UserID).put(e)
+		}
+	}
+	if !ok {
+		return domain.ErrEventNotFound
+	}
+
+	s.dropInvites(old)
+	s.addInvites(e)
+	return nil
+}
+
+func (s *shardedStorage) Delete(ctx context.Context, id string) error {
+	if err := aborted(ctx, "Delete"); err != nil {
+		return err
+	}
+
+	defer s.lockEvent(id)()
+
+	ownerID, exists := s.owner(id)
+	if !exists {
+		return domain.ErrEventNotFound
+	}
+	old, ok := s.shardFor(ownerID).moveToTrash(id, s.now())
+	if !ok {
+		return domain.ErrEventNotFound
+	}
+	s.owners.Delete(id)
+	s.deleted.Store(id, ownerID)
+	s.dropInvites(old)
+	return nil
+}
+
+func (s *shardedStorage) ListDeleted(ctx context.Context, userID int) ([]domain.DeletedEvent, error) {
+	if err := aborted(ctx, "ListDeleted"); err != nil {
+		return nil, err
+	}
+
+	sh := s.shardFor(userID)
+	sh.mu.RLock()
+	var result []domain.DeletedEvent
+	for _, d := range sh.trash {
+		if d.UserID == userID {
+			result = append(result, d)
+		}
+	}
+	sh.mu.RUnlock()
+
+	sortDeleted(result)
+	return result, nil
+}
+
+func (s *shardedStorage) Restore(ctx context.Context, id string) (domain.Event, error) {
+	if err := aborted(ctx, "Restore"); err != nil {
+		return domain.Event{}, err
+	}
+
+	defer s.lockEvent(id)()
+
+	v, ok := s.deleted.Load(id)
+	if !ok {
+		return domain.Event{}, domain.ErrEventNotFound
+	}
+	ownerID := v.(int)
+	e, ok := s.shardFor(ownerID).restore(id)
+	if !ok {
+		return domain.Event{}, domain.ErrEventNotFound
+	}
+	s.deleted.Delete(id)
+	s.owners.Store(id, ownerID)
+	s.addInvites(e)
+	return e, nil
+}
+
+func (s *shardedStorage) Purge(ctx context.Context, before time.Time) ([]domain.Event, error) {
+	if err := aborted(ctx, "Purge"); err != nil {
+		return nil, err
+	}
+
+	var purged []domain.Event
+	for _, sh := range s.shards {
+		sh.mu.RLock()
+		var ids []string
+		for id, d := range sh.trash {
+			if d.DeletedAt.Before(before) {
+				ids = append(ids, id)
+			}
+		}
+		sh.mu.RUnlock()
+
+		// Пока шард был свободен, событие могли восстановить: под блокировкой ID проверяем заново.
+		for _, id := range ids {
+			unlock := s.lockEvent(id)
+			if d, ok := sh.purge(id, before); ok {
+				s.deleted.Delete(id)
+				purged = append(purged, d.Event)
+			}
+			unlock()
+		}
+	}
+	return purged, nil
+}
+
+func (s *shardedStorage) GetByID(ctx context.Context, id string) (domain.Event, error) {
+	if err := aborted(ctx, "GetByID"); err != nil {
+		return domain.Event{}, err
+	}
+
+	ownerID, exists := s.owner(id)
+	if !exists {
+		return domain.Event{}, domain.ErrEventNotFound
+	}
+	e, ok := s.shardFor(ownerID).get(id)
+	if !ok {
+		return domain.Event{}, domain.ErrEventNotFound
+	}
+	return e, nil
+}
+
+func (s *shardedStorage) SetAttendeeStatus(ctx context.Context, id string, userID int, status domain.AttendeeStatus) error {
+	if err := aborted(ctx, "SetAttendeeStatus"); err != nil {
+		return err
+	}
+
+	ownerID, exists := s.owner(id)
+	if !exists {
+		return domain.ErrEventNotFound
+	}
+
+	sh := s.shardFor(ownerID)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	e, ok := sh.events[id]
+	if !ok {
+		return domain.ErrEventNotFound
+	}
+	attendees, err := withStatus(e.Attendees, userID, status)
+	if err != nil {
+		return err
+	}
+	e.Attendees = attendees
+	sh.events[id] = e
+	return nil
+}
+
+func (s *shardedStorage) GetByUserAndRange(ctx context.Context, userID int, start, end time.Time) ([]domain.Event, error) {
+	if err := aborted(ctx, "GetByUserAndRange"); err != nil {
+		return nil, err
+	}
+
+	sh := s.shardFor(userID)
+	sh.mu.RLock()
+	var result []domain.Event
+	for id := range sh.own[userID] {
+		if e := sh.events[id]; inRange(e, start, end) {
+			result = append(result, e)
+		}
+	}
+	invites := copyRefs(sh.invites[userID], nil)
+	sh.mu.RUnlock()
+
+	return s.appendInvited(ctx, result, userID, invites, start, end, nil)
+}
+
+func (s *shardedStorage) GetByUserTagsAndRange(ctx context.Context, userID int, tags []string, matchAll bool, start, end time.Time) ([]domain.Event, error) {
+	if err := aborted(ctx, "GetByUserTagsAndRange"); err != nil {
+		return nil, err
+	}
+
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	sh := s.shardFor(userID)
+	sh.mu.RLock()
+	var result []domain.Event
+	ids := lookupTags(sh.tags[userID], tags, matchAll)
+	for id := range ids {
+		if e, ok := sh.events[id]; ok && e.UserID == userID && inRange(e, start, end) {
+			result = append(result, e)
+		}
+	}
+	invites := copyRefs(sh.invites[userID], ids)
+	sh.mu.RUnlock()
+
+	match := func(e domain.Event) bool { return hasTags(e, tags, matchAll) }
+	return s.appendInvited(ctx, result, userID, invites, start, end, match)
+}
+
+func (s *shardedStorage) GetByUID(ctx context.Context, userID int, uid string) ([]domain.Event, error) {
+	if err := aborted(ctx, "GetByUID"); err != nil {
+		return nil, err
+	}
+
+	sh := s.shardFor(userID)
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+
+	var result []domain.Event
+	for id := range sh.uids[userID][uid] {
+		result = append(result, sh.events[id])
+	}
+	return result, nil
+}
+
+func (s *shardedStorage) CountByUser(ctx context.Context, userID int) (int, error) {
+	if err := aborted(ctx, "CountByUser"); err != nil {
+		return 0, err
+	}
+
+	sh := s.shardFor(userID)
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+	return len(sh.own[userID]), nil
+}
+
+func (s *shardedStorage) CountPerUser(ctx context.Context) (map[int]int, error) {
+	if err := aborted(ctx, "CountPerUser"); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int]int)
+	for _, sh := range s.shards {
+		sh.mu.RLock()
+		for userID, ids := range sh.own {
+			counts[userID] = len(ids)
+		}
+		sh.mu.RUnlock()
+	}
+	return counts, nil
+}
+
+// appendInvited дочитывает события, на которые приглашён userID, из шардов их организаторов.
+// Между чтениями шардов событие могло измениться, поэтому приглашение и теги проверяются заново.
+func (s *shardedStorage) appendInvited(ctx context.Context, result []domain.Event, userID int, invites map[string]int, start, end time.Time, match func(domain.Event) bool) ([]domain.Event, error) {
+	for id, ownerID := range invites {
+		// Каждое приглашение — отдельное чтение чужого шарда, отмену проверяем на каждом.
+		if err := aborted(ctx, "appendInvited"); err != nil {
+			return nil, err
+		}
+		e, ok := s.shardFor(ownerID).get(id)
+		if !ok || !e.HasAttendee(userID) || !inRange(e, start, end) {
+			continue
+		}
+		if match != nil && !match(e) {
+			continue
+		}
+		result = append(result, e)
+	}
+	return result, nil
+}
+
+// addInvites регистрирует событие у каждого участника — в их собственных шардах.
+func (s *shardedStorage) addInvites(e domain.Event) {
+	for _, a := range e.Attendees {
+		if a.UserID == e.UserID {
+			continue // организатор и так видит событие
+		}
+		sh := s.shardFor(a.UserID)
+		sh.mu.Lock()
+		refs := sh.invites[a.UserID]
+		if refs == nil {
+			refs = make(map[string]int)
+			sh.invites[a.UserID] = refs
+		}
+		refs[e.ID] = e.UserID
+		indexTags(sh.tags, a.UserID, e)
+		sh.mu.Unlock()
+	}
+}
+
+func (s *shardedStorage) dropInvites(e domain.Event) {
+	for _, a := range e.Attendees {
+		if a.UserID == e.UserID {
+			continue
+		}
+		sh := s.shardFor(a.UserID)
+		sh.mu.Lock()
+		delete(sh.invites[a.UserID], e.ID)
+		if len(sh.invites[a.UserID]) == 0 {
+			delete(sh.invites, a.UserID)
+		}
+		unindexTags(sh.tags, a.UserID, e)
+		sh.mu.Unlock()
+	}
+}
+
+func (sh *shard) get(id string) (domain.Event, bool) {
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+	e, ok := sh.events[id]
+	return e, ok
+}
+
+// put сохраняет событие организатора и индексирует его теги.
+func (sh *shard) put(e domain.Event) {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	sh.store(e)
+}
+
+// replace заменяет событие и возвращает прежнюю версию.
+func (sh *shard) replace(e domain.Event) (domain.Event, bool) {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	old, ok := sh.events[e.ID]
+	if !ok {
+		return domain.Event{}, false
+	}
+	sh.drop(old)
+	sh.store(e)
+	return old, true
+}
+
+func (sh *shard) remove(id string) (domain.Event, bool) {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	old, ok := sh.events[id]
+	if ok {
+		sh.drop(old)
+	}
+	return old, ok
+}
+
+// moveToTrash убирает событие из шарда в корзину и возвращает его.
+func (sh *shard) moveToTrash(id string, at time.Time) (domain.Event, bool) {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	old, ok := sh.events[id]
+	if ok {
+		sh.drop(old)
+		sh.trash[id] = domain.DeletedEvent{Event: old, DeletedAt: at}
+	}
+	return old, ok
+}
+
+// restore возвращает событие из корзины в шард.
+func (sh *shard) restore(id string) (domain.Event, bool) {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	d, ok := sh.trash[id]
+	if !ok {
+		return domain.Event{}, false
+	}
+	delete(sh.trash, id)
+	sh.store(d.Event)
+	return d.Event, true
+}
+
+func (sh *shard) putTrash(d domain.DeletedEvent) {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	sh.trash[d.ID] = d
+}
+
+func (sh *shard) untrash(id string) {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	delete(sh.trash, id)
+}
+
+// purge удаляет событие из корзины, если оно попало туда раньше before.
+func (sh *shard) purge(id string, before time.Time) (domain.DeletedEvent, bool) {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	d, ok := sh.trash[id]
+	if !ok || !d.DeletedAt.Before(before) {
+		return domain.DeletedEvent{}, false
+	}
+	delete(sh.trash, id)
+	return d, true
+}
+
+func (sh *shard) store(e domain.Event) {
+	sh.events[e.ID] = e
+	if sh.own[e.UserID] == nil {
+		sh.own[e.UserID] = make(map[string]struct{})
+	}
+	sh.own[e.UserID][e.ID] = struct{}{}
+	indexTags(sh.tags, e.UserID, e)
+	indexUID(sh.uids, e)
+}
+
+func (sh *shard) drop(e domain.Event) {
+	delete(sh.events, e.ID)
+	delete(sh.own[e.UserID], e.ID)
+	if len(sh.own[e.UserID]) == 0 {
+		delete(sh.own, e.UserID)
+	}
+	unindexTags(sh.tags, e.UserID, e)
+	unindexUID(sh.uids, e)
+}
+
+// copyRefs копирует ссылки на приглашения (только из ids, если он не nil),
+// чтобы читать шарды организаторов уже после освобождения своего.
+func copyRefs(refs map[string]int, ids map[string]struct{}) map[string]int {
+	res := make(map[string]int)
+	for id, ownerID := range refs {
+		if ids != nil {
+			if _, ok := ids[id]; !ok {
+				continue
+			}
+		}
+		res[id] = ownerID
+	}
+	return res
+}
+
+func hasTags(e domain.Event, tags []string, matchAll bool) bool {
+	for _, tag := range tags {
+		found := false
+		for _, t := range e.Tags {
+			if t == tag {
+				found = true
+				break
+			}
+		}
+		if found && !matchAll {
+			return true
+		}
+		if !found && matchAll {
+			return false
+		}
+	}
+	return matchAll
+}
@@ -0,0 +1,610 @@
+package repository
+
+import (
+	"calendar/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// storages — все реализации EventRepository, которые обязаны вести себя одинаково.
+var storages = map[string]func() EventRepository{
+	"local":   func() EventRepository { return NewLocalStorage() },
+	"sharded": func() EventRepository { return NewShardedStorage(8) },
+	"cached":  func() EventRepository { return NewCachedStorage(NewShardedStorage(8), 64, time.Minute) },
+}
+
+func eventIDs(events []domain.Event) []string {
+	res := []string{}
+	for _, e := range events {
+		res = append(res, e.ID)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func TestEventRepositoryContract(t *testing.T) {
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	next := day.AddDate(0, 0, 1)
+
+	for name, newRepo := range storages {
+		t.Run(name, func(t *testing.T) {
+			repo := newRepo()
+
+			events := []domain.Event{
+				{ID: "a", UserID: 1, Title: "own", Date: day, EventAttrs: domain.EventAttrs{Tags: []string{"work", "urgent"}}},
+				{ID: "b", UserID: 1, Title: "later", Date: day.AddDate(0, 0, 3), EventAttrs: domain.EventAttrs{Tags: []string{"work"}}},
+				{ID: "c", UserID: 2, Title: "invite", Date: day, EventAttrs: domain.EventAttrs{
+					Tags:      []string{"work"},
+					Attendees: []domain.Attendee{{UserID: 1, Status: domain.StatusNeedsAction}},
+				}},
+				{ID: "d", UserID: 3, Title: "foreign", Date: day, EventAttrs: domain.EventAttrs{Tags: []string{"work"}}},
+			}
+			for _, e := range events {
+				if _, err := repo.Create(t.Context(), e); err != nil {
+					t.Fatalf("Create() error = %v", err)
+				}
+			}
+
+			got, _ := repo.GetByUserAndRange(t.Context(), 1, day, next)
+			if want := []string{"a", "c"}; fmt.Sprint(eventIDs(got)) != fmt.Sprint(want) {
+				t.Errorf("GetByUserAndRange = %v, want %v", eventIDs(got), want)
+			}
+			got, _ = repo.GetByUserTagsAndRange(t.Context(), 1, []string{"work"}, false, day, next)
+			if want := []string{"a", "c"}; fmt.Sprint(eventIDs(got)) != fmt.Sprint(want) {
+				t.Errorf("tags any = %v, want %v", eventIDs(got), want)
+			}
+			got, _ = repo.GetByUserTagsAndRange(t.Context(), 1, []string{"work", "urgent"}, true, day, next)
+			if want := []string{"a"}; fmt.Sprint(eventIDs(got)) != fmt.Sprint(want) {
+				t.Errorf("tags all = %v, want %v", eventIDs(got), want)
+			}
+
+			if err := repo.SetAttendeeStatus(t.Context(), "c", 1, domain.StatusDeclined); err != nil {
+				t.Fatalf("SetAttendeeStatus() error = %v", err)
+			}
+			if err := repo.SetAttendeeStatus(t.Context(), "c", 3, domain.StatusDeclined); err != domain.ErrNotInvited {
+				t.Errorf("SetAttendeeStatus(uninvited) error = %v, want %v", err, domain.ErrNotInvited)
+			}
+			c, err := repo.GetByID(t.Context(), "c")
+			if err != nil || c.Attendees[0].Status != domain.StatusDeclined {
+				t.Errorf("GetByID() = %+v, %v", c, err)
+			}
+
+			// Участника убрали — событие пропадает из его выборок.
+			c.Attendees = nil
+			c.Tags = []string{"home"}
+			if err := repo.Update(t.Context(), c); err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+			got, _ = repo.GetByUserAndRange(t.Context(), 1, day, next)
+			if want := []string{"a"}; fmt.Sprint(eventIDs(got)) != fmt.Sprint(want) {
+				t.Errorf("after uninvite = %v, want %v", eventIDs(got), want)
+			}
+			got, _ = repo.GetByUserTagsAndRange(t.Context(), 2, []string{"home"}, false, day, next)
+			if want := []string{"c"}; fmt.Sprint(eventIDs(got)) != fmt.Sprint(want) {
+				t.Errorf("organiser tags after update = %v, want %v", eventIDs(got), want)
+			}
+
+			if err := repo.Delete(t.Context(), "a"); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			if _, err := repo.GetByID(t.Context(), "a"); err != domain.ErrEventNotFound {
+				t.Errorf("GetByID(deleted) error = %v", err)
+			}
+			if err := repo.Delete(t.Context(), "a"); err != domain.ErrEventNotFound {
+				t.Errorf("Delete(deleted) error = %v", err)
+			}
+			if err := repo.Update(t.Context(), domain.Event{ID: "missing"}); err != domain.ErrEventNotFound {
+				t.Errorf("Update(missing) error = %v", err)
+			}
+		})
+	}
+}
+
+func TestShardedStorage_GeneratesUniqueIDs(t *testing.T) {
+	repo := NewShardedStorage(4)
+
+	const workers, perWorker = 16, 200
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		seen = make(map[string]bool)
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(userID int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				id, _ := repo.Create(t.Context(), domain.Event{UserID: userID})
+				mu.Lock()
+				if seen[id] {
+					t.Errorf("duplicate ID %s", id)
+				}
+				seen[id] = true
+				mu.Unlock()
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if len(seen) != workers*perWorker {
+		t.Errorf("got %d IDs, want %d", len(seen), workers*perWorker)
+	}
+}
+
+// TestShardedStorage_Stress гоняет все операции параллельно; смысл — под go test -race.
+// В конце индексы приглашений должны совпадать с самими событиями.
+func TestShardedStorage_Stress(t *testing.T) {
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	repo := NewShardedStorage(4)
+
+	const users, rounds = 8, 300
+	var wg sync.WaitGroup
+	for u := 1; u <= users; u++ {
+		wg.Add(1)
+		go func(userID int) {
+			defer wg.Done()
+			guest := userID%users + 1
+			for i := 0; i < rounds; i++ {
+				e := domain.Event{UserID: userID, Title: "t", Date: day, EventAttrs: domain.EventAttrs{
+					Tags:      []string{"work"},
+					Attendees: []domain.Attendee{{UserID: guest, Status: domain.StatusNeedsAction}},
+				}}
+				id, _ := repo.Create(t.Context(), e)
+
+				e.ID = id
+				e.Tags = []string{"home"}
+				if i%2 == 0 {
+					e.Attendees = nil
+				}
+				repo.Update(t.Context(), e)
+				repo.SetAttendeeStatus(t.Context(), id, guest, domain.StatusAccepted)
+				repo.GetByUserAndRange(t.Context(), guest, day, day.AddDate(0, 0, 1))
+				repo.GetByUserTagsAndRange(t.Context(), guest, []string{"home"}, false, day, day.AddDate(0, 0, 1))
+				if i%3 == 0 {
+					repo.Delete(t.Context(), id)
+				}
+			}
+		}(u)
+	}
+	wg.Wait()
+
+	for u := 1; u <= users; u++ {
+		got, _ := repo.GetByUserAndRange(t.Context(), u, day, day.AddDate(0, 0, 1))
+		var own, invited int
+		for _, e := range got {
+			if e.UserID == u {
+				own++
+			} else if e.HasAttendee(u) {
+				invited++
+			} else {
+				t.Fatalf("user %d sees foreign event %s", u, e.ID)
+			}
+		}
+		// Из rounds событий удалено каждое третье, участник остаётся в нечётных.
+		if want := rounds - rounds/3; own != want {
+			t.Errorf("user %d owns %d events, want %d", u, own, want)
+		}
+		if want := rounds/2 - rounds/6; invited != want {
+			t.Errorf("user %d is invited to %d events, want %d", u, invited, want)
+		}
+	}
+}
+
+// Бенчмарки записи: каждый горутин-воркер пишет от своего пользователя.
+// go test -bench Parallel -cpu 1,2,4,8 ./internal/repository
+func BenchmarkCreateParallel(b *testing.B) {
+	for name, newRepo := range storages {
+		b.Run(name, func(b *testing.B) {
+			repo := newRepo()
+			var users atomic.Int64
+			date := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+			b.RunParallel(func(pb *testing.PB) {
+				userID := int(users.Add(1))
+				for pb.Next() {
+					repo.Create(b.Context(), domain.Event{UserID: userID, Title: "bench", Date: date})
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkMixedParallel(b *testing.B) {
+	for name, newRepo := range storages {
+		b.Run(name, func(b *testing.B) {
+			repo := newRepo()
+			var users atomic.Int64
+			date := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+			b.RunParallel(func(pb *testing.PB) {
+				userID := int(users.Add(1))
+				e := domain.Event{UserID: userID, Title: "bench", Date: date, EventAttrs: domain.EventAttrs{Tags: []string{"work"}}}
+				e.ID, _ = repo.Create(b.Context(), e)
+				for i := 0; pb.Next(); i++ {
+					switch i % 4 {
+					case 0:
+						repo.Create(b.Context(), domain.Event{UserID: userID, Title: "bench", Date: date})
+					case 1:
+						repo.Update(b.Context(), e)
+					default:
+						repo.GetByUserTagsAndRange(b.Context(), userID, []string{"work"}, false, date, date.AddDate(0, 0, 1))
+					}
+				}
+			})
+		})
+	}
+}
+
+func TestEventRepositoryHonoursCancellation(t *testing.T) {
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	for name, newRepo := range storages {
+		t.Run(name, func(t *testing.T) {
+			repo := newRepo()
+			id, err := repo.Create(t.Context(), domain.Event{UserID: 1, Date: day})
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+
+			ctx, cancel := context.WithCancel(t.Context())
+			cancel()
+
+			if _, err := repo.Create(ctx, domain.Event{UserID: 1, Date: day}); !errors.Is(err, context.Canceled) {
+				t.Errorf("Create() error = %v, want %v", err, context.Canceled)
+			}
+			if err := repo.Delete(ctx, id); !errors.Is(err, context.Canceled) {
+				t.Errorf("Delete() error = %v, want %v", err, context.Canceled)
+			}
+			if _, err := repo.GetByUserAndRange(ctx, 1, day, day.AddDate(0, 0, 1)); !errors.Is(err, context.Canceled) {
+				t.Errorf("GetByUserAndRange() error = %v, want %v", err, context.Canceled)
+			}
+
+			// Отменённые вызовы ничего не изменили.
+			got, _ := repo.GetByUserAndRange(t.Context(), 1, day, day.AddDate(0, 0, 1))
+			if len(got) != 1 || got[0].ID != id {
+				t.Errorf("GetByUserAndRange() = %v, want only %s", got, id)
+			}
+		})
+	}
+}
+
+func TestEventRepositoryTrash(t *testing.T) {
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	next := day.AddDate(0, 0, 1)
+
+	for name, newRepo := range storages {
+		t.Run(name, func(t *testing.T) {
+			repo := newRepo()
+			ctx := t.Context()
+
+			for _, e := range []domain.Event{
+				{ID: "a", UserID: 1, Date: day, EventAttrs: domain.EventAttrs{
+					Tags:      []string{"work"},
+					Attendees: []domain.Attendee{{UserID: 2, Status: domain.StatusAccepted}},
+				}},
+				{ID: "b", UserID: 1, Date: day},
+				{ID: "c", UserID: 2, Date: day},
+			} {
+				if _, err := repo.Create(ctx, e); err != nil {
+					t.Fatalf("Create() error = %v", err)
+				}
+			}
+			// Кеш должен увидеть удаление и восстановление.
+			repo.GetByUserAndRange(ctx, 2, day, next)
+
+			for _, id := range []string{"a", "b", "c"} {
+				if err := repo.Delete(ctx, id); err != nil {
+					t.Fatalf("Delete(%s) error = %v", id, err)
+				}
+			}
+			got, _ := repo.GetByUserAndRange(ctx, 2, day, next)
+			if len(got) != 0 {
+				t.Errorf("GetByUserAndRange after delete = %v, want none", eventIDs(got))
+			}
+
+			trash, err := repo.ListDeleted(ctx, 1)
+			if err != nil {
+				t.Fatalf("ListDeleted() error = %v", err)
+			}
+			var ids []string
+			for _, d := range trash {
+				if d.DeletedAt.IsZero() {
+					t.Errorf("ListDeleted(): %s has no DeletedAt", d.ID)
+				}
+				ids = append(ids, d.ID)
+			}
+			sort.Strings(ids)
+			if want := []string{"a", "b"}; fmt.Sprint(ids) != fmt.Sprint(want) {
+				t.Errorf("ListDeleted(1) = %v, want %v", ids, want)
+			}
+
+			// Восстановленное событие снова видно организатору и участникам, в том числе по тегам.
+			restored, err := repo.Restore(ctx, "a")
+			if err != nil || restored.ID != "a" || !restored.HasAttendee(2) {
+				t.Fatalf("Restore() = %+v, %v", restored, err)
+			}
+			got, _ = repo.GetByUserAndRange(ctx, 2, day, next)
+			if want := []string{"a"}; fmt.Sprint(eventIDs(got)) != fmt.Sprint(want) {
+				t.Errorf("attendee after restore = %v, want %v", eventIDs(got), want)
+			}
+			got, _ = repo.GetByUserTagsAndRange(ctx, 2, []string{"work"}, false, day, next)
+			if want := []string{"a"}; fmt.Sprint(eventIDs(got)) != fmt.Sprint(want) {
+				t.Errorf("attendee tags after restore = %v, want %v", eventIDs(got), want)
+			}
+			if _, err := repo.Restore(ctx, "a"); err != domain.ErrEventNotFound {
+				t.Errorf("Restore(live) error = %v, want %v", err, domain.ErrEventNotFound)
+			}
+
+			// Новое событие с ID из корзины вытесняет удалённое.
+			if _, err := repo.Create(ctx, domain.Event{ID: "c", UserID: 3, Date: day}); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if trash, _ := repo.ListDeleted(ctx, 2); len(trash) != 0 {
+				t.Errorf("ListDeleted(2) after reuse of ID = %v, want empty", trash)
+			}
+
+			purged, err := repo.Purge(ctx, time.Now().Add(-time.Hour))
+			if err != nil || len(purged) != 0 {
+				t.Errorf("Purge(past) = %v, %v; want nothing", eventIDs(purged), err)
+			}
+			purged, err = repo.Purge(ctx, time.Now().Add(time.Hour))
+			if want := []string{"b"}; err != nil || fmt.Sprint(eventIDs(purged)) != fmt.Sprint(want) {
+				t.Errorf("Purge() = %v, %v; want %v", eventIDs(purged), err, want)
+			}
+			if _, err := repo.Restore(ctx, "b"); err != domain.ErrEventNotFound {
+				t.Errorf("Restore(purged) error = %v, want %v", err, domain.ErrEventNotFound)
+			}
+			if trash, _ := repo.ListDeleted(ctx, 1); len(trash) != 0 {
+				t.Errorf("ListDeleted(1) after purge = %v, want empty", trash)
+			}
+		})
+	}
+}
+
+func TestEventRepositoryCounts(t *testing.T) {
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	for name, newRepo := range storages {
+		t.Run(name, func(t *testing.T) {
+			repo := newRepo()
+			ctx := t.Context()
+
+			for _, e := range []domain.Event{
+				{ID: "a", UserID: 1, Date: day},
+				{ID: "b", UserID: 1, Date: day},
+				{ID: "c", UserID: 2, Date: day, EventAttrs: domain.EventAttrs{Attendees: []domain.Attendee{{UserID: 1}}}},
+				{ID: "b", UserID: 1, Date: day.AddDate(0, 0, 1)}, // перезапись не добавляет событие
+			} {
+				if _, err := repo.Create(ctx, e); err != nil {
+					t.Fatalf("Create() error = %v", err)
+				}
+			}
+			check := func(step string, want map[int]int) {
+				t.Helper()
+				got, err := repo.CountPerUser(ctx)
+				if err != nil {
+					t.Fatalf("%s: CountPerUser() error = %v", step, err)
+				}
+				if fmt.Sprint(got) != fmt.Sprint(want) {
+					t.Errorf("%s: CountPerUser() = %v, want %v", step, got, want)
+				}
+				for _, userID := range []int{1, 2, 3} {
+					if n, _ := repo.CountByUser(ctx, userID); n != want[userID] {
+						t.Errorf("%s: CountByUser(%d) = %d, want %d", step, userID, n, want[userID])
+					}
+				}
+			}
+
+			check("created", map[int]int{1: 2, 2: 1})
+
+			if err := repo.Update(ctx, domain.Event{ID: "b", UserID: 3, Date: day}); err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+			check("organiser changed", map[int]int{1: 1, 2: 1, 3: 1})
+
+			if err := repo.Delete(ctx, "a"); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			check("deleted", map[int]int{2: 1, 3: 1})
+
+			if _, err := repo.Restore(ctx, "a"); err != nil {
+				t.Fatalf("Restore() error = %v", err)
+			}
+			check("restored", map[int]int{1: 1, 2: 1, 3: 1})
+		})
+	}
+}
+
+func TestEventRepositoryGetByUID(t *testing.T) {
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	withUID := func(id string, userID int, uid string) domain.Event {
+		return domain.Event{ID: id, UserID: userID, Date: day, EventAttrs: domain.EventAttrs{UID: uid}}
+	}
+
+	for name, newRepo := range storages {
+		t.Run(name, func(t *testing.T) {
+			repo := newRepo()
+			ctx := t.Context()
+
+			for _, e := range []domain.Event{
+				withUID("a", 1, "standup"),
+				withUID("b", 2, "standup"), // у другого организатора свой UID
+				withUID("c", 1, ""),
+			} {
+				if _, err := repo.Create(ctx, e); err != nil {
+					t.Fatalf("Create() error = %v", err)
+				}
+			}
+			check := func(step string, userID int, uid string, want []string) {
+				t.Helper()
+				got, err := repo.GetByUID(ctx, userID, uid)
+				if err != nil {
+					t.Fatalf("%s: GetByUID() error = %v", step, err)
+				}
+				if fmt.Sprint(eventIDs(got)) != fmt.Sprint(want) {
+					t.Errorf("%s: GetByUID(%d, %q) = %v, want %v", step, userID, uid, eventIDs(got), want)
+				}
+			}
+
+			check("created", 1, "standup", []string{"a"})
+			check("created", 2, "standup", []string{"b"})
+			check("no uid", 1, "", []string{})
+
+			if err := repo.Update(ctx, withUID("a", 1, "retro")); err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+			check("uid changed", 1, "standup", []string{})
+			check("uid changed", 1, "retro", []string{"a"})
+
+			if err := repo.Update(ctx, withUID("a", 3, "retro")); err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+			check("organiser changed", 1, "retro", []string{})
+			check("organiser changed", 3, "retro", []string{"a"})
+
+			if err := repo.Delete(ctx, "a"); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			check("deleted", 3, "retro", []string{})
+
+			if _, err := repo.Restore(ctx, "a"); err != nil {
+				t.Fatalf("Restore() error = %v", err)
+			}
+			check("restored", 3, "retro", []string{"a"})
+
+			if err := repo.Load(ctx, domain.Snapshot{Events: []domain.Event{withUID("d", 1, "retro")}}, true); err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			check("loaded", 3, "retro", []string{})
+			check("loaded", 1, "retro", []string{"d"})
+		})
+	}
+}
+
+func TestEventRepositorySnapshotAndLoad(t *testing.T) {
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	next := day.AddDate(0, 0, 1)
+
+	// Снимок любого хранилища загружается в любое другое.
+	for srcName, newSrc := range storages {
+		for dstName, newDst := range storages {
+			t.Run(srcName+"->"+dstName, func(t *testing.T) {
+				ctx := t.Context()
+				src := newSrc()
+				for _, e := range []domain.Event{
+					{UserID: 1, Title: "own", Date: day, EventAttrs: domain.EventAttrs{Tags: []string{"work"}}},
+					{UserID: 2, Title: "invite", Date: day, EventAttrs: domain.EventAttrs{
+						Attendees: []domain.Attendee{{UserID: 1, Status: domain.StatusAccepted}},
+					}},
+					{UserID: 1, Title: "trashed", Date: day},
+				} {
+					if _, err := src.Create(ctx, e); err != nil {
+						t.Fatalf("Create() error = %v", err)
+					}
+				}
+				if err := src.Delete(ctx, "event_3"); err != nil {
+					t.Fatalf("Delete() error = %v", err)
+				}
+				snap, err := src.Snapshot(ctx)
+				if err != nil {
+					t.Fatalf("Snapshot() error = %v", err)
+				}
+				if len(snap.Events) != 2 || len(snap.Deleted) != 1 {
+					t.Fatalf("Snapshot() = %d events, %d deleted; want 2, 1", len(snap.Events), len(snap.Deleted))
+				}
+
+				dst := newDst()
+				for _, e := range []domain.Event{
+					{ID: "event_1", UserID: 5, Title: "overwritten", Date: day},
+					{ID: "keep", UserID: 1, Title: "kept on merge", Date: day},
+				} {
+					dst.Create(ctx, e)
+				}
+				dst.GetByUserAndRange(ctx, 1, day, next) // прогреваем кеш
+
+				if err := dst.Load(ctx, snap, false); err != nil {
+					t.Fatalf("Load(merge) error = %v", err)
+				}
+				got, _ := dst.GetByUserAndRange(ctx, 1, day, next)
+				if want := []string{"event_1", "event_2", "keep"}; fmt.Sprint(eventIDs(got)) != fmt.Sprint(want) {
+					t.Errorf("after merge = %v, want %v", eventIDs(got), want)
+				}
+				if e, _ := dst.GetByID(ctx, "event_1"); e.Title != "own" {
+					t.Errorf("merge kept %q, want the event from the snapshot", e.Title)
+				}
+				if n, _ := dst.CountByUser(ctx, 5); n != 0 {
+					t.Errorf("CountByUser(5) = %d after its event was replaced", n)
+				}
+
+				if err := dst.Load(ctx, snap, true); err != nil {
+					t.Fatalf("Load(replace) error = %v", err)
+				}
+				got, _ = dst.GetByUserAndRange(ctx, 1, day, next)
+				if want := []string{"event_1", "event_2"}; fmt.Sprint(eventIDs(got)) != fmt.Sprint(want) {
+					t.Errorf("after replace = %v, want %v", eventIDs(got), want)
+				}
+				got, _ = dst.GetByUserTagsAndRange(ctx, 1, []string{"work"}, false, day, next)
+				if want := []string{"event_1"}; fmt.Sprint(eventIDs(got)) != fmt.Sprint(want) {
+					t.Errorf("tags after replace = %v, want %v", eventIDs(got), want)
+				}
+				if _, err := dst.Restore(ctx, "event_3"); err != nil {
+					t.Errorf("Restore(from snapshot trash) error = %v", err)
+				}
+
+				// Новые ID продолжают нумерацию загруженных.
+				id, _ := dst.Create(ctx, domain.Event{UserID: 1, Date: day})
+				if id != "event_4" {
+					t.Errorf("Create() after Load = %s, want event_4", id)
+				}
+			})
+		}
+	}
+}
+
+// Смена организатора переносит событие между шардами; снимок не должен поймать его посередине.
+func TestShardedStorage_SnapshotIsConsistent(t *testing.T) {
+	repo := NewShardedStorage(8)
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	const n = 50
+	for i := range n {
+		repo.Create(t.Context(), domain.Event{UserID: i, Date: day})
+	}
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			id := fmt.Sprintf("event_%d", i%n+1)
+			repo.Update(t.Context(), domain.Event{ID: id, UserID: i, Date: day})
+			if i%7 == 0 {
+				repo.Delete(t.Context(), id)
+				repo.Restore(t.Context(), id)
+			}
+		}
+	})
+
+	for range 200 {
+		snap, err := repo.Snapshot(t.Context())
+		if err != nil {
+			t.Fatalf("Snapshot() error = %v", err)
+		}
+		if got := len(snap.Events) + len(snap.Deleted); got != n {
+			close(stop)
+			wg.Wait()
+			t.Fatalf("Snapshot() has %d events, want %d", got, n)
+		}
+	}
+	close(stop)
+	wg.Wait()
+}
@@ -0,0 +1,18 @@
+// Package reqlog пишет в стандартный лог, добавляя ID запроса из контекста
+// (его кладёт middleware.RequestID), чтобы строки разных слоёв одного запроса можно было связать.
+package reqlog
+
+import (
+	"context"
+	"log"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// Printf работает как log.Printf; если в ctx есть ID запроса, строка начинается с него.
+func Printf(ctx context.Context, format string, v ...interface{}) {
+	if id := middleware.GetReqID(ctx); id != "" {
+		format = "[" + id + "] " + format
+	}
+	log.Printf(format, v...)
+}
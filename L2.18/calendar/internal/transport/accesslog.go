@@ -0,0 +1,118 @@
+package transport
+
+import (
+	"calendar/internal/domain"
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// WithAccessLog задаёт журнал запросов. По умолчанию — slog.Default().
+func WithAccessLog(logger *slog.Logger) HandlerOption {
+	return func(h *Handler) {
+		h.accessLog = logger
+	}
+}
+
+// accessEntry — то, что хендлер узнаёт о запросе по ходу разбора и что нужно
+// журналу после ответа. Middleware кладёт его в контекст до вызова хендлера.
+type accessEntry struct {
+	userID int
+}
+
+type accessKey struct{}
+
+// setAccessUser запоминает пользователя запроса для журнала.
+func setAccessUser(ctx context.Context, userID int) {
+	if e, ok := ctx.Value(accessKey{}).(*accessEntry); ok {
+		e.userID = userID
+	}
+}
+
+// noteBodyUser достаёт user_id из уже разобранного тела запроса.
+func noteBodyUser(r *http.Request, v any) {
+	switch req := v.(type) {
+	case *createRequest:
+		setAccessUser(r.Context(), req.UserID)
+	case *updateRequest:
+		setAccessUser(r.Context(), req.UserID)
+	case *deleteRequest:
+		setAccessUser(r.Context(), req.UserID)
+	case *respondRequest:
+		setAccessUser(r.Context(), req.UserID)
+	case *deleteAttachmentRequest:
+		setAccessUser(r.Context(), req.UserID)
+	case *domain.UserSettings:
+		setAccessUser(r.Context(), req.UserID)
+	}
+}
+
+// accessLogMiddleware пишет по строке на запрос: ID запроса, пользователь,
+// шаблон маршрута (/caldav/{userID}/..., а не конкретный путь), статус, размер
+// и длительность. 5xx пишутся с уровнем ERROR.
+func (h *Handler) accessLogMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		entry := &accessEntry{}
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		next.ServeHTTP(ww, r.WithContext(context.WithValue(r.Context(), accessKey{}, entry)))
+
+		attrs := []slog.Attr{
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.String("route", routePattern(r)),
+			slog.String("remote", r.RemoteAddr),
+			slog.Int("status", ww.Status()),
+			slog.Int("bytes", ww.BytesWritten()),
+			slog.Duration("duration", time.Since(start)),
+		}
+		if userID := requestUser(r, entry); userID != 0 {
+			attrs = append(attrs, slog.Int("user_id", userID))
+		}
+
+		level := slog.LevelInfo
+		if ww.Status() >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		h.logger().LogAttrs(r.Context(), level, "request", attrs...)
+	})
+}
+
+func (h *Handler) logger() *slog.Logger {
+	if h.accessLog != nil {
+		return h.accessLog
+	}
+	return slog.Default()
+}
+
+// requestUser — пользователь из тела запроса, query-параметра user_id или пути CalDAV.
+func requestUser(r *http.Request, entry *accessEntry) int {
+	if entry.userID != 0 {
+		return entry.userID
+	}
+	if id, err := strconv.Atoi(r.URL.Query().Get("user_id")); err == nil {
+		return id
+	}
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		if id, err := strconv.Atoi(rctx.URLParam("userID")); err == nil {
+			return id
+		}
+	}
+	return 0
+}
+
+// routePattern — шаблон сработавшего маршрута; chi заполняет его по ходу
+// маршрутизации, поэтому читать его можно только после обработки.
+func routePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		return rctx.RoutePattern()
+	}
+	return ""
+}
@@ -0,0 +1,66 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"calendar/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessLog(t *testing.T) {
+	uc := mocks.NewMockEventUseCase(t)
+	uc.EXPECT().DeleteEvent(mock.Anything, "evt-1", 7).Return(nil).Once()
+	uc.EXPECT().ListTrash(mock.Anything, 3).Return(nil, nil).Once()
+	uc.EXPECT().GetEventsInRange(mock.Anything, 5, mock.Anything, mock.Anything).Return(nil, nil).Once()
+	uc.EXPECT().DeleteEvent(mock.Anything, "evt-2", 7).Return(errors.New("disk on fire")).Once()
+
+	var buf bytes.Buffer
+	router := NewRouter(NewHandler(uc, WithAccessLog(slog.New(slog.NewJSONHandler(&buf, nil)))))
+
+	requests := []*http.Request{
+		httptest.NewRequest(http.MethodPost, "/delete_event", strings.NewReader(`{"id":"evt-1","user_id":7}`)),
+		httptest.NewRequest(http.MethodGet, "/trash?user_id=3", nil),
+		httptest.NewRequest("PROPFIND", "/dav/calendars/5/", strings.NewReader(`<propfind xmlns="DAV:"><prop><getetag/></prop></propfind>`)),
+		httptest.NewRequest(http.MethodPost, "/delete_event", strings.NewReader(`{"id":"evt-2","user_id":7}`)),
+		httptest.NewRequest(http.MethodGet, "/nowhere", nil),
+	}
+	requests[2].Header.Set("Depth", "1")
+	for _, req := range requests {
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	type line struct {
+		Level     string `json:"level"`
+		RequestID string `json:"request_id"`
+		Method    string `json:"method"`
+		Route     string `json:"route"`
+		Status    int    `json:"status"`
+		UserID    int    `json:"user_id"`
+	}
+	var lines []line
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var l line
+		require.NoError(t, dec.Decode(&l))
+		require.NotEmpty(t, l.RequestID)
+		l.RequestID = ""
+		lines = append(lines, l)
+	}
+
+	require.Equal(t, []line{
+		{Level: "INFO", Method: "POST", Route: "/delete_event", Status: http.StatusOK, UserID: 7},
+		{Level: "INFO", Method: "GET", Route: "/trash", Status: http.StatusOK, UserID: 3},
+		{Level: "INFO", Method: "PROPFIND", Route: "/dav/calendars/{userID}", Status: http.StatusMultiStatus, UserID: 5},
+		{Level: "ERROR", Method: "POST", Route: "/delete_event", Status: http.StatusInternalServerError, UserID: 7},
+		{Level: "INFO", Method: "GET", Route: "", Status: http.StatusNotFound},
+	}, lines)
+}
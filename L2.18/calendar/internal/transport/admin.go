@@ -0,0 +1,94 @@
+package transport
+
+import (
+	"calendar/internal/domain"
+	"calendar/internal/reqlog"
+	"crypto/subtle"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// WithAdminToken открывает маршруты /admin/* для запросов с заголовком
+// «Authorization: Bearer <token>». Без токена они отвечают 403.
+func WithAdminToken(token string) HandlerOption {
+	return func(h *Handler) {
+		h.adminToken = token
+	}
+}
+
+func (h *Handler) adminRoutes(r chi.Router) {
+	r.Use(h.requireAdmin)
+	r.Get("/usage", h.Usage)
+	r.Get("/backup", h.Backup)
+	r.Post("/restore", h.LoadBackup)
+}
+
+func (h *Handler) requireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := []byte(r.Header.Get("Authorization"))
+		want := []byte("Bearer " + h.adminToken)
+		if h.adminToken == "" || subtle.ConstantTimeCompare(got, want) != 1 {
+			h.handleLogicError(w, r, domain.ErrAdminRequired)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Usage показывает квоты и сколько событий сейчас у каждого пользователя.
+func (h *Handler) Usage(w http.ResponseWriter, r *http.Request) {
+	report, err := h.uc.Usage(r.Context())
+	if err != nil {
+		h.handleLogicError(w, r, err)
+		return
+	}
+	h.sendJSON(w, http.StatusOK, report)
+}
+
+// Backup отдаёт дамп всех событий: gzip с NDJSON, снятый на один момент времени.
+func (h *Handler) Backup(w http.ResponseWriter, r *http.Request) {
+	name := "calendar-" + time.Now().UTC().Format("20060102-150405") + ".ndjson.gz"
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+name+`"`)
+
+	cw := &countingWriter{w: w}
+	if _, err := h.uc.Backup(r.Context(), cw); err != nil {
+		if cw.n > 0 {
+			// Ответ уже начат: клиент получит оборванный gzip и не сможет его распаковать.
+			reqlog.Printf(r.Context(), "[ERROR] backup interrupted after %d bytes: %v", cw.n, err)
+			return
+		}
+		w.Header().Del("Content-Disposition")
+		h.handleLogicError(w, r, err)
+	}
+}
+
+// LoadBackup загружает дамп из тела запроса: ?mode=merge (по умолчанию) или ?mode=replace.
+func (h *Handler) LoadBackup(w http.ResponseWriter, r *http.Request) {
+	mode := r.URL.Query().Get("mode")
+	if mode == "" {
+		mode = "merge"
+	}
+
+	summary, err := h.uc.LoadBackup(r.Context(), r.Body, mode)
+	if err != nil {
+		h.handleLogicError(w, r, err)
+		return
+	}
+	h.sendJSON(w, http.StatusOK, summary)
+}
+
+// countingWriter считает записанные байты, чтобы понять, начат ли уже ответ.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
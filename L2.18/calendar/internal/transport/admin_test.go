@@ -0,0 +1,106 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"calendar/internal/domain"
+	"calendar/internal/repository"
+	"calendar/internal/usecase"
+	"calendar/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminUsage(t *testing.T) {
+	report := domain.UsageReport{
+		Quota: domain.Quota{MaxEvents: 100},
+		Users: []domain.UserUsage{{UserID: 7, Events: 42}, {UserID: 1, Events: 3}},
+	}
+
+	tests := []struct {
+		name       string
+		token      string
+		auth       string
+		wantStatus int
+	}{
+		{name: "admin disabled", auth: "Bearer ", wantStatus: http.StatusForbidden},
+		{name: "no header", token: "s3cret", wantStatus: http.StatusForbidden},
+		{name: "wrong token", token: "s3cret", auth: "Bearer guess", wantStatus: http.StatusForbidden},
+		{name: "ok", token: "s3cret", auth: "Bearer s3cret", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := mocks.NewMockEventUseCase(t)
+			if tt.wantStatus == http.StatusOK {
+				uc.EXPECT().Usage(mock.Anything).Return(report, nil).Once()
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/usage", nil)
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			rec := httptest.NewRecorder()
+			NewRouter(NewHandler(uc, WithAdminToken(tt.token))).ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus != http.StatusOK {
+				var p problem
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+				require.Equal(t, "admin_required", p.Code)
+				return
+			}
+			var resp struct{ Result domain.UsageReport }
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			require.Equal(t, report, resp.Result)
+		})
+	}
+}
+
+func TestAdminBackupAndRestore(t *testing.T) {
+	admin := func(h http.Handler, method, url string, body []byte) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, url, bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer s3cret")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	srcUC := usecase.NewEventUseCase(repository.NewLocalStorage())
+	for _, title := range []string{"One", "Two"} {
+		_, err := srcUC.CreateEvent(t.Context(), 1, "2026-10-20", title, domain.EventAttrs{})
+		require.NoError(t, err)
+	}
+	src := NewRouter(NewHandler(srcUC, WithAdminToken("s3cret")))
+
+	rec := admin(src, http.MethodGet, "/admin/backup", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/gzip", rec.Header().Get("Content-Type"))
+	require.Regexp(t, `^attachment; filename="calendar-\d{8}-\d{6}\.ndjson\.gz"$`, rec.Header().Get("Content-Disposition"))
+	dump := rec.Body.Bytes()
+
+	dstUC := usecase.NewEventUseCase(repository.NewShardedStorage(4))
+	dst := NewRouter(NewHandler(dstUC, WithAdminToken("s3cret")))
+
+	rec = admin(dst, http.MethodPost, "/admin/restore?mode=replace", dump)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"result":{"events":2,"deleted":0}}`, rec.Body.String())
+	events, err := dstUC.GetEventsForDay(t.Context(), 1, "2026-10-20", domain.PeriodOptions{}, domain.EventFilter{})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	rec = admin(dst, http.MethodPost, "/admin/restore?mode=upsert", dump)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), `"code":"backup_invalid"`)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/restore", strings.NewReader(""))
+	rec = httptest.NewRecorder()
+	dst.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusForbidden, rec.Code)
+}
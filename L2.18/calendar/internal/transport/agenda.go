@@ -0,0 +1,213 @@
+package transport
+
+import (
+	"bytes"
+	"calendar/internal/domain"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"net/http"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates/agenda.html.tmpl templates/agenda.txt.tmpl
+var templatesFS embed.FS
+
+// locale — строки и названия дат для одного языка повестки.
+type locale struct {
+	title    string
+	noEvents string
+	allDay   string
+	weekdays [7]string
+	months   [12]string // для ru — в родительном падеже: «16 октября»
+}
+
+var locales = map[string]locale{
+	"en": {
+		title:    "Agenda",
+		noEvents: "No events",
+		allDay:   "all day",
+		weekdays: [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		months: [12]string{"January", "February", "March", "April", "May", "June",
+			"July", "August", "September", "October", "November", "December"},
+	},
+	"ru": {
+		title:    "Повестка",
+		noEvents: "Нет событий",
+		allDay:   "весь день",
+		weekdays: [7]string{"Воскресенье", "Понедельник", "Вторник", "Среда", "Четверг", "Пятница", "Суббота"},
+		months: [12]string{"января", "февраля", "марта", "апреля", "мая", "июня",
+			"июля", "августа", "сентября", "октября", "ноября", "декабря"},
+	},
+}
+
+func (l locale) date(t time.Time) string {
+	return fmt.Sprintf("%d %s %d", t.Day(), l.months[t.Month()-1], t.Year())
+}
+
+func (l locale) day(t time.Time) string {
+	return l.weekdays[t.Weekday()] + ", " + l.date(t)
+}
+
+// clock выводит время события; события без времени (ровно полночь) идут как «весь день».
+func (l locale) clock(t time.Time) string {
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+		return l.allDay
+	}
+	return t.Format("15:04")
+}
+
+func (l locale) funcs() map[string]interface{} {
+	return map[string]interface{}{"day": l.day, "clock": l.clock}
+}
+
+// agendaView — данные для шаблонов повестки.
+type agendaView struct {
+	Lang     string
+	Title    string
+	NoEvents string
+	Days     []domain.AgendaDay
+}
+
+// renderAgenda рендерит повестку в HTML (format = "html") или обычный текст (format = "text").
+func renderAgenda(a domain.Agenda, format, lang string) ([]byte, error) {
+	l, ok := locales[lang]
+	if !ok {
+		return nil, fmt.Errorf("unsupported language %q", lang)
+	}
+
+	view := agendaView{
+		Lang:     lang,
+		Title:    l.title + ": " + l.date(a.From),
+		NoEvents: l.noEvents,
+		Days:     a.Days,
+	}
+	if last := a.To.AddDate(0, 0, -1); len(a.Days) > 1 {
+		view.Title += " – " + l.date(last)
+	}
+
+	templates, err := agendaTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	switch format {
+	case "html":
+		err = templates[lang].html.Execute(&buf, view)
+		return buf.Bytes(), err
+	case "text":
+		err = templates[lang].text.Execute(&buf, view)
+		return buf.Bytes(), err
+	}
+	return nil, fmt.Errorf("unsupported format %q", format)
+}
+
+// localeTemplates — шаблоны повестки с функциями одного языка.
+type localeTemplates struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+// agendaTemplates разбирает шаблоны при первом вызове и даёт каждому языку
+// свою копию: функции day и clock у языков разные.
+var agendaTemplates = sync.OnceValues(func() (map[string]localeTemplates, error) {
+	html, err := htmltemplate.New("agenda.html.tmpl").Funcs(locale{}.funcs()).ParseFS(templatesFS, "templates/agenda.html.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	text, err := texttemplate.New("agenda.txt.tmpl").Funcs(locale{}.funcs()).ParseFS(templatesFS, "templates/agenda.txt.tmpl")
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]localeTemplates, len(locales))
+	for lang, l := range locales {
+		h, err := html.Clone()
+		if err != nil {
+			return nil, err
+		}
+		t, err := text.Clone()
+		if err != nil {
+			return nil, err
+		}
+		res[lang] = localeTemplates{html: h.Funcs(l.funcs()), text: t.Funcs(l.funcs())}
+	}
+	return res, nil
+})
+
+func (h *Handler) AgendaForDay(w http.ResponseWriter, r *http.Request) {
+	h.agenda(w, r, domain.PeriodDay)
+}
+
+func (h *Handler) AgendaForWeek(w http.ResponseWriter, r *http.Request) {
+	h.agenda(w, r, domain.PeriodWeek)
+}
+
+func (h *Handler) AgendaForMonth(w http.ResponseWriter, r *http.Request) {
+	h.agenda(w, r, domain.PeriodMonth)
+}
+
+func (h *Handler) agenda(w http.ResponseWriter, r *http.Request, kind string) {
+	userID, date, err := parseQueryParams(r)
+	if err != nil {
+		h.badRequest(w, r, err)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "html"
+	}
+	if format != "html" && format != "text" {
+		h.badRequest(w, r, errors.New("format must be html or text"))
+		return
+	}
+	lang := agendaLang(r)
+
+	agenda, err := h.uc.GetAgenda(r.Context(), userID, date, kind, parsePeriodOptions(r))
+	if err != nil {
+		h.handleLogicError(w, r, err)
+		return
+	}
+
+	body, err := renderAgenda(agenda, format, lang)
+	if err != nil {
+		h.handleLogicError(w, r, err)
+		return
+	}
+
+	contentType := "text/html; charset=utf-8"
+	if format == "text" {
+		contentType = "text/plain; charset=utf-8"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Language", lang)
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// agendaLang выбирает язык: параметр lang, затем Accept-Language, по умолчанию английский.
+func agendaLang(r *http.Request) string {
+	if lang := strings.ToLower(r.URL.Query().Get("lang")); lang != "" {
+		if _, ok := locales[lang]; ok {
+			return lang
+		}
+	}
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag := strings.ToLower(strings.TrimSpace(strings.SplitN(part, ";", 2)[0]))
+		if _, ok := locales[tag]; ok {
+			return tag
+		}
+		if i := strings.Index(tag, "-"); i > 0 {
+			if _, ok := locales[tag[:i]]; ok {
+				return tag[:i]
+			}
+		}
+	}
+	return "en"
+}
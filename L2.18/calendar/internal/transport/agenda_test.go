@@ -0,0 +1,120 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"calendar/internal/domain"
+	"calendar/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testAgenda() domain.Agenda {
+	day := func(d, h, m int) time.Time { return time.Date(2026, 10, d, h, m, 0, 0, time.UTC) }
+	return domain.Agenda{
+		UserID: 1,
+		From:   day(16, 0, 0),
+		To:     day(18, 0, 0),
+		Days: []domain.AgendaDay{
+			{Date: day(16, 0, 0), Kind: domain.DayWorking, Events: []domain.Event{
+				{ID: "1", UserID: 1, Title: "Holiday", Date: day(16, 0, 0)},
+				{ID: "2", UserID: 1, Title: "<b>Review</b> & sync", Date: day(16, 14, 30)},
+			}},
+			{Date: day(17, 0, 0), Kind: domain.DayHoliday, Holiday: "Company retreat", Events: []domain.Event{}},
+		},
+	}
+}
+
+func getAgenda(t *testing.T, uc *mocks.MockEventUseCase, url string, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	NewRouter(NewHandler(uc)).ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAgenda_HTML(t *testing.T) {
+	uc := mocks.NewMockEventUseCase(t)
+	uc.EXPECT().
+		GetAgenda(mock.Anything, 1, "2026-10-16", domain.PeriodWeek, domain.PeriodOptions{Align: domain.AlignCalendar}).
+		Return(testAgenda(), nil).
+		Once()
+
+	rec := getAgenda(t, uc, "/agenda_for_week?user_id=1&date=2026-10-16&align=calendar", nil)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+	body := rec.Body.String()
+	require.Contains(t, body, `<html lang="en">`)
+	require.Contains(t, body, "Agenda: 16 October 2026 – 17 October 2026")
+	require.Contains(t, body, "<h2>Friday, 16 October 2026</h2>")
+	require.Contains(t, body, `<h2 class="off">Saturday, 17 October 2026 <span class="holiday">— Company retreat</span></h2>`)
+	require.Contains(t, body, `<span class="time">all day</span>Holiday`)
+	require.Contains(t, body, `<span class="time">14:30</span>&lt;b&gt;Review&lt;/b&gt; &amp; sync`)
+	require.Contains(t, body, `<p class="empty">No events</p>`)
+	require.NotContains(t, body, "<b>Review</b>")
+}
+
+func TestAgenda_TextRussian(t *testing.T) {
+	uc := mocks.NewMockEventUseCase(t)
+	uc.EXPECT().
+		GetAgenda(mock.Anything, 1, "2026-10-16", domain.PeriodDay, domain.PeriodOptions{}).
+		Return(testAgenda(), nil).
+		Once()
+
+	rec := getAgenda(t, uc, "/agenda_for_day?user_id=1&date=2026-10-16&format=text",
+		http.Header{"Accept-Language": {"ru-RU,ru;q=0.9,en;q=0.8"}})
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "text/plain; charset=utf-8", rec.Header().Get("Content-Type"))
+	require.Equal(t, "ru", rec.Header().Get("Content-Language"))
+	want := "Повестка: 16 октября 2026 – 17 октября 2026\n" +
+		"\n" +
+		"Пятница, 16 октября 2026\n" +
+		"  весь день  Holiday\n" +
+		"  14:30      <b>Review</b> & sync\n" +
+		"\n" +
+		"Суббота, 17 октября 2026 — Company retreat\n" +
+		"  Нет событий\n"
+	require.Equal(t, want, rec.Body.String())
+}
+
+func TestRenderAgenda_LocalesDoNotMix(t *testing.T) {
+	// Шаблоны общие на весь процесс: языки, отрендеренные вперемешку и параллельно,
+	// не должны подхватывать чужие функции.
+	var wg sync.WaitGroup
+	for range 8 {
+		for lang, want := range map[string]string{"en": "Friday, 16 October 2026", "ru": "Пятница, 16 октября 2026"} {
+			for _, format := range []string{"html", "text"} {
+				wg.Go(func() {
+					body, err := renderAgenda(testAgenda(), format, lang)
+					require.NoError(t, err)
+					require.Contains(t, string(body), want)
+				})
+			}
+		}
+	}
+	wg.Wait()
+}
+
+func TestAgenda_BadRequest(t *testing.T) {
+	uc := mocks.NewMockEventUseCase(t)
+
+	rec := getAgenda(t, uc, "/agenda_for_month?user_id=1&date=2026-10-16&format=pdf", nil)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = getAgenda(t, uc, "/agenda_for_month?date=2026-10-16", nil)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	uc.EXPECT().GetAgenda(mock.Anything, 1, "someday", domain.PeriodMonth, mock.Anything).Return(domain.Agenda{}, domain.ErrDateInvalid).Once()
+	rec = getAgenda(t, uc, "/agenda_for_month?user_id=1&date=someday&lang=ru", nil)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
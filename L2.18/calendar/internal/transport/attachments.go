@@ -0,0 +1,122 @@
+package transport
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+)
+
+type deleteAttachmentRequest struct {
+	ID           string `json:"id"`
+	AttachmentID string `json:"attachment_id"`
+	UserID       int    `json:"user_id"`
+}
+
+// AddAttachment принимает multipart/form-data с файлом в части «file»;
+// событие и пользователь передаются в query: ?id=...&user_id=...
+// Файл читается потоком, не целиком в память.
+func (h *Handler) AddAttachment(w http.ResponseWriter, r *http.Request) {
+	eventID, userID, err := parseEventQuery(r)
+	if err != nil {
+		h.badRequest(w, r, err)
+		return
+	}
+	mr, err := r.MultipartReader()
+	if err != nil {
+		h.badRequest(w, r, err)
+		return
+	}
+
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			h.badRequest(w, r, errors.New(`missing "file" part`))
+			return
+		}
+		if err != nil {
+			h.badRequest(w, r, err)
+			return
+		}
+		if part.FormName() != "file" {
+			part.Close()
+			continue
+		}
+
+		a, err := h.uc.AddAttachment(r.Context(), eventID, userID, part.FileName(), part.Header.Get("Content-Type"), part)
+		part.Close()
+		if err != nil {
+			h.handleLogicError(w, r, err)
+			return
+		}
+		h.sendJSON(w, http.StatusOK, a)
+		return
+	}
+}
+
+// ListAttachments возвращает описания вложений события: ?id=...&user_id=...
+func (h *Handler) ListAttachments(w http.ResponseWriter, r *http.Request) {
+	eventID, userID, err := parseEventQuery(r)
+	if err != nil {
+		h.badRequest(w, r, err)
+		return
+	}
+	list, err := h.uc.ListAttachments(r.Context(), eventID, userID)
+	if err != nil {
+		h.handleLogicError(w, r, err)
+		return
+	}
+	h.sendJSON(w, http.StatusOK, list)
+}
+
+// DownloadAttachment отдаёт содержимое вложения: ?id=...&attachment_id=...&user_id=...
+// Файл всегда скачивается (Content-Disposition: attachment), а nosniff не даёт браузеру
+// счесть его HTML-страницей.
+func (h *Handler) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	eventID, userID, err := parseEventQuery(r)
+	if err != nil {
+		h.badRequest(w, r, err)
+		return
+	}
+	a, rc, err := h.uc.OpenAttachment(r.Context(), eventID, r.URL.Query().Get("attachment_id"), userID)
+	if err != nil {
+		h.handleLogicError(w, r, err)
+		return
+	}
+	defer rc.Close()
+
+	w.Header().Set("Content-Type", a.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(a.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": a.Name}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	io.Copy(w, rc)
+}
+
+func (h *Handler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	var req deleteAttachmentRequest
+	if err := decodeBody(r, &req); err != nil {
+		h.badRequest(w, r, err)
+		return
+	}
+
+	if err := h.uc.DeleteAttachment(r.Context(), req.ID, req.AttachmentID, req.UserID); err != nil {
+		h.handleLogicError(w, r, err)
+		return
+	}
+	h.sendJSON(w, http.StatusOK, map[string]string{"result": "deleted"})
+}
+
+// parseEventQuery читает обязательные параметры id (событие) и user_id.
+func parseEventQuery(r *http.Request) (string, int, error) {
+	q := r.URL.Query()
+	if q.Get("id") == "" || q.Get("user_id") == "" {
+		return "", 0, errors.New("missing id or user_id")
+	}
+	userID, err := strconv.Atoi(q.Get("user_id"))
+	if err != nil {
+		return "", 0, errors.New("invalid user_id")
+	}
+	return q.Get("id"), userID, nil
+}
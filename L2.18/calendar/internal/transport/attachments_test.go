@@ -0,0 +1,108 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"calendar/internal/domain"
+	"calendar/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func multipartBody(t *testing.T, fields map[string]string, fileName, contentType, content string) (*bytes.Buffer, string) {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for k, v := range fields {
+		require.NoError(t, mw.WriteField(k, v))
+	}
+	h := textproto.MIMEHeader{}
+	h.Set("Content-Disposition", `form-data; name="file"; filename="`+fileName+`"`)
+	h.Set("Content-Type", contentType)
+	part, err := mw.CreatePart(h)
+	require.NoError(t, err)
+	io.WriteString(part, content)
+	require.NoError(t, mw.Close())
+	return &body, mw.FormDataContentType()
+}
+
+func TestAddAttachment(t *testing.T) {
+	uc := mocks.NewMockEventUseCase(t)
+	want := domain.Attachment{ID: "att-1", EventID: "evt-1", Name: "slides.pdf", ContentType: "application/pdf", Size: 9}
+	uc.EXPECT().
+		AddAttachment(mock.Anything, "evt-1", 2, "slides.pdf", "application/pdf", mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, _ int, _, _ string, r io.Reader) (domain.Attachment, error) {
+			data, err := io.ReadAll(r)
+			require.NoError(t, err)
+			require.Equal(t, "%PDF-1.7\n", string(data))
+			return want, nil
+		}).
+		Once()
+
+	body, ct := multipartBody(t, map[string]string{"comment": "ignored"}, "slides.pdf", "application/pdf", "%PDF-1.7\n")
+	req := httptest.NewRequest(http.MethodPost, "/add_attachment?id=evt-1&user_id=2", body)
+	req.Header.Set("Content-Type", ct)
+	rec := httptest.NewRecorder()
+	NewRouter(NewHandler(uc)).ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp struct{ Result domain.Attachment }
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Equal(t, want, resp.Result)
+}
+
+func TestAddAttachment_Errors(t *testing.T) {
+	uc := mocks.NewMockEventUseCase(t)
+	uc.EXPECT().AddAttachment(mock.Anything, "evt-1", 1, "big.bin", "application/pdf", mock.Anything).
+		Return(domain.Attachment{}, domain.ErrAttachmentTooLarge).Once()
+	uc.EXPECT().AddAttachment(mock.Anything, "evt-1", 1, "page.html", "text/html", mock.Anything).
+		Return(domain.Attachment{}, domain.ErrAttachmentType).Once()
+
+	post := func(url, fileName, contentType string) *httptest.ResponseRecorder {
+		body, ct := multipartBody(t, nil, fileName, contentType, "data")
+		req := httptest.NewRequest(http.MethodPost, url, body)
+		req.Header.Set("Content-Type", ct)
+		rec := httptest.NewRecorder()
+		NewRouter(NewHandler(uc)).ServeHTTP(rec, req)
+		return rec
+	}
+
+	require.Equal(t, http.StatusRequestEntityTooLarge, post("/add_attachment?id=evt-1&user_id=1", "big.bin", "application/pdf").Code)
+	require.Equal(t, http.StatusUnsupportedMediaType, post("/add_attachment?id=evt-1&user_id=1", "page.html", "text/html").Code)
+	require.Equal(t, http.StatusBadRequest, post("/add_attachment?user_id=1", "x.txt", "text/plain").Code)
+
+	req := httptest.NewRequest(http.MethodPost, "/add_attachment?id=evt-1&user_id=1", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	NewRouter(NewHandler(uc)).ServeHTTP(rec, req)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestDownloadAttachment(t *testing.T) {
+	uc := mocks.NewMockEventUseCase(t)
+	a := domain.Attachment{ID: "att-1", EventID: "evt-1", Name: "план встречи.md", ContentType: "text/markdown", Size: 8}
+	uc.EXPECT().OpenAttachment(mock.Anything, "evt-1", "att-1", 2).
+		Return(a, io.NopCloser(strings.NewReader("# Agenda")), nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/download_attachment?id=evt-1&attachment_id=att-1&user_id=2", nil)
+	rec := httptest.NewRecorder()
+	NewRouter(NewHandler(uc)).ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "# Agenda", rec.Body.String())
+	require.Equal(t, "text/markdown", rec.Header().Get("Content-Type"))
+	require.Equal(t, "8", rec.Header().Get("Content-Length"))
+	require.Equal(t, "nosniff", rec.Header().Get("X-Content-Type-Options"))
+	require.Equal(t, "attachment; filename*=utf-8''%D0%BF%D0%BB%D0%B0%D0%BD%20%D0%B2%D1%81%D1%82%D1%80%D0%B5%D1%87%D0%B8.md",
+		rec.Header().Get("Content-Disposition"))
+}
@@ -0,0 +1,652 @@
+package transport
+
+import (
+	"calendar/internal/domain"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Подмножество CalDAV (RFC 4791) для синхронизации с телефонами и десктопными клиентами:
+//
+//	/dav/principals/{userID}/               — принципал пользователя (PROPFIND)
+//	/dav/calendars/{userID}/                — его календарь (PROPFIND, REPORT)
+//	/dav/calendars/{userID}/{name}.ics      — событие (GET, PUT, DELETE, PROPFIND)
+//
+// В календаре лежат события, которые пользователь организует, и те, куда его пригласили.
+// Ресурс называется по UID события, а у событий, созданных через API, — по ID.
+// ETag — хеш события, getctag календаря — хеш всех его ETag.
+
+const (
+	davNS    = "DAV:"
+	calDAVNS = "urn:ietf:params:xml:ns:caldav"
+	csNS     = "http://calendarserver.org/ns/"
+
+	davPrefix = "/dav"
+
+	icalContentType = "text/calendar; charset=utf-8"
+
+	// maxICalSize — предел тела PUT; одно событие столько не занимает.
+	maxICalSize = 1 << 20
+)
+
+// Границы выборки «все события календаря».
+var (
+	davMinTime = time.Time{}
+	davMaxTime = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+)
+
+var davPrefixes = map[string]string{davNS: "D", calDAVNS: "C", csNS: "CS"}
+
+func init() {
+	chi.RegisterMethod("PROPFIND")
+	chi.RegisterMethod("REPORT")
+}
+
+// calDAVRoutes регистрирует CalDAV-маршруты под /dav.
+func (h *Handler) calDAVRoutes(r chi.Router) {
+	r.Options("/*", h.davOptions)
+
+	for _, p := range []string{"/principals/{userID}", "/principals/{userID}/"} {
+		r.MethodFunc("PROPFIND", p, h.PropfindPrincipal)
+	}
+	for _, p := range []string{"/calendars/{userID}", "/calendars/{userID}/"} {
+		r.MethodFunc("PROPFIND", p, h.PropfindCalendar)
+		r.MethodFunc("REPORT", p, h.ReportCalendar)
+	}
+
+	r.Get("/calendars/{userID}/{name}", h.GetICal)
+	r.Put("/calendars/{userID}/{name}", h.PutICal)
+	r.Delete("/calendars/{userID}/{name}", h.DeleteICal)
+	r.MethodFunc("PROPFIND", "/calendars/{userID}/{name}", h.PropfindICal)
+}
+
+func (h *Handler) davOptions(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("DAV", "1, 3, calendar-access")
+	w.Header().Set("Allow", "OPTIONS, GET, PUT, DELETE, PROPFIND, REPORT")
+	w.WriteHeader(http.StatusOK)
+}
+
+// --- XML ---
+
+// Имена элементов пишутся с префиксом прямо в локальном имени («D:href»):
+// так encoding/xml не повторяет xmlns у каждого элемента.
+
+type multistatus struct {
+	XMLName   xml.Name      `xml:"D:multistatus"`
+	NSD       string        `xml:"xmlns:D,attr"`
+	NSC       string        `xml:"xmlns:C,attr"`
+	NSCS      string        `xml:"xmlns:CS,attr"`
+	Responses []davResponse `xml:"D:response"`
+}
+
+type davResponse struct {
+	Href      string     `xml:"D:href"`
+	Status    string     `xml:"D:status,omitempty"`
+	Propstats []propstat `xml:"D:propstat"`
+}
+
+type propstat struct {
+	Props  []davProp `xml:"D:prop>x"`
+	Status string    `xml:"D:status"`
+}
+
+// davProp — свойство ресурса. name — полное имя для поиска, XMLName — как его вывести.
+type davProp struct {
+	XMLName xml.Name
+	Text    string `xml:",chardata"`
+	Inner   string `xml:",innerxml"`
+	name    xml.Name
+}
+
+func newProp(ns, local string) davProp {
+	p := davProp{XMLName: xml.Name{Space: ns, Local: local}, name: xml.Name{Space: ns, Local: local}}
+	if prefix, ok := davPrefixes[ns]; ok {
+		p.XMLName = xml.Name{Local: prefix + ":" + local}
+	}
+	return p
+}
+
+func textProp(ns, local, text string) davProp {
+	p := newProp(ns, local)
+	p.Text = text
+	return p
+}
+
+func xmlProp(ns, local, inner string) davProp {
+	p := newProp(ns, local)
+	p.Inner = inner
+	return p
+}
+
+func hrefProp(ns, local, href string) davProp {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(href))
+	return xmlProp(ns, local, "<D:href>"+b.String()+"</D:href>")
+}
+
+// propList — запрошенные свойства: дочерние элементы <D:prop>.
+type propList struct {
+	Names []struct {
+		XMLName xml.Name
+	} `xml:",any"`
+}
+
+type propfindRequest struct {
+	XMLName xml.Name  `xml:"DAV: propfind"`
+	AllProp *struct{} `xml:"DAV: allprop"`
+	Prop    *propList `xml:"DAV: prop"`
+}
+
+type reportRequest struct {
+	XMLName xml.Name
+	Prop    *propList   `xml:"DAV: prop"`
+	Hrefs   []string    `xml:"DAV: href"`
+	Filter  *compFilter `xml:"urn:ietf:params:xml:ns:caldav filter>comp-filter"`
+}
+
+type compFilter struct {
+	Name      string       `xml:"name,attr"`
+	TimeRange *timeRange   `xml:"urn:ietf:params:xml:ns:caldav time-range"`
+	Comps     []compFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+}
+
+type timeRange struct {
+	Start string `xml:"start,attr"`
+	End   string `xml:"end,attr"`
+}
+
+// davResource — ресурс со всеми своими свойствами.
+type davResource struct {
+	href  string
+	props []davProp
+}
+
+// response отбирает запрошенные свойства; неизвестные попадают в propstat с 404.
+// При allprop (names == nil) calendar-data не выводится, как требует RFC 4791.
+func (res davResource) response(names []xml.Name) davResponse {
+	var found, missing []davProp
+	if names == nil {
+		for _, p := range res.props {
+			if p.name != (xml.Name{Space: calDAVNS, Local: "calendar-data"}) {
+				found = append(found, p)
+			}
+		}
+	}
+	for _, n := range names {
+		i := slices.IndexFunc(res.props, func(p davProp) bool { return p.name == n })
+		if i >= 0 {
+			found = append(found, res.props[i])
+		} else {
+			missing = append(missing, newProp(n.Space, n.Local))
+		}
+	}
+
+	resp := davResponse{Href: res.href}
+	if len(found) > 0 {
+		resp.Propstats = append(resp.Propstats, propstat{Props: found, Status: "HTTP/1.1 200 OK"})
+	}
+	if len(missing) > 0 {
+		resp.Propstats = append(resp.Propstats, propstat{Props: missing, Status: "HTTP/1.1 404 Not Found"})
+	}
+	return resp
+}
+
+func sendMultistatus(w http.ResponseWriter, responses []davResponse) {
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusMultiStatus)
+	io.WriteString(w, xml.Header)
+	xml.NewEncoder(w).Encode(multistatus{NSD: davNS, NSC: calDAVNS, NSCS: csNS, Responses: responses})
+}
+
+// decodeDAVBody разбирает XML-тело запроса; пустое тело оставляет v нетронутым.
+func decodeDAVBody(r *http.Request, v any) error {
+	err := xml.NewDecoder(r.Body).Decode(v)
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	return err
+}
+
+func (l *propList) names() []xml.Name {
+	if l == nil {
+		return nil
+	}
+	names := make([]xml.Name, 0, len(l.Names))
+	for _, n := range l.Names {
+		names = append(names, n.XMLName)
+	}
+	return names
+}
+
+// --- Ресурсы ---
+
+func principalHref(userID int) string {
+	return fmt.Sprintf("%s/principals/%d/", davPrefix, userID)
+}
+
+func calendarHref(userID int) string {
+	return fmt.Sprintf("%s/calendars/%d/", davPrefix, userID)
+}
+
+// davName — имя ресурса события без «.ics».
+func davName(e domain.Event) string {
+	if e.UID != "" {
+		return e.UID
+	}
+	return e.ID
+}
+
+func eventHref(userID int, e domain.Event) string {
+	return calendarHref(userID) + url.PathEscape(davName(e)) + ".ics"
+}
+
+// etag — хеш всего содержимого события: меняется при любом изменении, включая ответы участников.
+func etag(e domain.Event) string {
+	f := fnv.New64a()
+	fmt.Fprintf(f, "%s|%d|%s|%s|%+v", e.ID, e.UserID, e.Title, e.Date.Format(time.RFC3339), e.EventAttrs)
+	return fmt.Sprintf(`"%016x"`, f.Sum64())
+}
+
+// ctag — хеш ETag всех событий календаря в порядке их имён.
+func ctag(events []domain.Event) string {
+	events = slices.Clone(events)
+	slices.SortFunc(events, func(a, b domain.Event) int { return strings.Compare(davName(a), davName(b)) })
+	f := fnv.New64a()
+	for _, e := range events {
+		io.WriteString(f, davName(e)+etag(e))
+	}
+	return fmt.Sprintf(`"%016x"`, f.Sum64())
+}
+
+func principalResource(userID int) davResource {
+	return davResource{
+		href: principalHref(userID),
+		props: []davProp{
+			xmlProp(davNS, "resourcetype", "<D:principal/>"),
+			textProp(davNS, "displayname", fmt.Sprintf("User %d", userID)),
+			hrefProp(davNS, "current-user-principal", principalHref(userID)),
+			hrefProp(calDAVNS, "calendar-home-set", calendarHref(userID)),
+		},
+	}
+}
+
+func calendarResource(userID int, events []domain.Event) davResource {
+	return davResource{
+		href: calendarHref(userID),
+		props: []davProp{
+			xmlProp(davNS, "resourcetype", "<D:collection/><C:calendar/>"),
+			textProp(davNS, "displayname", fmt.Sprintf("Calendar of user %d", userID)),
+			hrefProp(davNS, "current-user-principal", principalHref(userID)),
+			hrefProp(davNS, "owner", principalHref(userID)),
+			xmlProp(calDAVNS, "supported-calendar-component-set", `<C:comp name="VEVENT"/>`),
+			textProp(csNS, "getctag", ctag(events)),
+		},
+	}
+}
+
+func eventResource(userID int, e domain.Event) davResource {
+	return davResource{
+		href: eventHref(userID, e),
+		props: []davProp{
+			xmlProp(davNS, "resourcetype", ""),
+			textProp(davNS, "getetag", etag(e)),
+			textProp(davNS, "getcontenttype", icalContentType+"; component=vevent"),
+			textProp(calDAVNS, "calendar-data", formatICal(e)),
+		},
+	}
+}
+
+// --- Хендлеры ---
+
+// PropfindPrincipal отдаёт свойства принципала, по которым клиент находит календарь.
+func (h *Handler) PropfindPrincipal(w http.ResponseWriter, r *http.Request) {
+	userID, err := davUserID(r)
+	if err != nil {
+		h.badRequest(w, r, err)
+		return
+	}
+	var req propfindRequest
+	if err := decodeDAVBody(r, &req); err != nil {
+		h.badRequest(w, r, err)
+		return
+	}
+	sendMultistatus(w, []davResponse{principalResource(userID).response(req.Prop.names())})
+}
+
+// PropfindCalendar отдаёт свойства календаря, а при Depth: 1 — и всех его событий.
+func (h *Handler) PropfindCalendar(w http.ResponseWriter, r *http.Request) {
+	userID, err := davUserID(r)
+	if err != nil {
+		h.badRequest(w, r, err)
+		return
+	}
+	var req propfindRequest
+	if err := decodeDAVBody(r, &req); err != nil {
+		h.badRequest(w, r, err)
+		return
+	}
+	events, err := h.calendarEvents(r.Context(), userID, davMinTime, davMaxTime)
+	if err != nil {
+		h.handleLogicError(w, r, err)
+		return
+	}
+
+	names := req.Prop.names()
+	responses := []davResponse{calendarResource(userID, events).response(names)}
+	// Depth: infinity для календаря равносилен 1: вложенных коллекций в нём нет.
+	if r.Header.Get("Depth") != "0" {
+		for _, e := range events {
+			responses = append(responses, eventResource(userID, e).response(names))
+		}
+	}
+	sendMultistatus(w, responses)
+}
+
+// PropfindICal отдаёт свойства одного события.
+func (h *Handler) PropfindICal(w http.ResponseWriter, r *http.Request) {
+	userID, e, err := h.davEvent(r)
+	if err != nil {
+		h.handleLogicError(w, r, err)
+		return
+	}
+	var req propfindRequest
+	if err := decodeDAVBody(r, &req); err != nil {
+		h.badRequest(w, r, err)
+		return
+	}
+	sendMultistatus(w, []davResponse{eventResource(userID, e).response(req.Prop.names())})
+}
+
+// ReportCalendar выполняет calendar-query (выборка по интервалу) и calendar-multiget (по href).
+func (h *Handler) ReportCalendar(w http.ResponseWriter, r *http.Request) {
+	userID, err := davUserID(r)
+	if err != nil {
+		h.badRequest(w, r, err)
+		return
+	}
+	var req reportRequest
+	if err := decodeDAVBody(r, &req); err != nil {
+		h.badRequest(w, r, err)
+		return
+	}
+
+	switch req.XMLName {
+	case xml.Name{Space: calDAVNS, Local: "calendar-query"}:
+		h.calendarQuery(w, r, userID, req)
+	case xml.Name{Space: calDAVNS, Local: "calendar-multiget"}:
+		h.calendarMultiget(w, r, userID, req)
+	default:
+		h.badRequest(w, r, fmt.Errorf("unsupported report %q", req.XMLName.Local))
+	}
+}
+
+func (h *Handler) calendarQuery(w http.ResponseWriter, r *http.Request, userID int, req reportRequest) {
+	from, to := davMinTime, davMaxTime
+	if f := req.Filter; f != nil {
+		vevent := slices.IndexFunc(f.Comps, func(c compFilter) bool { return c.Name == "VEVENT" })
+		if f.Name != "VCALENDAR" || (len(f.Comps) > 0 && vevent < 0) {
+			sendMultistatus(w, nil) // кроме VEVENT, компонентов у нас нет
+			return
+		}
+		if vevent >= 0 && f.Comps[vevent].TimeRange != nil {
+			var err error
+			if from, to, err = parseTimeRange(*f.Comps[vevent].TimeRange); err != nil {
+				h.badRequest(w, r, err)
+				return
+			}
+		}
+	}
+
+	// Событие, начавшееся до from, тоже может пересекать интервал; берём его с запасом
+	// в наибольшую длительность события, как и при поиске свободного времени.
+	events, err := h.calendarEvents(r.Context(), userID, from.Add(-domain.MaxDuration*time.Minute), to)
+	if err != nil {
+		h.handleLogicError(w, r, err)
+		return
+	}
+	names := req.Prop.names()
+	responses := []davResponse{}
+	for _, e := range events {
+		if start, end := e.Span(); end.After(from) && start.Before(to) {
+			responses = append(responses, eventResource(userID, e).response(names))
+		}
+	}
+	sendMultistatus(w, responses)
+}
+
+func (h *Handler) calendarMultiget(w http.ResponseWriter, r *http.Request, userID int, req reportRequest) {
+	events, err := h.calendarEvents(r.Context(), userID, davMinTime, davMaxTime)
+	if err != nil {
+		h.handleLogicError(w, r, err)
+		return
+	}
+	names := req.Prop.names()
+	responses := []davResponse{}
+	for _, href := range req.Hrefs {
+		e, ok := findDAVEvent(events, hrefName(href))
+		if !ok {
+			responses = append(responses, davResponse{Href: href, Status: "HTTP/1.1 404 Not Found"})
+			continue
+		}
+		responses = append(responses, eventResource(userID, e).response(names))
+	}
+	sendMultistatus(w, responses)
+}
+
+// GetICal отдаёт событие в формате iCalendar.
+func (h *Handler) GetICal(w http.ResponseWriter, r *http.Request) {
+	_, e, err := h.davEvent(r)
+	if err != nil {
+		h.handleLogicError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", icalContentType)
+	w.Header().Set("ETag", etag(e))
+	io.WriteString(w, formatICal(e))
+}
+
+// PutICal создаёт или изменяет событие. Организатор меняет событие целиком, приглашённый —
+// только свой ответ (PARTSTAT). ETag в ответе не возвращается: сохранённое событие
+// отличается от присланного, и клиент должен перечитать его (RFC 4791, 5.3.4).
+func (h *Handler) PutICal(w http.ResponseWriter, r *http.Request) {
+	userID, err := davUserID(r)
+	if err != nil {
+		h.badRequest(w, r, err)
+		return
+	}
+	name, ok := strings.CutSuffix(chi.URLParam(r, "name"), ".ics")
+	if !ok {
+		h.handleLogicError(w, r, domain.ErrEventNotFound)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxICalSize+1))
+	if err != nil || len(body) > maxICalSize {
+		h.badRequest(w, r, fmt.Errorf("iCalendar body is unreadable or larger than %d bytes", maxICalSize))
+		return
+	}
+
+	s, err := h.uc.GetUserSettings(r.Context(), userID)
+	if err != nil {
+		h.handleLogicError(w, r, err)
+		return
+	}
+	loc, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+	in, err := parseICal(string(body), loc)
+	if err != nil {
+		h.badRequest(w, r, err)
+		return
+	}
+	if in.UID != "" && in.UID != name {
+		h.badRequest(w, r, fmt.Errorf("UID %q does not match resource name %q", in.UID, name))
+		return
+	}
+
+	events, err := h.calendarEvents(r.Context(), userID, davMinTime, davMaxTime)
+	if err != nil {
+		h.handleLogicError(w, r, err)
+		return
+	}
+	old, exists := findDAVEvent(events, name)
+	if !davPreconditions(r, old, exists) {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+
+	switch {
+	case !exists:
+		in.UID = name
+		_, err = h.uc.CreateEvent(r.Context(), userID, formatDate(in.Date), in.Title, in.EventAttrs)
+	case old.UserID != userID:
+		err = h.respondViaICal(r.Context(), old, userID, in.Attendees)
+	default:
+		in.UID = old.UID
+		err = h.uc.UpdateEvent(r.Context(), old.ID, userID, formatDate(in.Date), in.Title, in.EventAttrs)
+	}
+	if err != nil {
+		h.handleLogicError(w, r, err)
+		return
+	}
+	if exists {
+		w.WriteHeader(http.StatusNoContent)
+	} else {
+		w.WriteHeader(http.StatusCreated)
+	}
+}
+
+// respondViaICal сохраняет ответ приглашённого, если он поменял свой PARTSTAT.
+func (h *Handler) respondViaICal(ctx context.Context, e domain.Event, userID int, attendees []domain.Attendee) error {
+	i := slices.IndexFunc(attendees, func(a domain.Attendee) bool { return a.UserID == userID })
+	if i < 0 {
+		return domain.ErrOwnerMismatch
+	}
+	j := slices.IndexFunc(e.Attendees, func(a domain.Attendee) bool { return a.UserID == userID })
+	if j >= 0 && e.Attendees[j].Status == attendees[i].Status {
+		return nil
+	}
+	return h.uc.RespondToInvitation(ctx, e.ID, userID, attendees[i].Status)
+}
+
+// DeleteICal удаляет событие; удалить его может только организатор.
+func (h *Handler) DeleteICal(w http.ResponseWriter, r *http.Request) {
+	userID, e, err := h.davEvent(r)
+	if err != nil {
+		h.handleLogicError(w, r, err)
+		return
+	}
+	if !davPreconditions(r, e, true) {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+	if err := h.uc.DeleteEvent(r.Context(), e.ID, userID); err != nil {
+		h.handleLogicError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// davPreconditions проверяет If-Match и If-None-Match: клиент не должен затереть
+// изменения, которых не видел.
+func davPreconditions(r *http.Request, e domain.Event, exists bool) bool {
+	if m := r.Header.Get("If-Match"); m != "" {
+		if !exists || (m != "*" && m != etag(e)) {
+			return false
+		}
+	}
+	if m := r.Header.Get("If-None-Match"); m != "" && exists {
+		if m == "*" || m == etag(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// davEvent находит событие по пути запроса среди событий пользователя.
+func (h *Handler) davEvent(r *http.Request) (int, domain.Event, error) {
+	userID, err := davUserID(r)
+	if err != nil {
+		return 0, domain.Event{}, fmt.Errorf("%w: %v", domain.ErrRequestInvalid, err)
+	}
+	name, ok := strings.CutSuffix(chi.URLParam(r, "name"), ".ics")
+	if !ok {
+		return 0, domain.Event{}, domain.ErrEventNotFound
+	}
+	events, err := h.calendarEvents(r.Context(), userID, davMinTime, davMaxTime)
+	if err != nil {
+		return 0, domain.Event{}, err
+	}
+	e, ok := findDAVEvent(events, name)
+	if !ok {
+		return 0, domain.Event{}, domain.ErrEventNotFound
+	}
+	return userID, e, nil
+}
+
+// calendarEvents возвращает события календаря в устойчивом порядке: по началу, затем по имени.
+func (h *Handler) calendarEvents(ctx context.Context, userID int, from, to time.Time) ([]domain.Event, error) {
+	events, err := h.uc.GetEventsInRange(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(events, func(a, b domain.Event) int {
+		if c := a.Date.Compare(b.Date); c != 0 {
+			return c
+		}
+		return strings.Compare(davName(a), davName(b))
+	})
+	return events, nil
+}
+
+func findDAVEvent(events []domain.Event, name string) (domain.Event, bool) {
+	i := slices.IndexFunc(events, func(e domain.Event) bool { return davName(e) == name })
+	if i < 0 {
+		return domain.Event{}, false
+	}
+	return events[i], true
+}
+
+// hrefName достаёт имя ресурса из href: /dav/calendars/1/abc.ics -> abc.
+func hrefName(href string) string {
+	if u, err := url.Parse(href); err == nil {
+		href = u.Path
+	}
+	return strings.TrimSuffix(path.Base(href), ".ics")
+}
+
+func davUserID(r *http.Request) (int, error) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "userID"))
+	if err != nil || userID <= 0 {
+		return 0, fmt.Errorf("invalid user id %q", chi.URLParam(r, "userID"))
+	}
+	return userID, nil
+}
+
+func parseTimeRange(tr timeRange) (from, to time.Time, err error) {
+	from, to = davMinTime, davMaxTime
+	if tr.Start != "" {
+		if from, err = time.Parse(icalUTC, tr.Start); err != nil {
+			return from, to, fmt.Errorf("invalid time-range start %q", tr.Start)
+		}
+	}
+	if tr.End != "" {
+		if to, err = time.Parse(icalUTC, tr.End); err != nil {
+			return from, to, fmt.Errorf("invalid time-range end %q", tr.End)
+		}
+	}
+	return from, to, nil
+}
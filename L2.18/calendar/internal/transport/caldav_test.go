@@ -0,0 +1,265 @@
+package transport
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"calendar/internal/domain"
+	"calendar/internal/repository"
+	"calendar/internal/usecase"
+
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// newCalDAVServer поднимает роутер поверх настоящих use case и хранилища с несколькими событиями.
+func newCalDAVServer(t *testing.T) http.Handler {
+	t.Helper()
+	ctx := t.Context()
+	now := func() time.Time { return time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC) }
+	uc := usecase.NewEventUseCase(repository.NewLocalStorage(), usecase.WithClock(now))
+	require.NoError(t, uc.UpdateUserSettings(ctx, domain.UserSettings{UserID: 1, PeriodOptions: domain.PeriodOptions{TimeZone: "Europe/Moscow"}}))
+
+	seed := []struct {
+		date, title string
+		attrs       domain.EventAttrs
+	}{
+		{"2026-10-19 10:00", "Standup, daily", domain.EventAttrs{Duration: 30, Tags: []string{"work"}, Priority: 2}},
+		{"2026-10-23", "Day off", domain.EventAttrs{}},
+		{"2026-10-20 15:00", "Planning", domain.EventAttrs{Duration: 60, Category: "meetings", Attendees: []domain.Attendee{{UserID: 2}}}},
+		{"2026-11-05", "Conference", domain.EventAttrs{Duration: 2 * 24 * 60}},
+	}
+	for _, s := range seed {
+		_, err := uc.CreateEvent(ctx, 1, s.date, s.title, s.attrs)
+		require.NoError(t, err)
+	}
+	return NewRouter(NewHandler(uc))
+}
+
+// readFixture разбирает записанный запрос клиента: строка запроса, заголовки, пустая строка, тело.
+func readFixture(t *testing.T, path string) *http.Request {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	raw := strings.ReplaceAll(string(data), "\r\n", "\n")
+	head, body, _ := strings.Cut(raw, "\n\n")
+	req, err := http.ReadRequest(bufio.NewReader(strings.NewReader(head + "\n\n")))
+	require.NoError(t, err)
+
+	// Тело iCalendar хранится с CRLF, как его прислал клиент.
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "text/calendar") {
+		_, body, _ = strings.Cut(string(data), "\r\n\r\n")
+	}
+	req.Body = io.NopCloser(strings.NewReader(body))
+	req.ContentLength = int64(len(body))
+	req.RequestURI = ""
+	return req
+}
+
+var requestIDRe = regexp.MustCompile(`"request_id":"[^"]*"`)
+
+// dumpResponse выводит статус, значимые заголовки и тело ответа в виде для golden-файла.
+func dumpResponse(rec *httptest.ResponseRecorder) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d %s\n", rec.Code, http.StatusText(rec.Code))
+	for _, k := range []string{"Allow", "Content-Type", "Dav", "Etag"} {
+		if v := rec.Header().Get(k); v != "" {
+			fmt.Fprintf(&b, "%s: %s\n", k, v)
+		}
+	}
+	b.WriteString("\n")
+	b.WriteString(requestIDRe.ReplaceAllString(rec.Body.String(), `"request_id":"-"`))
+	return b.String()
+}
+
+// TestCalDAV_RecordedClients проигрывает по порядку запросы, записанные у DAVx5 и Thunderbird,
+// и сравнивает ответы с golden-файлами (go test -run CalDAV -update перезаписывает их).
+func TestCalDAV_RecordedClients(t *testing.T) {
+	router := newCalDAVServer(t)
+
+	fixtures, err := filepath.Glob(filepath.Join("testdata", "caldav", "*.http"))
+	require.NoError(t, err)
+	require.NotEmpty(t, fixtures)
+	slices.Sort(fixtures)
+
+	for _, path := range fixtures {
+		name := strings.TrimSuffix(filepath.Base(path), ".http")
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, readFixture(t, path))
+			got := dumpResponse(rec)
+
+			golden := strings.TrimSuffix(path, ".http") + ".golden"
+			if *update {
+				require.NoError(t, os.WriteFile(golden, []byte(got), 0o644))
+			}
+			want, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			require.Equal(t, string(want), got)
+		})
+	}
+}
+
+// TestCalDAV_RESTUpdateKeepsUID: правка через /update_event не должна менять имя ресурса,
+// иначе следующий PUT клиента по старому имени создал бы дубль.
+func TestCalDAV_RESTUpdateKeepsUID(t *testing.T) {
+	uc := usecase.NewEventUseCase(repository.NewLocalStorage())
+	router := NewRouter(NewHandler(uc))
+	serve := func(method, target, contentType, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+	ical := func(summary string) string {
+		return strings.Join([]string{
+			"BEGIN:VCALENDAR", "VERSION:2.0", "BEGIN:VEVENT", "UID:abc-1",
+			"DTSTART:20261021T140000Z", "SUMMARY:" + summary, "END:VEVENT", "END:VCALENDAR", "",
+		}, "\r\n")
+	}
+	const href = "/dav/calendars/1/abc-1.ics"
+
+	rec := serve(http.MethodPut, href, "text/calendar", ical("Created in CalDAV"))
+	require.Equal(t, http.StatusCreated, rec.Code)
+	events, err := uc.GetEventsForDay(t.Context(), 1, "2026-10-21", domain.PeriodOptions{}, domain.EventFilter{})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	body := fmt.Sprintf(`{"id":%q,"user_id":1,"date":"2026-10-21 15:00","event":"Edited in REST"}`, events[0].ID)
+	rec = serve(http.MethodPost, "/update_event", "application/json", body)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec = serve(http.MethodGet, href, "", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "SUMMARY:Edited in REST")
+
+	rec = serve(http.MethodPut, href, "text/calendar", ical("Edited in CalDAV"))
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	events, err = uc.GetEventsForDay(t.Context(), 1, "2026-10-21", domain.PeriodOptions{}, domain.EventFilter{})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, "Edited in CalDAV", events[0].Title)
+	require.Equal(t, "abc-1", events[0].UID)
+}
+
+// Многодневное событие, начавшееся задолго до интервала calendar-query, всё ещё его пересекает.
+func TestCalDAV_QueryFindsEventStartedEarlier(t *testing.T) {
+	uc := usecase.NewEventUseCase(repository.NewLocalStorage())
+	_, err := uc.CreateEvent(t.Context(), 1, "2026-10-01 09:00", "Expedition", domain.EventAttrs{UID: "trip", Duration: 30 * 24 * 60})
+	require.NoError(t, err)
+
+	body := `<?xml version="1.0" encoding="utf-8"?><C:calendar-query xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav">` +
+		`<D:prop><D:getetag/></D:prop><C:filter><C:comp-filter name="VCALENDAR"><C:comp-filter name="VEVENT">` +
+		`<C:time-range start="%s" end="%s"/></C:comp-filter></C:comp-filter></C:filter></C:calendar-query>`
+	query := func(start, end string) string {
+		req := httptest.NewRequest("REPORT", "/dav/calendars/1/", strings.NewReader(fmt.Sprintf(body, start, end)))
+		req.Header.Set("Content-Type", "application/xml")
+		req.Header.Set("Depth", "1")
+		rec := httptest.NewRecorder()
+		NewRouter(NewHandler(uc)).ServeHTTP(rec, req)
+		require.Equal(t, http.StatusMultiStatus, rec.Code, rec.Body.String())
+		return rec.Body.String()
+	}
+
+	require.Contains(t, query("20261019T000000Z", "20261026T000000Z"), "/dav/calendars/1/trip.ics")
+	require.NotContains(t, query("20261101T000000Z", "20261108T000000Z"), "trip.ics", "the event is over by then")
+}
+
+func TestParseICal(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		body string
+		want domain.Event
+	}{
+		{
+			name: "all day",
+			body: "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:a\r\nSUMMARY:Trip\r\nDTSTART;VALUE=DATE:20261101\r\nDTEND;VALUE=DATE:20261104\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+			want: domain.Event{Title: "Trip", Date: time.Date(2026, 11, 1, 0, 0, 0, 0, moscow), EventAttrs: domain.EventAttrs{UID: "a", Duration: 3 * 24 * 60}},
+		},
+		{
+			name: "single day",
+			body: "BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:Birthday\nDTSTART;VALUE=DATE:20261101\nEND:VEVENT\nEND:VCALENDAR\n",
+			want: domain.Event{Title: "Birthday", Date: time.Date(2026, 11, 1, 0, 0, 0, 0, moscow)},
+		},
+		{
+			name: "utc with duration",
+			body: "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nSUMMARY:a\\;b\\nc\r\nDTSTART:20261101T070000Z\r\nDURATION:PT1H30M\r\nCATEGORIES:x\\,y,z\r\nPRIORITY:1\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+			want: domain.Event{Title: "a;b\nc", Date: time.Date(2026, 11, 1, 10, 0, 0, 0, moscow), EventAttrs: domain.EventAttrs{Duration: 90, Tags: []string{"x,y", "z"}, Priority: 9}},
+		},
+		{
+			name: "attendees",
+			body: "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART:20261101T100000\r\nATTENDEE;PARTSTAT=DECLINED;CN=\"Doe: John\":urn:calendar:user:5\r\nATTENDEE:mailto:guest@example.com\r\nATTENDEE:urn:calendar:user:6\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+			want: domain.Event{Date: time.Date(2026, 11, 1, 10, 0, 0, 0, moscow), EventAttrs: domain.EventAttrs{Attendees: []domain.Attendee{
+				{UserID: 5, Status: domain.StatusDeclined},
+				{UserID: 6, Status: domain.StatusNeedsAction},
+			}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseICal(tt.body, moscow)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseICal_Invalid(t *testing.T) {
+	for _, body := range []string{
+		"",
+		"BEGIN:VEVENT\r\nEND:VEVENT\r\n",
+		"BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nSUMMARY:no start\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+		"BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART:20261101T100000Z\r\nDTEND:20261101T090000Z\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+		"BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART:20261101T100000Z\r\nDURATION:1 hour\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+		"BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART:20261101T100000Z\r\n",
+	} {
+		_, err := parseICal(body, time.UTC)
+		require.Error(t, err, body)
+	}
+}
+
+func TestFormatICal_RoundTrip(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	e := domain.Event{
+		ID:     "event_7",
+		UserID: 1,
+		Title:  strings.Repeat("Очень длинное название; ", 6),
+		Date:   time.Date(2026, 11, 1, 10, 15, 0, 0, moscow),
+		EventAttrs: domain.EventAttrs{
+			Duration:  45,
+			Tags:      []string{"a,b", "c"},
+			Category:  "work",
+			Priority:  3,
+			Attendees: []domain.Attendee{{UserID: 2, Status: domain.StatusTentative}},
+		},
+	}
+
+	data := formatICal(e)
+	for _, l := range strings.Split(strings.TrimSuffix(data, "\r\n"), "\r\n") {
+		require.LessOrEqual(t, len(l), icalMaxLine, l)
+	}
+
+	got, err := parseICal(data, moscow)
+	require.NoError(t, err)
+	e.ID, e.UserID, e.UID = "", 0, "event_7"
+	require.Equal(t, e, got)
+}
@@ -0,0 +1,60 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"calendar/internal/domain"
+	"calendar/mocks"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_PassesRequestContext(t *testing.T) {
+	uc := mocks.NewMockEventUseCase(t)
+
+	// В use case должен дойти контекст запроса: с его ID и с дедлайном.
+	reqCtx := mock.MatchedBy(func(ctx context.Context) bool {
+		_, hasDeadline := ctx.Deadline()
+		return middleware.GetReqID(ctx) != "" && hasDeadline
+	})
+	date := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	uc.EXPECT().EventDate(reqCtx, 1, "2026-10-16", "").Return(date, nil).Once()
+	uc.EXPECT().CreateEvent(reqCtx, 1, "2026-10-16", "Demo", domain.EventAttrs{}).Return("evt-1", nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/create_event", strings.NewReader(`{"user_id":1,"date":"2026-10-16","event":"Demo"}`))
+	rec := httptest.NewRecorder()
+	NewRouter(NewHandler(uc)).ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestHandler_ContextErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "deadline", err: context.DeadlineExceeded, want: http.StatusGatewayTimeout},
+		{name: "canceled", err: context.Canceled, want: statusClientClosedRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := mocks.NewMockEventUseCase(t)
+			uc.EXPECT().DeleteEvent(mock.Anything, "evt-1", 1).Return(tt.err).Once()
+
+			req := httptest.NewRequest(http.MethodPost, "/delete_event", strings.NewReader(`{"id":"evt-1","user_id":1}`))
+			rec := httptest.NewRecorder()
+			NewRouter(NewHandler(uc)).ServeHTTP(rec, req)
+
+			require.Equal(t, tt.want, rec.Code)
+		})
+	}
+}
@@ -2,40 +2,87 @@ package transport
 
 import (
 	"calendar/internal/domain"
+	"context"
 	"encoding/json"
 	"errors"
+	"io"
+	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 )
 
+const (
+	dateLayout     = "2006-01-02"
+	dateTimeLayout = "2006-01-02 15:04"
+)
+
+// formatDate выводит дату, а если у неё есть время суток — и время.
+func formatDate(t time.Time) string {
+	if t.Hour() == 0 && t.Minute() == 0 {
+		return t.Format(dateLayout)
+	}
+	return t.Format(dateTimeLayout)
+}
+
 type EventUseCase interface {
-	CreateEvent(userID int, dateStr, title string) (string, error)
-	UpdateEvent(id string, userID int, dateStr, title string) error
-	DeleteEvent(id string) error
-	GetEventsForDay(userID int, dateStr string) ([]domain.Event, error)
-	GetEventsForWeek(userID int, dateStr string) ([]domain.Event, error)
-	GetEventsForMonth(userID int, dateStr string) ([]domain.Event, error)
+	CreateEvent(ctx context.Context, userID int, dateStr, title string, attrs domain.EventAttrs) (string, error)
+	UpdateEvent(ctx context.Context, id string, userID int, dateStr, title string, attrs domain.EventAttrs) error
+	DeleteEvent(ctx context.Context, id string, userID int) error
+	ListTrash(ctx context.Context, userID int) ([]domain.DeletedEvent, error)
+	RestoreEvent(ctx context.Context, id string, userID int) (domain.Event, error)
+	RespondToInvitation(ctx context.Context, id string, userID int, status domain.AttendeeStatus) error
+	GetEventsForDay(ctx context.Context, userID int, dateStr string, opts domain.PeriodOptions, filter domain.EventFilter) ([]domain.Event, error)
+	GetEventsForWeek(ctx context.Context, userID int, dateStr string, opts domain.PeriodOptions, filter domain.EventFilter) ([]domain.Event, error)
+	GetEventsForMonth(ctx context.Context, userID int, dateStr string, opts domain.PeriodOptions, filter domain.EventFilter) ([]domain.Event, error)
+	GetEventsInRange(ctx context.Context, userID int, from, to time.Time) ([]domain.Event, error)
+	GetUserSettings(ctx context.Context, userID int) (domain.UserSettings, error)
+	UpdateUserSettings(ctx context.Context, s domain.UserSettings) error
+	ResolveDate(ctx context.Context, userID int, dateStr string, opts domain.PeriodOptions) (time.Time, error)
+	GetAgenda(ctx context.Context, userID int, dateStr, kind string, opts domain.PeriodOptions) (domain.Agenda, error)
+	GetStats(ctx context.Context, userID int, dateStr, kind string, opts domain.PeriodOptions) (domain.EventStats, error)
+	GetCalendarDays(ctx context.Context, userID int, dateStr, kind string, opts domain.PeriodOptions) ([]domain.CalendarDay, error)
+	AddWorkingDays(ctx context.Context, userID int, dateStr string, n int, opts domain.PeriodOptions) (time.Time, error)
+	EventDate(ctx context.Context, userID int, dateStr, shift string) (time.Time, error)
+	FindFreeSlots(ctx context.Context, q domain.SlotQuery) ([]domain.Slot, error)
+	AddAttachment(ctx context.Context, eventID string, userID int, name, contentType string, r io.Reader) (domain.Attachment, error)
+	ListAttachments(ctx context.Context, eventID string, userID int) ([]domain.Attachment, error)
+	OpenAttachment(ctx context.Context, eventID, attachmentID string, userID int) (domain.Attachment, io.ReadCloser, error)
+	DeleteAttachment(ctx context.Context, eventID, attachmentID string, userID int) error
+	ImportEvents(ctx context.Context, userID int, r io.Reader, opts domain.ImportOptions) (domain.ImportReport, error)
+	Usage(ctx context.Context) (domain.UsageReport, error)
+	Backup(ctx context.Context, w io.Writer) (domain.BackupSummary, error)
+	LoadBackup(ctx context.Context, r io.Reader, mode string) (domain.BackupSummary, error)
 }
 
 type Handler struct {
-	uc EventUseCase
+	uc           EventUseCase
+	legacyErrors bool
+	adminToken   string // пусто — /admin/* закрыт
+	accessLog    *slog.Logger
 }
 
-func NewHandler(uc EventUseCase) *Handler {
-	return &Handler{uc: uc}
+func NewHandler(uc EventUseCase, opts ...HandlerOption) *Handler {
+	h := &Handler{uc: uc}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
-
-
 type response struct {
-	Result interface{} `json:"result,omitempty"`
-	Error  string      `json:"error,omitempty"`
+	Result interface{}          `json:"result,omitempty"`
+	Date   string               `json:"date,omitempty"` // дата запроса после разбора («завтра» -> 2026-10-17)
+	Days   []domain.CalendarDay `json:"days,omitempty"` // нерабочие и сокращённые дни периода (mark_days=true)
+	Error  string               `json:"error,omitempty"`
 }
 
 type createRequest struct {
 	UserID int    `json:"user_id"`
 	Date   string `json:"date"`
 	Event  string `json:"event"`
+	domain.EventAttrs
 }
 
 type updateRequest struct {
@@ -43,10 +90,18 @@ type updateRequest struct {
 	UserID int    `json:"user_id"`
 	Date   string `json:"date"`
 	Event  string `json:"event"`
+	domain.EventAttrs
 }
 
 type deleteRequest struct {
-	ID string `json:"id"`
+	ID     string `json:"id"`
+	UserID int    `json:"user_id"`
+}
+
+type respondRequest struct {
+	ID     string                `json:"id"`
+	UserID int                   `json:"user_id"`
+	Status domain.AttendeeStatus `json:"status"`
 }
 
 // --- Handlers ---
@@ -54,92 +109,220 @@ type deleteRequest struct {
 func (h *Handler) CreateEvent(w http.ResponseWriter, r *http.Request) {
 	var req createRequest
 	if err := decodeBody(r, &req); err != nil {
-		h.sendError(w, err, http.StatusBadRequest)
+		h.badRequest(w, r, err)
+		return
+	}
+
+	date, err := h.uc.EventDate(r.Context(), req.UserID, req.Date, req.Shift)
+	if err != nil {
+		h.handleLogicError(w, r, err)
 		return
 	}
 
-	id, err := h.uc.CreateEvent(req.UserID, req.Date, req.Event)
+	id, err := h.uc.CreateEvent(r.Context(), req.UserID, formatDate(date), req.Event, req.EventAttrs)
 	if err != nil {
-		h.handleLogicError(w, err)
+		h.handleLogicError(w, r, err)
 		return
 	}
 
-	h.sendJSON(w, http.StatusOK, map[string]string{"id": id})
+	h.sendResolved(w, map[string]string{"id": id}, date)
 }
 
 func (h *Handler) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 	var req updateRequest
 	if err := decodeBody(r, &req); err != nil {
-		h.sendError(w, err, http.StatusBadRequest)
+		h.badRequest(w, r, err)
 		return
 	}
 
-	if err := h.uc.UpdateEvent(req.ID, req.UserID, req.Date, req.Event); err != nil {
-		h.handleLogicError(w, err)
+	date, err := h.uc.EventDate(r.Context(), req.UserID, req.Date, req.Shift)
+	if err != nil {
+		h.handleLogicError(w, r, err)
 		return
 	}
 
-	h.sendJSON(w, http.StatusOK, map[string]string{"result": "updated"})
+	if err := h.uc.UpdateEvent(r.Context(), req.ID, req.UserID, formatDate(date), req.Event, req.EventAttrs); err != nil {
+		h.handleLogicError(w, r, err)
+		return
+	}
+
+	h.sendResolved(w, map[string]string{"result": "updated"}, date)
 }
 
 func (h *Handler) DeleteEvent(w http.ResponseWriter, r *http.Request) {
 	var req deleteRequest
 	if err := decodeBody(r, &req); err != nil {
-		h.sendError(w, err, http.StatusBadRequest)
+		h.badRequest(w, r, err)
+		return
+	}
+
+	// Без user_id удалить событие может только организатор, а кто это — неизвестно.
+	// Старое тело {"id"} отвергается явно, а не как чужое событие.
+	if req.UserID == 0 {
+		h.badRequest(w, r, errors.New("user_id is required"))
 		return
 	}
 
-	if err := h.uc.DeleteEvent(req.ID); err != nil {
-		h.handleLogicError(w, err)
+	if err := h.uc.DeleteEvent(r.Context(), req.ID, req.UserID); err != nil {
+		h.handleLogicError(w, r, err)
 		return
 	}
 
 	h.sendJSON(w, http.StatusOK, map[string]string{"result": "deleted"})
 }
 
+// RespondToInvitation принимает ответ участника: accepted, declined или tentative.
+func (h *Handler) RespondToInvitation(w http.ResponseWriter, r *http.Request) {
+	var req respondRequest
+	if err := decodeBody(r, &req); err != nil {
+		h.badRequest(w, r, err)
+		return
+	}
+
+	if err := h.uc.RespondToInvitation(r.Context(), req.ID, req.UserID, req.Status); err != nil {
+		h.handleLogicError(w, r, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, map[string]string{"result": string(req.Status)})
+}
+
+// FindFreeSlots подбирает время, когда свободны все перечисленные пользователи.
+func (h *Handler) FindFreeSlots(w http.ResponseWriter, r *http.Request) {
+	var req domain.SlotQuery
+	if err := decodeBody(r, &req); err != nil {
+		h.badRequest(w, r, err)
+		return
+	}
+
+	slots, err := h.uc.FindFreeSlots(r.Context(), req)
+	if err != nil {
+		h.handleLogicError(w, r, err)
+		return
+	}
+	h.sendJSON(w, http.StatusOK, slots)
+}
+
 func (h *Handler) EventsForDay(w http.ResponseWriter, r *http.Request) {
 	userID, date, err := parseQueryParams(r)
 	if err != nil {
-		h.sendError(w, err, http.StatusBadRequest)
+		h.badRequest(w, r, err)
+		return
+	}
+	filter, err := parseFilter(r)
+	if err != nil {
+		h.badRequest(w, r, err)
 		return
 	}
 
-	events, err := h.uc.GetEventsForDay(userID, date)
+	opts := parsePeriodOptions(r)
+	resolved, err := h.uc.ResolveDate(r.Context(), userID, date, opts)
 	if err != nil {
-		h.handleLogicError(w, err)
+		h.handleLogicError(w, r, err)
+		return
+	}
+
+	events, err := h.uc.GetEventsForDay(r.Context(), userID, resolved.Format(dateLayout), opts, filter)
+	if err != nil {
+		h.handleLogicError(w, r, err)
+		return
+	}
+	days, ok := h.markedDays(w, r, userID, resolved, domain.PeriodDay, opts)
+	if !ok {
 		return
 	}
-	h.sendJSON(w, http.StatusOK, events)
+	h.sendEvents(w, events, resolved, days)
 }
 
 func (h *Handler) EventsForWeek(w http.ResponseWriter, r *http.Request) {
 	userID, date, err := parseQueryParams(r)
 	if err != nil {
-		h.sendError(w, err, http.StatusBadRequest)
+		h.badRequest(w, r, err)
+		return
+	}
+	filter, err := parseFilter(r)
+	if err != nil {
+		h.badRequest(w, r, err)
+		return
+	}
+
+	opts := parsePeriodOptions(r)
+	resolved, err := h.uc.ResolveDate(r.Context(), userID, date, opts)
+	if err != nil {
+		h.handleLogicError(w, r, err)
 		return
 	}
 
-	events, err := h.uc.GetEventsForWeek(userID, date)
+	events, err := h.uc.GetEventsForWeek(r.Context(), userID, resolved.Format(dateLayout), opts, filter)
 	if err != nil {
-		h.handleLogicError(w, err)
+		h.handleLogicError(w, r, err)
 		return
 	}
-	h.sendJSON(w, http.StatusOK, events)
+	days, ok := h.markedDays(w, r, userID, resolved, domain.PeriodWeek, opts)
+	if !ok {
+		return
+	}
+	h.sendEvents(w, events, resolved, days)
 }
 
 func (h *Handler) EventsForMonth(w http.ResponseWriter, r *http.Request) {
 	userID, date, err := parseQueryParams(r)
 	if err != nil {
-		h.sendError(w, err, http.StatusBadRequest)
+		h.badRequest(w, r, err)
+		return
+	}
+	filter, err := parseFilter(r)
+	if err != nil {
+		h.badRequest(w, r, err)
+		return
+	}
+
+	opts := parsePeriodOptions(r)
+	resolved, err := h.uc.ResolveDate(r.Context(), userID, date, opts)
+	if err != nil {
+		h.handleLogicError(w, r, err)
 		return
 	}
 
-	events, err := h.uc.GetEventsForMonth(userID, date)
+	events, err := h.uc.GetEventsForMonth(r.Context(), userID, resolved.Format(dateLayout), opts, filter)
 	if err != nil {
-		h.handleLogicError(w, err)
+		h.handleLogicError(w, r, err)
 		return
 	}
-	h.sendJSON(w, http.StatusOK, events)
+	days, ok := h.markedDays(w, r, userID, resolved, domain.PeriodMonth, opts)
+	if !ok {
+		return
+	}
+	h.sendEvents(w, events, resolved, days)
+}
+
+func (h *Handler) GetUserSettings(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(r.URL.Query().Get("user_id"))
+	if err != nil {
+		h.badRequest(w, r, errors.New("invalid user_id"))
+		return
+	}
+
+	settings, err := h.uc.GetUserSettings(r.Context(), userID)
+	if err != nil {
+		h.handleLogicError(w, r, err)
+		return
+	}
+	h.sendJSON(w, http.StatusOK, settings)
+}
+
+func (h *Handler) UpdateUserSettings(w http.ResponseWriter, r *http.Request) {
+	var req domain.UserSettings
+	if err := decodeBody(r, &req); err != nil {
+		h.badRequest(w, r, err)
+		return
+	}
+
+	if err := h.uc.UpdateUserSettings(r.Context(), req); err != nil {
+		h.handleLogicError(w, r, err)
+		return
+	}
+	h.sendJSON(w, http.StatusOK, map[string]string{"result": "updated"})
 }
 
 // --- Helpers ---
@@ -148,7 +331,11 @@ func decodeBody(r *http.Request, v interface{}) error {
 	defer r.Body.Close()
 	// Для поддержки x-www-form-urlencoded можно добавить проверку Content-Type
 	// Здесь реализация для JSON как основного формата в Best Practices
-	return json.NewDecoder(r.Body).Decode(v)
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return err
+	}
+	noteBodyUser(r, v)
+	return nil
 }
 
 func parseQueryParams(r *http.Request) (int, string, error) {
@@ -167,15 +354,41 @@ func parseQueryParams(r *http.Request) (int, string, error) {
 	return userID, date, nil
 }
 
-func (h *Handler) handleLogicError(w http.ResponseWriter, err error) {
-	switch {
-	case errors.Is(err, domain.ErrEventNotFound):
-		h.sendError(w, err, http.StatusServiceUnavailable) // ТЗ: 503
-	case errors.Is(err, domain.ErrDateInvalid):
-		h.sendError(w, err, http.StatusBadRequest) // ТЗ: 400
+// parsePeriodOptions читает необязательные параметры align, week_start и tz.
+func parsePeriodOptions(r *http.Request) domain.PeriodOptions {
+	q := r.URL.Query()
+	return domain.PeriodOptions{
+		Align:     q.Get("align"),
+		WeekStart: q.Get("week_start"),
+		TimeZone:  q.Get("tz"),
+	}
+}
+
+// parseFilter читает необязательные фильтры: tags=a,b, tag_match=any|all, category, min_priority.
+func parseFilter(r *http.Request) (domain.EventFilter, error) {
+	q := r.URL.Query()
+	f := domain.EventFilter{Category: q.Get("category")}
+
+	if tags := q.Get("tags"); tags != "" {
+		f.Tags = strings.Split(tags, ",")
+	}
+
+	switch q.Get("tag_match") {
+	case "", "any":
+	case "all":
+		f.MatchAll = true
 	default:
-		h.sendError(w, err, http.StatusInternalServerError) // ТЗ: 500
+		return f, errors.New("tag_match must be any or all")
 	}
+
+	if p := q.Get("min_priority"); p != "" {
+		v, err := strconv.Atoi(p)
+		if err != nil {
+			return f, errors.New("invalid min_priority")
+		}
+		f.MinPriority = v
+	}
+	return f, nil
 }
 
 func (h *Handler) sendJSON(w http.ResponseWriter, code int, payload interface{}) {
@@ -185,8 +398,16 @@ func (h *Handler) sendJSON(w http.ResponseWriter, code int, payload interface{})
 	json.NewEncoder(w).Encode(response{Result: payload})
 }
 
+// sendResolved отвечает как sendJSON и дополнительно возвращает разобранную дату запроса.
+func (h *Handler) sendResolved(w http.ResponseWriter, payload interface{}, date time.Time) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response{Result: payload, Date: formatDate(date)})
+}
+
+// sendError отвечает в старом формате {"error": ...} (см. WithLegacyErrors).
 func (h *Handler) sendError(w http.ResponseWriter, err error, code int) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(code)
 	json.NewEncoder(w).Encode(response{Error: err.Error()})
-}
\ No newline at end of file
+}
@@ -0,0 +1,91 @@
+package transport
+
+import (
+	"calendar/internal/domain"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// markedDays — нерабочие и сокращённые дни периода для ответа выборки событий,
+// если клиент попросил их параметром mark_days=true. При ошибке ответ уже
+// отправлен и ok = false.
+func (h *Handler) markedDays(w http.ResponseWriter, r *http.Request, userID int, date time.Time, kind string, opts domain.PeriodOptions) (days []domain.CalendarDay, ok bool) {
+	raw := r.URL.Query().Get("mark_days")
+	if raw == "" {
+		return nil, true
+	}
+	mark, err := strconv.ParseBool(raw)
+	if err != nil {
+		h.badRequest(w, r, errors.New("mark_days must be true or false"))
+		return nil, false
+	}
+	if !mark {
+		return nil, true
+	}
+
+	all, err := h.uc.GetCalendarDays(r.Context(), userID, date.Format(dateLayout), kind, opts)
+	if err != nil {
+		h.handleLogicError(w, r, err)
+		return nil, false
+	}
+	days = []domain.CalendarDay{}
+	for _, d := range all {
+		if d.Kind != domain.DayWorking {
+			days = append(days, d)
+		}
+	}
+	return days, true
+}
+
+// sendEvents отвечает как sendResolved и добавляет отмеченные дни периода.
+func (h *Handler) sendEvents(w http.ResponseWriter, events []domain.Event, date time.Time, days []domain.CalendarDay) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response{Result: events, Date: formatDate(date), Days: days})
+}
+
+// AddWorkingDays считает дату через N рабочих дней:
+// ?user_id=...&date=...&days=N (N < 0 — назад), плюс tz как у выборок.
+func (h *Handler) AddWorkingDays(w http.ResponseWriter, r *http.Request) {
+	userID, date, err := parseQueryParams(r)
+	if err != nil {
+		h.badRequest(w, r, err)
+		return
+	}
+	n, err := strconv.Atoi(r.URL.Query().Get("days"))
+	if err != nil {
+		h.badRequest(w, r, errors.New("days must be an integer"))
+		return
+	}
+
+	result, err := h.uc.AddWorkingDays(r.Context(), userID, date, n, parsePeriodOptions(r))
+	if err != nil {
+		h.handleLogicError(w, r, err)
+		return
+	}
+	h.sendJSON(w, http.StatusOK, map[string]string{"date": formatDate(result)})
+}
+
+// CalendarDays отдаёт производственный календарь на период:
+// ?user_id=...&date=...&period=day|week|month (по умолчанию month).
+func (h *Handler) CalendarDays(w http.ResponseWriter, r *http.Request) {
+	userID, date, err := parseQueryParams(r)
+	if err != nil {
+		h.badRequest(w, r, err)
+		return
+	}
+	kind := r.URL.Query().Get("period")
+	if kind == "" {
+		kind = domain.PeriodMonth
+	}
+
+	days, err := h.uc.GetCalendarDays(r.Context(), userID, date, kind, parsePeriodOptions(r))
+	if err != nil {
+		h.handleLogicError(w, r, err)
+		return
+	}
+	h.sendJSON(w, http.StatusOK, days)
+}
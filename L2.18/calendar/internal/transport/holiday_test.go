@@ -0,0 +1,74 @@
+package transport
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"calendar/internal/holiday"
+	"calendar/internal/repository"
+	"calendar/internal/usecase"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHolidayEndpoints(t *testing.T) {
+	uc := usecase.NewEventUseCase(repository.NewLocalStorage(), usecase.WithHolidays(holiday.RU()))
+	router := NewRouter(NewHandler(uc))
+	do := func(method, url, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(method, url, strings.NewReader(body)))
+		return rec
+	}
+
+	// Создание с переносом: 1 января — на первый рабочий день после каникул.
+	rec := do(http.MethodPost, "/create_event", `{"user_id":1,"date":"2026-01-01 10:00","event":"Kickoff","shift":"next"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var created response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	require.Equal(t, "2026-01-12 10:00", created.Date)
+
+	rec = do(http.MethodPost, "/create_event", `{"user_id":1,"date":"2026-01-01","event":"Kickoff","shift":"sideways"}`)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), "shift_invalid")
+
+	// Без mark_days ответ прежний, с ним — нерабочие и сокращённые дни периода.
+	rec = do(http.MethodGet, "/events_for_week?user_id=1&date=2026-01-12&align=calendar", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotContains(t, rec.Body.String(), `"days"`)
+
+	rec = do(http.MethodGet, "/events_for_week?user_id=1&date=2026-01-12&align=calendar&mark_days=true", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var week struct {
+		Result []map[string]any
+		Days   []struct{ Date, Kind string }
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &week))
+	require.Len(t, week.Result, 1)
+	require.Len(t, week.Days, 2)
+	require.Equal(t, "weekend", week.Days[0].Kind)
+	require.True(t, strings.HasPrefix(week.Days[0].Date, "2026-01-17"))
+
+	rec = do(http.MethodGet, "/events_for_day?user_id=1&date=2026-01-12&mark_days=maybe", "")
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = do(http.MethodGet, "/add_working_days?user_id=1&date=2026-05-07&days=2", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"result":{"date":"2026-05-12"}}`, rec.Body.String())
+
+	rec = do(http.MethodGet, "/add_working_days?user_id=1&date=2026-05-07&days=two", "")
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = do(http.MethodGet, "/add_working_days?user_id=1&date=2026-05-07&days=2000000000", "")
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), "days_invalid")
+
+	rec = do(http.MethodGet, "/calendar_days?user_id=1&date=2026-11-04&period=day", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"result":[{"date":"2026-11-04T00:00:00Z","kind":"holiday","name":"День народного единства"}]}`, rec.Body.String())
+
+	rec = do(http.MethodGet, "/calendar_days?user_id=1&date=2026-11-04&period=year", "")
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,331 @@
+package transport
+
+import (
+	"bufio"
+	"calendar/internal/domain"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Разбор и вывод iCalendar (RFC 5545) в объёме, нужном CalDAV: один VEVENT без повторений.
+
+const (
+	icalDate     = "20060102"
+	icalDateTime = "20060102T150405"
+	icalUTC      = "20060102T150405Z"
+
+	// userURIPrefix — как пользователи сервиса записываются в ORGANIZER и ATTENDEE.
+	userURIPrefix = "urn:calendar:user:"
+
+	// icalMaxLine — максимальная длина строки в октетах, длиннее строки переносятся.
+	icalMaxLine = 75
+)
+
+// icalProp — свойство контентной строки: NAME;PARAM=VALUE:value.
+type icalProp struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// formatICal выводит событие как VCALENDAR. События на весь день пишутся датами (VALUE=DATE),
+// остальные — во времени UTC, чтобы не описывать VTIMEZONE.
+func formatICal(e domain.Event) string {
+	var b strings.Builder
+	line := func(s string) {
+		for len(s) > icalMaxLine {
+			cut := icalMaxLine
+			for cut > 1 && s[cut]&0xC0 == 0x80 { // не режем многобайтовый символ UTF-8
+				cut--
+			}
+			b.WriteString(s[:cut] + "\r\n")
+			s = " " + s[cut:]
+		}
+		b.WriteString(s + "\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//calendar//CalDAV//EN")
+	line("BEGIN:VEVENT")
+	line("UID:" + escapeText(davName(e)))
+	// DTSTAMP обязателен; время изменения события не хранится, поэтому берём его начало.
+	line("DTSTAMP:" + e.Date.UTC().Format(icalUTC))
+
+	start, end := e.Span()
+	if allDay(e) {
+		line("DTSTART;VALUE=DATE:" + start.Format(icalDate))
+		line("DTEND;VALUE=DATE:" + end.Format(icalDate))
+	} else {
+		line("DTSTART:" + start.UTC().Format(icalUTC))
+		line("DTEND:" + end.UTC().Format(icalUTC))
+	}
+
+	line("SUMMARY:" + escapeText(e.Title))
+	if len(e.Tags) > 0 {
+		tags := make([]string, len(e.Tags))
+		for i, t := range e.Tags {
+			tags[i] = escapeText(t)
+		}
+		line("CATEGORIES:" + strings.Join(tags, ","))
+	}
+	if e.Category != "" {
+		line("X-CALENDAR-CATEGORY:" + escapeText(e.Category))
+	}
+	if e.Priority > 0 {
+		// В iCalendar 1 — наивысший приоритет, 9 — низший; у нас чем больше, тем важнее.
+		line("PRIORITY:" + strconv.Itoa(10-min(e.Priority, 9)))
+	}
+	if len(e.Attendees) > 0 {
+		line("ORGANIZER:" + userURIPrefix + strconv.Itoa(e.UserID))
+		for _, a := range e.Attendees {
+			line("ATTENDEE;PARTSTAT=" + strings.ToUpper(string(a.Status)) + ":" + userURIPrefix + strconv.Itoa(a.UserID))
+		}
+	}
+	line("END:VEVENT")
+	line("END:VCALENDAR")
+	return b.String()
+}
+
+// allDay сообщает, выводится ли событие датами: начинается в полночь и длится целое число дней.
+func allDay(e domain.Event) bool {
+	h, m, s := e.Date.Clock()
+	return h == 0 && m == 0 && s == 0 && e.Duration%(24*60) == 0
+}
+
+// parseICal разбирает VCALENDAR с одним VEVENT. Время без зоны и даты трактуются в loc
+// (часовом поясе пользователя), время с TZID или в UTC переводится в loc.
+// Статус участника из PARTSTAT сохраняется в Attendee.Status.
+func parseICal(data string, loc *time.Location) (domain.Event, error) {
+	props, err := veventProps(data)
+	if err != nil {
+		return domain.Event{}, err
+	}
+
+	var (
+		e          domain.Event
+		start, end time.Time
+		dateOnly   bool
+		duration   time.Duration
+	)
+	for _, p := range props {
+		switch p.name {
+		case "UID":
+			e.UID = unescapeText(p.value)
+		case "SUMMARY":
+			e.Title = unescapeText(p.value)
+		case "DTSTART":
+			start, dateOnly, err = parseICalTime(p, loc)
+		case "DTEND":
+			end, _, err = parseICalTime(p, loc)
+		case "DURATION":
+			duration, err = parseICalDuration(p.value)
+		case "CATEGORIES":
+			for _, t := range splitText(p.value) {
+				e.Tags = append(e.Tags, unescapeText(t))
+			}
+		case "X-CALENDAR-CATEGORY":
+			e.Category = unescapeText(p.value)
+		case "PRIORITY":
+			var prio int
+			prio, err = strconv.Atoi(p.value)
+			if err == nil && prio >= 1 && prio <= 9 {
+				e.Priority = 10 - prio
+			}
+		case "ATTENDEE":
+			id, ok := strings.CutPrefix(p.value, userURIPrefix)
+			if !ok {
+				continue // участники не из нашего сервиса
+			}
+			var userID int
+			userID, err = strconv.Atoi(id)
+			status := domain.AttendeeStatus(strings.ToLower(p.params["PARTSTAT"]))
+			if !status.Valid() {
+				status = domain.StatusNeedsAction
+			}
+			e.Attendees = append(e.Attendees, domain.Attendee{UserID: userID, Status: status})
+		case "RRULE", "RDATE":
+			return domain.Event{}, errors.New("recurring events are not supported")
+		}
+		if err != nil {
+			return domain.Event{}, fmt.Errorf("%s: %v", p.name, err)
+		}
+	}
+
+	if start.IsZero() {
+		return domain.Event{}, errors.New("VEVENT has no DTSTART")
+	}
+	if !end.IsZero() {
+		duration = end.Sub(start)
+	}
+	e.Date = start
+	if duration < 0 {
+		return domain.Event{}, errors.New("DTEND is before DTSTART")
+	}
+	// Однодневное событие-дата хранится без длительности, как и созданное через API.
+	if !(dateOnly && duration <= 24*time.Hour) {
+		e.Duration = int(duration / time.Minute)
+	}
+	return e, nil
+}
+
+// veventProps разворачивает перенесённые строки и возвращает свойства первого VEVENT.
+func veventProps(data string) ([]icalProp, error) {
+	var lines []string
+	sc := bufio.NewScanner(strings.NewReader(data))
+	for sc.Scan() {
+		l := strings.TrimRight(sc.Text(), "\r")
+		if (strings.HasPrefix(l, " ") || strings.HasPrefix(l, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += l[1:]
+			continue
+		}
+		if l != "" {
+			lines = append(lines, l)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 || lines[0] != "BEGIN:VCALENDAR" {
+		return nil, errors.New("not an iCalendar object")
+	}
+
+	var (
+		props []icalProp
+		depth int // вложенность внутри VEVENT (например, VALARM)
+		in    bool
+	)
+	for _, l := range lines {
+		switch {
+		case l == "BEGIN:VEVENT" && !in:
+			in = true
+			continue
+		case !in:
+			continue
+		case strings.HasPrefix(l, "BEGIN:"):
+			depth++
+			continue
+		case l == "END:VEVENT" && depth == 0:
+			return props, nil
+		case strings.HasPrefix(l, "END:"):
+			depth--
+			continue
+		}
+		if depth > 0 {
+			continue
+		}
+		p, err := parseContentLine(l)
+		if err != nil {
+			return nil, err
+		}
+		props = append(props, p)
+	}
+	return nil, errors.New("no complete VEVENT found")
+}
+
+// parseContentLine разбирает строку NAME;PARAM=VALUE;PARAM="V:1":value.
+func parseContentLine(l string) (icalProp, error) {
+	p := icalProp{params: map[string]string{}}
+	quoted := false
+	colon := -1
+	for i, r := range l {
+		if r == '"' {
+			quoted = !quoted
+		}
+		if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return p, fmt.Errorf("malformed content line %q", l)
+	}
+	p.value = l[colon+1:]
+
+	parts := strings.Split(l[:colon], ";")
+	p.name = strings.ToUpper(parts[0])
+	for _, param := range parts[1:] {
+		k, v, _ := strings.Cut(param, "=")
+		p.params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+	return p, nil
+}
+
+// parseICalTime разбирает DATE или DATE-TIME и переводит результат в loc.
+func parseICalTime(p icalProp, loc *time.Location) (t time.Time, dateOnly bool, err error) {
+	if p.params["VALUE"] == "DATE" || len(p.value) == len(icalDate) {
+		t, err = time.ParseInLocation(icalDate, p.value, loc)
+		return t, true, err
+	}
+	if strings.HasSuffix(p.value, "Z") {
+		t, err = time.Parse(icalUTC, p.value)
+		return t.In(loc), false, err
+	}
+	zone := loc
+	if tzid := p.params["TZID"]; tzid != "" {
+		// Клиенты обычно используют имена IANA; незнакомую зону считаем зоной пользователя.
+		if z, err := time.LoadLocation(tzid); err == nil {
+			zone = z
+		}
+	}
+	t, err = time.ParseInLocation(icalDateTime, p.value, zone)
+	return t.In(loc), false, err
+}
+
+var icalDurationRe = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseICalDuration разбирает длительность вида P1D, PT1H30M, P2W.
+func parseICalDuration(s string) (time.Duration, error) {
+	m := icalDurationRe.FindStringSubmatch(s)
+	if m == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return 0, fmt.Errorf("malformed duration %q", s)
+	}
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var d time.Duration
+	for i, unit := range units {
+		if m[i+2] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+2])
+		if err != nil {
+			return 0, err
+		}
+		d += time.Duration(n) * unit
+	}
+	if m[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+var textUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+
+func unescapeText(s string) string {
+	return textUnescaper.Replace(s)
+}
+
+// splitText делит список значений по запятым, не разрезая экранированные «\,».
+func splitText(s string) []string {
+	var res []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case ',':
+			res = append(res, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(res, s[start:])
+}
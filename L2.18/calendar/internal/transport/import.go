@@ -0,0 +1,88 @@
+package transport
+
+import (
+	"calendar/internal/domain"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ImportEvents создаёт события пользователя из CSV или JSON-массива в теле запроса.
+// Параметры query:
+//
+//	user_id    — пользователь, у которого создаются события
+//	format     — csv или json; по умолчанию берётся из Content-Type
+//	dry_run    — true: только проверить строки
+//	delimiter  — разделитель CSV, например «;»
+//	map        — сопоставление поля и столбца CSV, «поле:Заголовок», можно повторять
+//
+// Ответ — отчёт по каждой строке; ошибки строк не меняют код ответа.
+func (h *Handler) ImportEvents(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	userID, err := strconv.Atoi(q.Get("user_id"))
+	if err != nil {
+		h.badRequest(w, r, errors.New("invalid user_id"))
+		return
+	}
+	opts, err := importOptions(r)
+	if err != nil {
+		h.badRequest(w, r, err)
+		return
+	}
+
+	report, err := h.uc.ImportEvents(r.Context(), userID, r.Body, opts)
+	if err != nil {
+		h.handleLogicError(w, r, err)
+		return
+	}
+	h.sendJSON(w, http.StatusOK, report)
+}
+
+func importOptions(r *http.Request) (domain.ImportOptions, error) {
+	q := r.URL.Query()
+	opts := domain.ImportOptions{Format: strings.ToLower(q.Get("format"))}
+
+	if opts.Format == "" {
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		switch mediaType {
+		case "text/csv":
+			opts.Format = domain.ImportCSV
+		case "application/json":
+			opts.Format = domain.ImportJSON
+		default:
+			return opts, errors.New("format is not set and Content-Type is neither text/csv nor application/json")
+		}
+	}
+
+	if v := q.Get("dry_run"); v != "" {
+		dryRun, err := strconv.ParseBool(v)
+		if err != nil {
+			return opts, fmt.Errorf("invalid dry_run %q", v)
+		}
+		opts.DryRun = dryRun
+	}
+
+	if v := q.Get("delimiter"); v != "" {
+		d, size := utf8.DecodeRuneInString(v)
+		if size != len(v) {
+			return opts, fmt.Errorf("delimiter must be a single character, got %q", v)
+		}
+		opts.Delimiter = d
+	}
+
+	for _, m := range q["map"] {
+		field, column, ok := strings.Cut(m, ":")
+		if !ok || strings.TrimSpace(field) == "" || strings.TrimSpace(column) == "" {
+			return opts, fmt.Errorf("invalid map %q, want field:Column", m)
+		}
+		if opts.Columns == nil {
+			opts.Columns = make(map[string]string)
+		}
+		opts.Columns[strings.ToLower(strings.TrimSpace(field))] = column
+	}
+	return opts, nil
+}
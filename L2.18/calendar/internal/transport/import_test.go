@@ -0,0 +1,62 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"calendar/internal/domain"
+	"calendar/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportEvents(t *testing.T) {
+	uc := mocks.NewMockEventUseCase(t)
+	report := domain.ImportReport{DryRun: true, Total: 1, Succeeded: 1, Rows: []domain.ImportResult{{Row: 1, OK: true}}}
+	wantOpts := domain.ImportOptions{
+		Format:    domain.ImportCSV,
+		Columns:   map[string]string{"date": "Start Date", "title": "Subject"},
+		Delimiter: ';',
+		DryRun:    true,
+	}
+	uc.EXPECT().ImportEvents(mock.Anything, 3, mock.Anything, wantOpts).
+		RunAndReturn(func(_ context.Context, _ int, r io.Reader, _ domain.ImportOptions) (domain.ImportReport, error) {
+			body, err := io.ReadAll(r)
+			require.NoError(t, err)
+			require.Equal(t, "Start Date;Subject\n2026-10-20;Sync\n", string(body))
+			return report, nil
+		}).Once()
+
+	url := "/import_events?user_id=3&dry_run=true&delimiter=%3B&map=date:Start%20Date&map=Title:Subject"
+	req := httptest.NewRequest(http.MethodPost, url, strings.NewReader("Start Date;Subject\n2026-10-20;Sync\n"))
+	req.Header.Set("Content-Type", "text/csv; charset=utf-8")
+	rec := httptest.NewRecorder()
+	NewRouter(NewHandler(uc)).ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp struct{ Result domain.ImportReport }
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, report, resp.Result)
+}
+
+func TestImportEvents_BadRequest(t *testing.T) {
+	for _, url := range []string{
+		"/import_events?format=csv",
+		"/import_events?user_id=1",
+		"/import_events?user_id=1&format=csv&dry_run=maybe",
+		"/import_events?user_id=1&format=csv&delimiter=%3B%3B",
+		"/import_events?user_id=1&format=csv&map=date",
+	} {
+		uc := mocks.NewMockEventUseCase(t)
+		req := httptest.NewRequest(http.MethodPost, url, strings.NewReader(""))
+		rec := httptest.NewRecorder()
+		NewRouter(NewHandler(uc)).ServeHTTP(rec, req)
+		require.Equal(t, http.StatusBadRequest, rec.Code, url)
+	}
+}
@@ -0,0 +1,156 @@
+package transport
+
+import (
+	"calendar/internal/domain"
+	"calendar/internal/reqlog"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+const problemContentType = "application/problem+json"
+
+// problem — тело ответа об ошибке по RFC 7807. Code и RequestID — расширения:
+// стабильный код из каталога domain и ID запроса для поиска в логах.
+type problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	Code      string `json:"code"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// statusClientClosedRequest — клиент ушёл, не дождавшись ответа (код из nginx).
+const statusClientClosedRequest = 499
+
+var kindStatus = map[domain.ErrorKind]int{
+	domain.KindInvalid:   http.StatusBadRequest,
+	domain.KindNotFound:  http.StatusNotFound,
+	domain.KindForbidden: http.StatusForbidden,
+	domain.KindTimeout:   http.StatusGatewayTimeout,
+	domain.KindCanceled:  statusClientClosedRequest,
+
+	domain.KindTooLarge:        http.StatusRequestEntityTooLarge,
+	domain.KindUnsupportedType: http.StatusUnsupportedMediaType,
+	domain.KindQuotaExceeded:   http.StatusTooManyRequests,
+	domain.KindUnprocessable:   http.StatusUnprocessableEntity,
+	domain.KindConflict:        http.StatusConflict,
+}
+
+// HandlerOption настраивает Handler.
+type HandlerOption func(*Handler)
+
+// WithLegacyErrors включает старый формат ошибок {"error": "..."} с прежними
+// кодами ответа (см. legacyStatuses) — для клиентов, которые ещё не перешли на problem+json.
+func WithLegacyErrors(legacy bool) HandlerOption {
+	return func(h *Handler) {
+		h.legacyErrors = legacy
+	}
+}
+
+// classify сопоставляет ошибке запись каталога. Неизвестные ошибки становятся ErrInternal,
+// и их текст наружу не уходит.
+func classify(err error) (e *domain.Error, detail string) {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return domain.ErrTimeout, domain.ErrTimeout.Message
+	case errors.Is(err, context.Canceled):
+		return domain.ErrCanceled, domain.ErrCanceled.Message
+	}
+	if e, ok := domain.AsError(err); ok {
+		return e, err.Error()
+	}
+	return domain.ErrInternal, domain.ErrInternal.Message
+}
+
+func statusOf(e *domain.Error) int {
+	if status, ok := kindStatus[e.Kind]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// legacyStatuses — коды ответа в режиме совместимости. Старый API знал три кода:
+// 503 — событие не найдено, 400 — неверная дата, 500 — всё остальное, в том числе
+// чужое событие и истёкший запрос. Ошибки, появившиеся позже, сопоставлены явно:
+// «не найдено» — 503, неверные входные данные — 400, а квоты сохраняют 429 и 422,
+// которых требуют их клиенты. Админские эндпоинты старым клиентам незнакомы
+// и отвечают, как в problem+json.
+var legacyStatuses = map[*domain.Error]int{
+	domain.ErrEventNotFound: http.StatusServiceUnavailable, // ТЗ: 503
+	domain.ErrDateInvalid:   http.StatusBadRequest,         // ТЗ: 400
+	domain.ErrOwnerMismatch: http.StatusInternalServerError,
+	domain.ErrInternal:      http.StatusInternalServerError, // ТЗ: 500
+	domain.ErrTimeout:       http.StatusInternalServerError,
+	domain.ErrCanceled:      http.StatusInternalServerError,
+
+	domain.ErrAttachmentNotFound: http.StatusServiceUnavailable,
+
+	domain.ErrPeriodInvalid:      http.StatusBadRequest,
+	domain.ErrAttrsInvalid:       http.StatusBadRequest,
+	domain.ErrStatusInvalid:      http.StatusBadRequest,
+	domain.ErrSlotQueryInvalid:   http.StatusBadRequest,
+	domain.ErrAttachmentTooLarge: http.StatusBadRequest,
+	domain.ErrAttachmentType:     http.StatusBadRequest,
+	domain.ErrUIDConflict:        http.StatusBadRequest,
+	domain.ErrBackupInvalid:      http.StatusBadRequest,
+	domain.ErrImportInvalid:      http.StatusBadRequest,
+	domain.ErrShiftInvalid:       http.StatusBadRequest,
+	domain.ErrDaysInvalid:        http.StatusBadRequest,
+	domain.ErrRequestInvalid:     http.StatusBadRequest,
+
+	domain.ErrNotInvited: http.StatusInternalServerError, // как чужое событие
+
+	domain.ErrEventQuota:   http.StatusTooManyRequests,
+	domain.ErrDailyQuota:   http.StatusTooManyRequests,
+	domain.ErrTitleTooLong: http.StatusUnprocessableEntity,
+
+	domain.ErrAdminRequired: http.StatusForbidden,
+}
+
+func legacyStatus(e *domain.Error) int {
+	if status, ok := legacyStatuses[e]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// handleLogicError отвечает ошибкой: application/problem+json или, в режиме совместимости, {"error": ...}.
+func (h *Handler) handleLogicError(w http.ResponseWriter, r *http.Request, err error) {
+	e, detail := classify(err)
+	status := statusOf(e)
+	if h.legacyErrors {
+		status = legacyStatus(e)
+	}
+	if status >= http.StatusInternalServerError || e.Kind == domain.KindCanceled {
+		reqlog.Printf(r.Context(), "[ERROR] %s: %v", r.URL.Path, err)
+	}
+
+	if h.legacyErrors {
+		h.sendError(w, err, status)
+		return
+	}
+
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(problem{
+		Type:      "urn:calendar:error:" + e.Code,
+		Title:     e.Message,
+		Status:    status,
+		Detail:    detail,
+		Instance:  r.URL.Path,
+		Code:      e.Code,
+		RequestID: middleware.GetReqID(r.Context()),
+	})
+}
+
+// badRequest отвечает на запрос, который не удалось разобрать.
+func (h *Handler) badRequest(w http.ResponseWriter, r *http.Request, err error) {
+	h.handleLogicError(w, r, fmt.Errorf("%w: %v", domain.ErrRequestInvalid, err))
+}
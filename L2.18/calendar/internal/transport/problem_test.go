@@ -0,0 +1,158 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"calendar/internal/domain"
+	"calendar/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func deleteEvent(t *testing.T, h *Handler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/delete_event", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	NewRouter(h).ServeHTTP(rec, req)
+	return rec
+}
+
+func TestProblemDetails(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+	}{
+		{name: "not found", err: domain.ErrEventNotFound, wantStatus: http.StatusNotFound, wantCode: "event_not_found", wantDetail: "event not found"},
+		{name: "forbidden", err: domain.ErrOwnerMismatch, wantStatus: http.StatusForbidden, wantCode: "owner_mismatch"},
+		{
+			name:       "wrapped",
+			err:        fmt.Errorf("%w: unknown time zone %q", domain.ErrPeriodInvalid, "Nowhere"),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "period_invalid",
+			wantDetail: `period options are invalid: unknown time zone "Nowhere"`,
+		},
+		{name: "quota", err: fmt.Errorf("%w: limit is 200", domain.ErrDailyQuota), wantStatus: http.StatusTooManyRequests, wantCode: "daily_event_quota_exceeded"},
+		{name: "title too long", err: domain.ErrTitleTooLong, wantStatus: http.StatusUnprocessableEntity, wantCode: "title_too_long"},
+		{name: "unknown error is hidden", err: errors.New("db password is hunter2"), wantStatus: http.StatusInternalServerError, wantCode: "internal", wantDetail: "internal error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := mocks.NewMockEventUseCase(t)
+			uc.EXPECT().DeleteEvent(mock.Anything, "evt-1", 1).Return(tt.err).Once()
+
+			rec := deleteEvent(t, NewHandler(uc), `{"id":"evt-1","user_id":1}`)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			require.Equal(t, problemContentType, rec.Header().Get("Content-Type"))
+
+			var p problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+			require.Equal(t, tt.wantStatus, p.Status)
+			require.Equal(t, tt.wantCode, p.Code)
+			require.Equal(t, "urn:calendar:error:"+tt.wantCode, p.Type)
+			require.Equal(t, "/delete_event", p.Instance)
+			require.NotEmpty(t, p.Title)
+			require.NotEmpty(t, p.RequestID)
+			if tt.wantDetail != "" {
+				require.Equal(t, tt.wantDetail, p.Detail)
+			}
+		})
+	}
+}
+
+func TestProblemDetails_BadRequest(t *testing.T) {
+	uc := mocks.NewMockEventUseCase(t)
+
+	rec := deleteEvent(t, NewHandler(uc), `{not json`)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	var p problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+	require.Equal(t, "request_invalid", p.Code)
+	require.True(t, strings.HasPrefix(p.Detail, "request is invalid: "), p.Detail)
+}
+
+func TestLegacyErrors(t *testing.T) {
+	uc := mocks.NewMockEventUseCase(t)
+	uc.EXPECT().DeleteEvent(mock.Anything, "evt-1", 1).Return(domain.ErrEventNotFound).Once()
+
+	rec := deleteEvent(t, NewHandler(uc, WithLegacyErrors(true)), `{"id":"evt-1","user_id":1}`)
+
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.JSONEq(t, `{"error":"event not found"}`, rec.Body.String())
+
+	// У каждой ошибки каталога есть явный код для старых клиентов.
+	for _, e := range domain.Catalogue {
+		_, ok := legacyStatuses[e]
+		require.True(t, ok, "no legacy status for %s", e.Code)
+	}
+
+	tests := []struct {
+		err  error
+		want int
+	}{
+		// Ошибки старого API — ровно прежние коды.
+		{domain.ErrEventNotFound, http.StatusServiceUnavailable},
+		{domain.ErrDateInvalid, http.StatusBadRequest},
+		{domain.ErrOwnerMismatch, http.StatusInternalServerError},
+		{errors.New("db down"), http.StatusInternalServerError},
+		{context.DeadlineExceeded, http.StatusInternalServerError},
+		{context.Canceled, http.StatusInternalServerError},
+		// Новые — по смыслу в кодах старого API, квоты — со своими кодами.
+		{domain.ErrAttachmentNotFound, http.StatusServiceUnavailable},
+		{domain.ErrAttrsInvalid, http.StatusBadRequest},
+		{domain.ErrUIDConflict, http.StatusBadRequest},
+		{domain.ErrAttachmentTooLarge, http.StatusBadRequest},
+		{domain.ErrDaysInvalid, http.StatusBadRequest},
+		{domain.ErrNotInvited, http.StatusInternalServerError},
+		{domain.ErrEventQuota, http.StatusTooManyRequests},
+		{domain.ErrDailyQuota, http.StatusTooManyRequests},
+		{domain.ErrTitleTooLong, http.StatusUnprocessableEntity},
+		{domain.ErrAdminRequired, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		uc := mocks.NewMockEventUseCase(t)
+		uc.EXPECT().DeleteEvent(mock.Anything, "evt-1", 1).Return(tt.err).Once()
+		rec := deleteEvent(t, NewHandler(uc, WithLegacyErrors(true)), `{"id":"evt-1","user_id":1}`)
+		require.Equal(t, tt.want, rec.Code, "%v", tt.err)
+	}
+}
+
+func TestErrorCatalogue(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, e := range domain.Catalogue {
+		require.NotEmpty(t, e.Code)
+		require.False(t, seen[e.Code], "duplicate code %s", e.Code)
+		seen[e.Code] = true
+
+		if e.Kind != domain.KindInternal {
+			require.NotEqual(t, http.StatusInternalServerError, statusOf(e), "no status for %s", e.Code)
+		}
+	}
+}
+
+func TestDeleteEvent_WithoutUserID(t *testing.T) {
+	// Тело в старом формате — только ID: без user_id владельца не проверить, удаления нет.
+	uc := mocks.NewMockEventUseCase(t)
+	rec := deleteEvent(t, NewHandler(uc), `{"id":"evt-1"}`)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), "user_id is required")
+
+	// С user_id — обычная проверка владельца.
+	uc.EXPECT().DeleteEvent(mock.Anything, "evt-1", 2).Return(domain.ErrOwnerMismatch).Once()
+	rec = deleteEvent(t, NewHandler(uc), `{"id":"evt-1","user_id":2}`)
+	require.Equal(t, http.StatusForbidden, rec.Code)
+}
@@ -1,7 +1,7 @@
 package transport
 
 import (
-	"log"
+	"context"
 	"net/http"
 	"time"
 
@@ -9,6 +9,9 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 )
 
+// requestTimeout — дедлайн обработки одного запроса; его видят все слои через контекст.
+const requestTimeout = 30 * time.Second
+
 // NewRouter инициализирует chi роутер и регистрирует хендлеры
 func NewRouter(h *Handler) http.Handler {
 	r := chi.NewRouter()
@@ -17,36 +20,56 @@ func NewRouter(h *Handler) http.Handler {
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
 	r.Use(middleware.Recoverer)
-	r.Use(loggingMiddleware)
+	r.Use(h.accessLogMiddleware)
+	r.Use(timeoutMiddleware(requestTimeout))
 
 	r.Post("/create_event", h.CreateEvent)
 	r.Post("/update_event", h.UpdateEvent)
 	r.Post("/delete_event", h.DeleteEvent)
+	r.Post("/restore_event", h.RestoreEvent)
+	r.Post("/import_events", h.ImportEvents)
+	r.Post("/respond_invitation", h.RespondToInvitation)
+	r.Post("/find_slots", h.FindFreeSlots)
+	r.Post("/add_attachment", h.AddAttachment)
+	r.Post("/delete_attachment", h.DeleteAttachment)
 
 	r.Get("/events_for_day", h.EventsForDay)
 	r.Get("/events_for_week", h.EventsForWeek)
 	r.Get("/events_for_month", h.EventsForMonth)
 
+	r.Get("/trash", h.ListTrash)
+
+	r.Get("/attachments", h.ListAttachments)
+	r.Get("/download_attachment", h.DownloadAttachment)
+
+	r.Get("/agenda_for_day", h.AgendaForDay)
+	r.Get("/agenda_for_week", h.AgendaForWeek)
+	r.Get("/agenda_for_month", h.AgendaForMonth)
+
+	r.Get("/stats_for_day", h.StatsForDay)
+	r.Get("/stats_for_week", h.StatsForWeek)
+	r.Get("/stats_for_month", h.StatsForMonth)
+
+	r.Get("/calendar_days", h.CalendarDays)
+	r.Get("/add_working_days", h.AddWorkingDays)
+
+	r.Get("/user_settings", h.GetUserSettings)
+	r.Post("/user_settings", h.UpdateUserSettings)
+
+	r.Route(davPrefix, h.calDAVRoutes)
+	r.Route("/admin", h.adminRoutes)
+
 	return r
 }
 
-// loggingMiddleware логирует запросы (адаптация под http.Handler)
-func loggingMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		start := time.Now()
-		
-		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
-		
-		next.ServeHTTP(ww, r)
-
-		log.Printf(
-			"[%s] %s %s | Status: %d | Size: %d | Duration: %s",
-			r.Method,
-			r.URL.Path,
-			r.RemoteAddr,
-			ww.Status(),
-			ww.BytesWritten(),
-			time.Since(start),
-		)
-	})
-}
\ No newline at end of file
+// timeoutMiddleware ограничивает время обработки запроса. Сам ответ не пишет:
+// use case и хранилище получают отменённый контекст, а хендлер отвечает 504.
+func timeoutMiddleware(d time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer cancel()
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
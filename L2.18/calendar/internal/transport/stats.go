@@ -0,0 +1,35 @@
+package transport
+
+import (
+	"calendar/internal/domain"
+	"net/http"
+)
+
+// StatsForDay, StatsForWeek и StatsForMonth отдают статистику событий за период:
+// ?user_id=...&date=... и те же align, week_start и tz, что у выборок событий.
+func (h *Handler) StatsForDay(w http.ResponseWriter, r *http.Request) {
+	h.stats(w, r, domain.PeriodDay)
+}
+
+func (h *Handler) StatsForWeek(w http.ResponseWriter, r *http.Request) {
+	h.stats(w, r, domain.PeriodWeek)
+}
+
+func (h *Handler) StatsForMonth(w http.ResponseWriter, r *http.Request) {
+	h.stats(w, r, domain.PeriodMonth)
+}
+
+func (h *Handler) stats(w http.ResponseWriter, r *http.Request, kind string) {
+	userID, date, err := parseQueryParams(r)
+	if err != nil {
+		h.badRequest(w, r, err)
+		return
+	}
+
+	stats, err := h.uc.GetStats(r.Context(), userID, date, kind, parsePeriodOptions(r))
+	if err != nil {
+		h.handleLogicError(w, r, err)
+		return
+	}
+	h.sendJSON(w, http.StatusOK, stats)
+}
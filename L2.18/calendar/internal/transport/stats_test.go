@@ -0,0 +1,40 @@
+package transport
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"calendar/internal/domain"
+	"calendar/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsEndpoints(t *testing.T) {
+	uc := mocks.NewMockEventUseCase(t)
+	stats := domain.EventStats{UserID: 1, Events: 3, BookedMinutes: 150, BusyMinutes: 120}
+	opts := domain.PeriodOptions{Align: domain.AlignCalendar, TimeZone: "Europe/Moscow"}
+	uc.EXPECT().GetStats(mock.Anything, 1, "2026-10-14", domain.PeriodWeek, opts).Return(stats, nil).Once()
+	uc.EXPECT().GetStats(mock.Anything, 1, "2026-10-14", domain.PeriodMonth, domain.PeriodOptions{Align: "yearly"}).
+		Return(domain.EventStats{}, domain.ErrPeriodInvalid).Once()
+	router := NewRouter(NewHandler(uc))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stats_for_week?user_id=1&date=2026-10-14&align=calendar&tz=Europe/Moscow", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp struct{ Result map[string]any }
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.EqualValues(t, 150, resp.Result["booked_minutes"])
+	require.EqualValues(t, 120, resp.Result["busy_minutes"])
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stats_for_month?user_id=1&date=2026-10-14&align=yearly", nil))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stats_for_day?user_id=1", nil))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,39 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+// ListTrash возвращает корзину пользователя: ?user_id=...
+func (h *Handler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(r.URL.Query().Get("user_id"))
+	if err != nil {
+		h.badRequest(w, r, errors.New("invalid user_id"))
+		return
+	}
+
+	trash, err := h.uc.ListTrash(r.Context(), userID)
+	if err != nil {
+		h.handleLogicError(w, r, err)
+		return
+	}
+	h.sendJSON(w, http.StatusOK, trash)
+}
+
+// RestoreEvent возвращает событие из корзины. Тело — как у /delete_event: {"id", "user_id"}.
+func (h *Handler) RestoreEvent(w http.ResponseWriter, r *http.Request) {
+	var req deleteRequest
+	if err := decodeBody(r, &req); err != nil {
+		h.badRequest(w, r, err)
+		return
+	}
+
+	event, err := h.uc.RestoreEvent(r.Context(), req.ID, req.UserID)
+	if err != nil {
+		h.handleLogicError(w, r, err)
+		return
+	}
+	h.sendJSON(w, http.StatusOK, event)
+}
@@ -0,0 +1,50 @@
+package transport
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"calendar/internal/domain"
+	"calendar/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrashEndpoints(t *testing.T) {
+	uc := mocks.NewMockEventUseCase(t)
+	event := domain.Event{ID: "evt-1", UserID: 1, Title: "Retro", Date: time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)}
+	deletedAt := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	uc.EXPECT().ListTrash(mock.Anything, 1).Return([]domain.DeletedEvent{{Event: event, DeletedAt: deletedAt}}, nil).Once()
+	uc.EXPECT().RestoreEvent(mock.Anything, "evt-1", 1).Return(event, nil).Once()
+	uc.EXPECT().RestoreEvent(mock.Anything, "evt-2", 1).Return(domain.Event{}, domain.ErrEventNotFound).Once()
+	router := NewRouter(NewHandler(uc))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/trash?user_id=1", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var list struct{ Result []map[string]any }
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&list))
+	require.Len(t, list.Result, 1)
+	require.Equal(t, "evt-1", list.Result[0]["id"])
+	require.Equal(t, "2026-10-18T09:00:00Z", list.Result[0]["deleted_at"])
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/trash?user_id=x", nil))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/restore_event", strings.NewReader(`{"id":"evt-1","user_id":1}`)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var restored struct{ Result domain.Event }
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&restored))
+	require.Equal(t, event, restored.Result)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/restore_event", strings.NewReader(`{"id":"evt-2","user_id":1}`)))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
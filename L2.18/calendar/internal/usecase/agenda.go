@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"calendar/internal/domain"
+	"context"
+	"sort"
+)
+
+// GetAgenda возвращает события за день, неделю или месяц, разложенные по дням
+// и отсортированные по времени. Дни без событий тоже попадают в результат.
+func (uc *EventUseCase) GetAgenda(ctx context.Context, userID int, dateStr, kind string, opts domain.PeriodOptions) (domain.Agenda, error) {
+	p, t, err := uc.parseQuery(ctx, userID, dateStr, opts)
+	if err != nil {
+		return domain.Agenda{}, err
+	}
+
+	from, to, err := p.bounds(kind, t)
+	if err != nil {
+		return domain.Agenda{}, err
+	}
+
+	events, err := uc.repo.GetByUserAndRange(ctx, userID, from, to)
+	if err != nil {
+		return domain.Agenda{}, err
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].Date.Equal(events[j].Date) {
+			return events[i].Date.Before(events[j].Date)
+		}
+		return events[i].ID < events[j].ID
+	})
+
+	agenda := domain.Agenda{UserID: userID, From: from, To: to}
+	i := 0
+	for day := from; day.Before(to); {
+		_, next := p.day(day)
+		cd := uc.holidays.Day(day)
+		ad := domain.AgendaDay{Date: day, Kind: cd.Kind, Holiday: cd.Name, Events: []domain.Event{}}
+		for i < len(events) && events[i].Date.Before(next) {
+			e := events[i]
+			e.Date = e.Date.In(p.loc) // время показываем по часовому поясу повестки
+			ad.Events = append(ad.Events, e)
+			i++
+		}
+		agenda.Days = append(agenda.Days, ad)
+		day = next
+	}
+	return agenda, nil
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"calendar/internal/domain"
+	repoMocks "calendar/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventUseCase_GetAgenda_GroupsAndSorts(t *testing.T) {
+	repo := repoMocks.NewMockEventRepository(t)
+	uc := NewEventUseCase(repo)
+
+	day := func(d, h int) time.Time { return time.Date(2026, 10, d, h, 0, 0, 0, time.UTC) }
+	from, to := day(12, 0), day(19, 0)
+
+	repo.EXPECT().
+		GetByUserAndRange(mock.Anything, 1, from, to).
+		Return([]domain.Event{
+			{ID: "3", UserID: 1, Title: "Late", Date: day(14, 18)},
+			{ID: "1", UserID: 1, Title: "Standup", Date: day(12, 10)},
+			{ID: "2", UserID: 1, Title: "Early", Date: day(14, 9)},
+			{ID: "4", UserID: 1, Title: "Sunday", Date: day(18, 0)},
+		}, nil).
+		Once()
+
+	agenda, err := uc.GetAgenda(t.Context(), 1, "2026-10-14", domain.PeriodWeek, domain.PeriodOptions{Align: domain.AlignCalendar})
+	require.NoError(t, err)
+	require.Equal(t, from, agenda.From)
+	require.Equal(t, to, agenda.To)
+	require.Len(t, agenda.Days, 7)
+
+	titles := func(d domain.AgendaDay) []string {
+		res := []string{}
+		for _, e := range d.Events {
+			res = append(res, e.Title)
+		}
+		return res
+	}
+	require.Equal(t, day(12, 0), agenda.Days[0].Date)
+	require.Equal(t, []string{"Standup"}, titles(agenda.Days[0]))
+	require.Equal(t, []string{}, titles(agenda.Days[1]), "empty days must be present")
+	require.Equal(t, []string{"Early", "Late"}, titles(agenda.Days[2]))
+	require.Equal(t, []string{"Sunday"}, titles(agenda.Days[6]))
+}
+
+func TestEventUseCase_GetAgenda_DaysFollowTimeZone(t *testing.T) {
+	repo := repoMocks.NewMockEventRepository(t)
+	uc := NewEventUseCase(repo)
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	// 23:30 UTC 28 марта — это уже 00:30 29 марта в Берлине (CET, UTC+1).
+	event := domain.Event{ID: "1", UserID: 1, Title: "Night", Date: time.Date(2026, 3, 28, 23, 30, 0, 0, time.UTC)}
+	repo.EXPECT().GetByUserAndRange(mock.Anything, 1, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return([]domain.Event{event}, nil).Once()
+
+	agenda, err := uc.GetAgenda(t.Context(), 1, "2026-03-23", domain.PeriodWeek, domain.PeriodOptions{Align: domain.AlignCalendar, TimeZone: "Europe/Berlin"})
+	require.NoError(t, err)
+	require.Len(t, agenda.Days, 7)
+	require.Equal(t, time.Date(2026, 3, 29, 0, 0, 0, 0, berlin), agenda.Days[6].Date)
+	require.Len(t, agenda.Days[6].Events, 1)
+	require.Equal(t, "00:30", agenda.Days[6].Events[0].Date.Format("15:04"))
+}
+
+func TestEventUseCase_GetAgenda_UnknownPeriod(t *testing.T) {
+	repo := repoMocks.NewMockEventRepository(t)
+	uc := NewEventUseCase(repo)
+
+	_, err := uc.GetAgenda(t.Context(), 1, "2026-10-14", "fortnight", domain.PeriodOptions{})
+	require.ErrorIs(t, err, domain.ErrPeriodInvalid)
+}
@@ -0,0 +1,274 @@
+package usecase
+
+import (
+	"bufio"
+	"calendar/internal/blob"
+	"calendar/internal/domain"
+	"calendar/internal/repository"
+	"calendar/internal/reqlog"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"unicode"
+)
+
+// DefaultMaxAttachmentSize — предельный размер вложения по умолчанию.
+const DefaultMaxAttachmentSize = 10 << 20
+
+// DefaultAttachmentTypes — MIME-типы вложений по умолчанию: документы, таблицы,
+// презентации, картинки и текст. «*» в конце разрешает все подтипы.
+var DefaultAttachmentTypes = []string{
+	"application/pdf",
+	"application/zip",
+	"application/msword",
+	"application/vnd.ms-*",
+	"application/vnd.openxmlformats-officedocument.*",
+	"application/vnd.oasis.opendocument.*",
+	"image/*",
+	"text/plain",
+	"text/markdown",
+	"text/csv",
+}
+
+var errNoBlobStore = errors.New("attachments are disabled: no blob store configured")
+
+// WithAttachments включает вложения: описания хранятся в repo, содержимое — в blobs.
+func WithAttachments(repo repository.AttachmentRepository, blobs blob.Store) Option {
+	return func(uc *EventUseCase) {
+		uc.attachments = repo
+		uc.blobs = blobs
+	}
+}
+
+// WithAttachmentLimits задаёт предельный размер вложения и допустимые MIME-типы.
+func WithAttachmentLimits(maxSize int64, types ...string) Option {
+	return func(uc *EventUseCase) {
+		uc.maxAttachment = maxSize
+		uc.attachmentTypes = types
+	}
+}
+
+// AddAttachment сохраняет файл r как вложение события. Прикладывать файлы могут
+// организатор и участники. Тип берётся из contentType, а если клиент его не указал —
+// определяется по содержимому.
+func (uc *EventUseCase) AddAttachment(ctx context.Context, eventID string, userID int, name, contentType string, r io.Reader) (domain.Attachment, error) {
+	if uc.blobs == nil {
+		return domain.Attachment{}, errNoBlobStore
+	}
+	if _, err := uc.visibleEvent(ctx, eventID, userID); err != nil {
+		return domain.Attachment{}, err
+	}
+
+	br := bufio.NewReaderSize(r, 512)
+	head, _ := br.Peek(512)
+	contentType = resolveContentType(contentType, head)
+	if !uc.typeAllowed(contentType) {
+		return domain.Attachment{}, fmt.Errorf("%w: %s", domain.ErrAttachmentType, contentType)
+	}
+
+	a := domain.Attachment{
+		ID:          newAttachmentID(),
+		EventID:     eventID,
+		Name:        cleanFileName(name),
+		ContentType: contentType,
+		UploadedBy:  userID,
+		UploadedAt:  uc.now(),
+	}
+	size, err := uc.blobs.Put(ctx, a.ID, &sizeLimiter{r: br, left: uc.maxAttachment, max: uc.maxAttachment})
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	a.Size = size
+
+	if err := uc.attachments.AddAttachment(ctx, a); err != nil {
+		uc.dropBlob(ctx, a.ID)
+		return domain.Attachment{}, err
+	}
+	// Событие могли удалить, пока шла загрузка, и его вложения уже собраны: убираем и это.
+	if _, err := uc.repo.GetByID(ctx, eventID); err != nil {
+		uc.removeAttachment(context.WithoutCancel(ctx), a)
+		return domain.Attachment{}, err
+	}
+
+	reqlog.Printf(ctx, "[ATTACH] user %d attached %s (%s, %d bytes) to %s", userID, a.ID, a.ContentType, a.Size, eventID)
+	return a, nil
+}
+
+// ListAttachments возвращает вложения события тому, кто его видит.
+func (uc *EventUseCase) ListAttachments(ctx context.Context, eventID string, userID int) ([]domain.Attachment, error) {
+	if _, err := uc.visibleEvent(ctx, eventID, userID); err != nil {
+		return nil, err
+	}
+	return uc.attachments.ListAttachments(ctx, eventID)
+}
+
+// OpenAttachment возвращает описание вложения и его содержимое; закрыть его должен вызывающий.
+func (uc *EventUseCase) OpenAttachment(ctx context.Context, eventID, attachmentID string, userID int) (domain.Attachment, io.ReadCloser, error) {
+	if uc.blobs == nil {
+		return domain.Attachment{}, nil, domain.ErrAttachmentNotFound
+	}
+	if _, err := uc.visibleEvent(ctx, eventID, userID); err != nil {
+		return domain.Attachment{}, nil, err
+	}
+	a, err := uc.eventAttachment(ctx, eventID, attachmentID)
+	if err != nil {
+		return domain.Attachment{}, nil, err
+	}
+
+	rc, err := uc.blobs.Get(ctx, a.ID)
+	if errors.Is(err, blob.ErrNotFound) {
+		return domain.Attachment{}, nil, domain.ErrAttachmentNotFound
+	}
+	if err != nil {
+		return domain.Attachment{}, nil, err
+	}
+	return a, rc, nil
+}
+
+// DeleteAttachment удаляет вложение; это может сделать тот, кто его загрузил, или организатор.
+func (uc *EventUseCase) DeleteAttachment(ctx context.Context, eventID, attachmentID string, userID int) error {
+	e, err := uc.visibleEvent(ctx, eventID, userID)
+	if err != nil {
+		return err
+	}
+	a, err := uc.eventAttachment(ctx, eventID, attachmentID)
+	if err != nil {
+		return err
+	}
+	if a.UploadedBy != userID && e.UserID != userID {
+		return domain.ErrOwnerMismatch
+	}
+
+	if err := uc.removeAttachment(ctx, a); err != nil {
+		return err
+	}
+	reqlog.Printf(ctx, "[ATTACH] user %d removed %s from %s", userID, a.ID, eventID)
+	return nil
+}
+
+// collectAttachments удаляет вложения события, вычищенного из корзины. Событие уже удалено,
+// поэтому ошибки только логируются, а отмена запроса уборку не прерывает.
+func (uc *EventUseCase) collectAttachments(ctx context.Context, eventID string) {
+	ctx = context.WithoutCancel(ctx)
+	list, err := uc.attachments.ListAttachments(ctx, eventID)
+	if err != nil {
+		reqlog.Printf(ctx, "[ERROR] listing attachments of deleted event %s: %v", eventID, err)
+		return
+	}
+	for _, a := range list {
+		if err := uc.removeAttachment(ctx, a); err != nil {
+			reqlog.Printf(ctx, "[ERROR] removing attachment %s of deleted event %s: %v", a.ID, eventID, err)
+		}
+	}
+	if len(list) > 0 {
+		reqlog.Printf(ctx, "[ATTACH] removed %d attachments of deleted event %s", len(list), eventID)
+	}
+}
+
+// removeAttachment сначала удаляет описание, потом содержимое: лучше осиротевший файл,
+// чем описание без файла.
+func (uc *EventUseCase) removeAttachment(ctx context.Context, a domain.Attachment) error {
+	if err := uc.attachments.DeleteAttachment(ctx, a.ID); err != nil {
+		return err
+	}
+	return uc.dropBlob(ctx, a.ID)
+}
+
+func (uc *EventUseCase) dropBlob(ctx context.Context, id string) error {
+	if uc.blobs == nil {
+		return nil
+	}
+	if err := uc.blobs.Delete(ctx, id); err != nil && !errors.Is(err, blob.ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+func (uc *EventUseCase) eventAttachment(ctx context.Context, eventID, attachmentID string) (domain.Attachment, error) {
+	a, err := uc.attachments.GetAttachment(ctx, attachmentID)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	if a.EventID != eventID {
+		return domain.Attachment{}, domain.ErrAttachmentNotFound
+	}
+	return a, nil
+}
+
+// visibleEvent достаёт событие, если userID — его организатор или участник.
+func (uc *EventUseCase) visibleEvent(ctx context.Context, id string, userID int) (domain.Event, error) {
+	e, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return domain.Event{}, err
+	}
+	if e.UserID != userID && !e.HasAttendee(userID) {
+		return domain.Event{}, domain.ErrNotInvited
+	}
+	return e, nil
+}
+
+func (uc *EventUseCase) typeAllowed(contentType string) bool {
+	for _, t := range uc.attachmentTypes {
+		if prefix, ok := strings.CutSuffix(t, "*"); ok && strings.HasPrefix(contentType, prefix) || t == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveContentType нормализует заявленный тип (без параметров, в нижнем регистре),
+// а пустой или application/octet-stream заменяет определённым по первым байтам.
+func resolveContentType(declared string, head []byte) string {
+	t, _, err := mime.ParseMediaType(declared)
+	if err != nil || t == "application/octet-stream" {
+		t, _, _ = mime.ParseMediaType(http.DetectContentType(head))
+	}
+	return t
+}
+
+// cleanFileName оставляет от имени файла только последнюю часть пути без управляющих символов.
+func cleanFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+	if r := []rune(name); len(r) > 255 {
+		name = string(r[:255])
+	}
+	return name
+}
+
+func newAttachmentID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// sizeLimiter отдаёт не больше max байт, а на следующем байте возвращает ErrAttachmentTooLarge.
+type sizeLimiter struct {
+	r         io.Reader
+	left, max int64
+}
+
+func (l *sizeLimiter) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.left -= int64(n)
+	if l.left < 0 {
+		return n, fmt.Errorf("%w: the limit is %d bytes", domain.ErrAttachmentTooLarge, l.max)
+	}
+	return n, err
+}
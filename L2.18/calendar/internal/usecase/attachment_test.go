@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"calendar/internal/blob"
+	"calendar/internal/domain"
+	"calendar/internal/repository"
+	repoMocks "calendar/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var pdf = "%PDF-1.7\n" + strings.Repeat("x", 100)
+
+// newAttachmentUseCase собирает use case с вложениями на временном каталоге.
+// Событие evt-1 организует пользователь 1, участник — 2.
+func newAttachmentUseCase(t *testing.T, opts ...Option) (*EventUseCase, *repoMocks.MockEventRepository, string) {
+	t.Helper()
+	dir := t.TempDir()
+	blobs, err := blob.NewLocalStore(dir)
+	require.NoError(t, err)
+
+	repo := repoMocks.NewMockEventRepository(t)
+	event := domain.Event{ID: "evt-1", UserID: 1, EventAttrs: domain.EventAttrs{
+		Attendees: []domain.Attendee{{UserID: 2, Status: domain.StatusAccepted}},
+	}}
+	repo.EXPECT().GetByID(mock.Anything, "evt-1").Return(event, nil).Maybe()
+
+	opts = append([]Option{WithAttachments(repository.NewLocalAttachmentStorage(), blobs)}, opts...)
+	return NewEventUseCase(repo, opts...), repo, dir
+}
+
+// blobFiles возвращает файлы, оставшиеся в хранилище.
+func blobFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	var files []string
+	filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files
+}
+
+func TestEventUseCase_Attachments(t *testing.T) {
+	uc, _, _ := newAttachmentUseCase(t)
+
+	slides, err := uc.AddAttachment(t.Context(), "evt-1", 2, `C:\Users\me\slides.pdf`, "", strings.NewReader(pdf))
+	require.NoError(t, err)
+	require.Equal(t, "slides.pdf", slides.Name)
+	require.Equal(t, "application/pdf", slides.ContentType) // тип не указан — определён по содержимому
+	require.Equal(t, int64(len(pdf)), slides.Size)
+	require.Equal(t, 2, slides.UploadedBy)
+
+	agenda, err := uc.AddAttachment(t.Context(), "evt-1", 1, "agenda.md", "text/markdown; charset=utf-8", strings.NewReader("# Agenda"))
+	require.NoError(t, err)
+	require.Equal(t, "text/markdown", agenda.ContentType)
+
+	list, err := uc.ListAttachments(t.Context(), "evt-1", 1)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+
+	a, rc, err := uc.OpenAttachment(t.Context(), "evt-1", slides.ID, 1)
+	require.NoError(t, err)
+	data, _ := io.ReadAll(rc)
+	rc.Close()
+	require.Equal(t, slides, a)
+	require.Equal(t, pdf, string(data))
+}
+
+func TestEventUseCase_AddAttachment_Limits(t *testing.T) {
+	uc, _, dir := newAttachmentUseCase(t, WithAttachmentLimits(64, "application/pdf", "image/*"))
+
+	_, err := uc.AddAttachment(t.Context(), "evt-1", 1, "big.pdf", "application/pdf", strings.NewReader(pdf))
+	require.ErrorIs(t, err, domain.ErrAttachmentTooLarge)
+
+	_, err = uc.AddAttachment(t.Context(), "evt-1", 1, "page.html", "", strings.NewReader("<html><body>hi</body></html>"))
+	require.ErrorIs(t, err, domain.ErrAttachmentType)
+
+	_, err = uc.AddAttachment(t.Context(), "evt-1", 1, "photo.png", "image/png", strings.NewReader("png"))
+	require.NoError(t, err)
+
+	list, _ := uc.ListAttachments(t.Context(), "evt-1", 1)
+	require.Len(t, list, 1)
+	require.Len(t, blobFiles(t, dir), 1, "rejected uploads must not leave files behind")
+}
+
+func TestEventUseCase_Attachments_Access(t *testing.T) {
+	uc, repo, _ := newAttachmentUseCase(t)
+	repo.EXPECT().GetByID(mock.Anything, "missing").Return(domain.Event{}, domain.ErrEventNotFound).Maybe()
+
+	_, err := uc.AddAttachment(t.Context(), "evt-1", 3, "x.txt", "text/plain", strings.NewReader("x"))
+	require.ErrorIs(t, err, domain.ErrNotInvited)
+	_, err = uc.AddAttachment(t.Context(), "missing", 1, "x.txt", "text/plain", strings.NewReader("x"))
+	require.ErrorIs(t, err, domain.ErrEventNotFound)
+
+	a, err := uc.AddAttachment(t.Context(), "evt-1", 1, "x.txt", "text/plain", strings.NewReader("x"))
+	require.NoError(t, err)
+
+	_, _, err = uc.OpenAttachment(t.Context(), "evt-1", a.ID, 3)
+	require.ErrorIs(t, err, domain.ErrNotInvited)
+	_, _, err = uc.OpenAttachment(t.Context(), "evt-1", "nope", 1)
+	require.ErrorIs(t, err, domain.ErrAttachmentNotFound)
+
+	// Участник не может удалить чужой файл, организатор — может.
+	require.ErrorIs(t, uc.DeleteAttachment(t.Context(), "evt-1", a.ID, 2), domain.ErrOwnerMismatch)
+	require.NoError(t, uc.DeleteAttachment(t.Context(), "evt-1", a.ID, 1))
+	_, _, err = uc.OpenAttachment(t.Context(), "evt-1", a.ID, 1)
+	require.ErrorIs(t, err, domain.ErrAttachmentNotFound)
+}
+
+func TestEventUseCase_PurgeTrash_CollectsAttachments(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	uc, repo, dir := newAttachmentUseCase(t, WithClock(func() time.Time { return now }))
+	repo.EXPECT().Delete(mock.Anything, "evt-1").Return(nil).Once()
+	repo.EXPECT().Purge(mock.Anything, now.Add(-DefaultTrashRetention)).
+		Return([]domain.Event{{ID: "evt-1", UserID: 1}}, nil).Once()
+
+	for _, name := range []string{"a.txt", "b.txt"} {
+		_, err := uc.AddAttachment(t.Context(), "evt-1", 2, name, "text/plain", strings.NewReader(name))
+		require.NoError(t, err)
+	}
+	require.Len(t, blobFiles(t, dir), 2)
+
+	// Пока событие в корзине, его можно восстановить — вложения остаются.
+	require.NoError(t, uc.DeleteEvent(t.Context(), "evt-1", 1))
+	require.Len(t, blobFiles(t, dir), 2)
+
+	n, err := uc.PurgeTrash(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	require.Empty(t, blobFiles(t, dir))
+	list, err := uc.attachments.ListAttachments(t.Context(), "evt-1")
+	require.NoError(t, err)
+	require.Empty(t, list)
+}
+
+func TestEventUseCase_AddAttachment_EventDeletedDuringUpload(t *testing.T) {
+	dir := t.TempDir()
+	blobs, err := blob.NewLocalStore(dir)
+	require.NoError(t, err)
+	repo := repoMocks.NewMockEventRepository(t)
+	uc := NewEventUseCase(repo, WithAttachments(repository.NewLocalAttachmentStorage(), blobs))
+
+	repo.EXPECT().GetByID(mock.Anything, "evt-1").Return(domain.Event{ID: "evt-1", UserID: 1}, nil).Once()
+	repo.EXPECT().GetByID(mock.Anything, "evt-1").Return(domain.Event{}, domain.ErrEventNotFound).Once()
+
+	_, err = uc.AddAttachment(t.Context(), "evt-1", 1, "x.txt", "text/plain", strings.NewReader("x"))
+	require.ErrorIs(t, err, domain.ErrEventNotFound)
+	require.Empty(t, blobFiles(t, dir))
+}
+
+func TestEventUseCase_AddAttachment_Disabled(t *testing.T) {
+	uc := NewEventUseCase(repoMocks.NewMockEventRepository(t))
+
+	_, err := uc.AddAttachment(t.Context(), "evt-1", 1, "x.txt", "text/plain", strings.NewReader("x"))
+	require.Error(t, err)
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"calendar/internal/domain"
+	"calendar/internal/reqlog"
+	"context"
+	"fmt"
+)
+
+// normalizeAttendees убирает дубли и самого организатора, а статусы берёт из prev
+// (для новых участников — needs-action). Статус из запроса игнорируется.
+func normalizeAttendees(organizer int, list, prev []domain.Attendee) ([]domain.Attendee, error) {
+	if len(list) == 0 {
+		return nil, nil
+	}
+
+	prevStatus := make(map[int]domain.AttendeeStatus, len(prev))
+	for _, a := range prev {
+		prevStatus[a.UserID] = a.Status
+	}
+
+	seen := make(map[int]bool, len(list))
+	res := make([]domain.Attendee, 0, len(list))
+	for _, a := range list {
+		if a.UserID <= 0 {
+			return nil, fmt.Errorf("%w: invalid attendee user_id %d", domain.ErrAttrsInvalid, a.UserID)
+		}
+		if a.UserID == organizer || seen[a.UserID] {
+			continue
+		}
+		seen[a.UserID] = true
+
+		status, ok := prevStatus[a.UserID]
+		if !ok {
+			status = domain.StatusNeedsAction
+		}
+		res = append(res, domain.Attendee{UserID: a.UserID, Status: status})
+	}
+	if len(res) == 0 {
+		return nil, nil
+	}
+	return res, nil
+}
+
+func attendeeIDs(attendees []domain.Attendee) []int {
+	ids := make([]int, 0, len(attendees))
+	for _, a := range attendees {
+		ids = append(ids, a.UserID)
+	}
+	return ids
+}
+
+// ownedEvent достаёт событие и проверяет, что userID — его организатор.
+func (uc *EventUseCase) ownedEvent(ctx context.Context, id string, userID int) (domain.Event, error) {
+	e, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return domain.Event{}, err
+	}
+	if e.UserID != userID {
+		return domain.Event{}, domain.ErrOwnerMismatch
+	}
+	return e, nil
+}
+
+// RespondToInvitation записывает ответ участника и сообщает о нём организатору.
+func (uc *EventUseCase) RespondToInvitation(ctx context.Context, id string, userID int, status domain.AttendeeStatus) error {
+	if !status.Valid() {
+		return domain.ErrStatusInvalid
+	}
+	if err := uc.repo.SetAttendeeStatus(ctx, id, userID, status); err != nil {
+		return err
+	}
+	reqlog.Printf(ctx, "[EVENT] user %d answered %s: %s", userID, id, status)
+
+	e, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	uc.notify(ctx, domain.NotifyResponded, e, userID, e.UserID)
+	return nil
+}
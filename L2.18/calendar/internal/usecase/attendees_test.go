@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"calendar/internal/domain"
+	repoMocks "calendar/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventUseCase_CreateEvent_InvitesAttendees(t *testing.T) {
+	repo := repoMocks.NewMockEventRepository(t)
+	notifier := repoMocks.NewMockNotifier(t)
+	uc := NewEventUseCase(repo, WithNotifier(notifier))
+
+	date := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	want := domain.Event{
+		UserID: 1,
+		Title:  "Sync",
+		Date:   date,
+		EventAttrs: domain.EventAttrs{Attendees: []domain.Attendee{
+			{UserID: 2, Status: domain.StatusNeedsAction},
+			{UserID: 3, Status: domain.StatusNeedsAction},
+		}},
+	}
+	repo.EXPECT().Create(mock.Anything, want).Return("evt-1", nil).Once()
+
+	want.ID = "evt-1"
+	for _, userID := range []int{2, 3} {
+		notifier.EXPECT().
+			Notify(mock.Anything, userID, domain.Notification{Kind: domain.NotifyInvited, Event: want, From: 1}).
+			Return(nil).
+			Once()
+	}
+
+	// Организатор и дубли выкидываются, присланный статус игнорируется.
+	id, err := uc.CreateEvent(t.Context(), 1, "2026-10-16", "Sync", domain.EventAttrs{Attendees: []domain.Attendee{
+		{UserID: 2, Status: domain.StatusAccepted}, {UserID: 1}, {UserID: 3}, {UserID: 2},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, "evt-1", id)
+}
+
+func TestEventUseCase_CreateEvent_InvalidAttendee(t *testing.T) {
+	repo := repoMocks.NewMockEventRepository(t)
+	uc := NewEventUseCase(repo)
+
+	_, err := uc.CreateEvent(t.Context(), 1, "2026-10-16", "Sync", domain.EventAttrs{Attendees: []domain.Attendee{{UserID: 0}}})
+	require.ErrorIs(t, err, domain.ErrAttrsInvalid)
+}
+
+func TestEventUseCase_OnlyOrganizerChangesEvent(t *testing.T) {
+	repo := repoMocks.NewMockEventRepository(t)
+	uc := NewEventUseCase(repo)
+
+	event := domain.Event{ID: "evt-1", UserID: 1, EventAttrs: domain.EventAttrs{
+		Attendees: []domain.Attendee{{UserID: 2, Status: domain.StatusAccepted}},
+	}}
+	repo.EXPECT().GetByID(mock.Anything, "evt-1").Return(event, nil)
+
+	err := uc.UpdateEvent(t.Context(), "evt-1", 2, "2026-10-16", "Mine now", domain.EventAttrs{})
+	require.ErrorIs(t, err, domain.ErrOwnerMismatch)
+
+	err = uc.DeleteEvent(t.Context(), "evt-1", 2)
+	require.ErrorIs(t, err, domain.ErrOwnerMismatch)
+
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestEventUseCase_UpdateEvent_NotifiesAttendees(t *testing.T) {
+	date := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	old := domain.Event{ID: "evt-1", UserID: 1, Title: "Sync", Date: date, EventAttrs: domain.EventAttrs{
+		Attendees: []domain.Attendee{
+			{UserID: 2, Status: domain.StatusAccepted},
+			{UserID: 3, Status: domain.StatusDeclined},
+		},
+	}}
+	newAttendees := []domain.Attendee{{UserID: 2}, {UserID: 4}}
+
+	t.Run("same date keeps answers", func(t *testing.T) {
+		repo := repoMocks.NewMockEventRepository(t)
+		notifier := repoMocks.NewMockNotifier(t)
+		uc := NewEventUseCase(repo, WithNotifier(notifier))
+
+		want := domain.Event{ID: "evt-1", UserID: 1, Title: "Sync v2", Date: date, EventAttrs: domain.EventAttrs{
+			Attendees: []domain.Attendee{
+				{UserID: 2, Status: domain.StatusAccepted},
+				{UserID: 4, Status: domain.StatusNeedsAction},
+			},
+		}}
+		repo.EXPECT().GetByID(mock.Anything, "evt-1").Return(old, nil).Once()
+		repo.EXPECT().Update(mock.Anything, want).Return(nil).Once()
+		notifier.EXPECT().Notify(mock.Anything, 2, domain.Notification{Kind: domain.NotifyUpdated, Event: want, From: 1}).Return(nil).Once()
+		notifier.EXPECT().Notify(mock.Anything, 4, domain.Notification{Kind: domain.NotifyInvited, Event: want, From: 1}).Return(nil).Once()
+		notifier.EXPECT().Notify(mock.Anything, 3, domain.Notification{Kind: domain.NotifyCancelled, Event: want, From: 1}).Return(nil).Once()
+
+		err := uc.UpdateEvent(t.Context(), "evt-1", 1, "2026-10-16", "Sync v2", domain.EventAttrs{Attendees: newAttendees})
+		require.NoError(t, err)
+	})
+
+	t.Run("moved event resets answers", func(t *testing.T) {
+		repo := repoMocks.NewMockEventRepository(t)
+		notifier := repoMocks.NewMockNotifier(t)
+		uc := NewEventUseCase(repo, WithNotifier(notifier))
+
+		repo.EXPECT().GetByID(mock.Anything, "evt-1").Return(old, nil).Once()
+		repo.EXPECT().
+			Update(mock.Anything, mock.MatchedBy(func(e domain.Event) bool {
+				return e.Attendees[0] == domain.Attendee{UserID: 2, Status: domain.StatusNeedsAction}
+			})).
+			Return(nil).
+			Once()
+		// Ошибка доставки не отменяет изменение.
+		notifier.EXPECT().Notify(mock.Anything, mock.Anything, mock.Anything).Return(errors.New("mail is down")).Times(3)
+
+		err := uc.UpdateEvent(t.Context(), "evt-1", 1, "2026-10-17", "Sync", domain.EventAttrs{Attendees: newAttendees})
+		require.NoError(t, err)
+	})
+}
+
+func TestEventUseCase_DeleteEvent_NotifiesAttendees(t *testing.T) {
+	repo := repoMocks.NewMockEventRepository(t)
+	notifier := repoMocks.NewMockNotifier(t)
+	uc := NewEventUseCase(repo, WithNotifier(notifier))
+
+	event := domain.Event{ID: "evt-1", UserID: 1, EventAttrs: domain.EventAttrs{
+		Attendees: []domain.Attendee{{UserID: 2, Status: domain.StatusAccepted}},
+	}}
+	repo.EXPECT().GetByID(mock.Anything, "evt-1").Return(event, nil).Once()
+	repo.EXPECT().Delete(mock.Anything, "evt-1").Return(nil).Once()
+	notifier.EXPECT().Notify(mock.Anything, 2, domain.Notification{Kind: domain.NotifyCancelled, Event: event, From: 1}).Return(nil).Once()
+
+	require.NoError(t, uc.DeleteEvent(t.Context(), "evt-1", 1))
+}
+
+func TestEventUseCase_RespondToInvitation(t *testing.T) {
+	t.Run("organizer is notified", func(t *testing.T) {
+		repo := repoMocks.NewMockEventRepository(t)
+		notifier := repoMocks.NewMockNotifier(t)
+		uc := NewEventUseCase(repo, WithNotifier(notifier))
+
+		event := domain.Event{ID: "evt-1", UserID: 1, EventAttrs: domain.EventAttrs{
+			Attendees: []domain.Attendee{{UserID: 2, Status: domain.StatusTentative}},
+		}}
+		repo.EXPECT().SetAttendeeStatus(mock.Anything, "evt-1", 2, domain.StatusTentative).Return(nil).Once()
+		repo.EXPECT().GetByID(mock.Anything, "evt-1").Return(event, nil).Once()
+		notifier.EXPECT().Notify(mock.Anything, 1, domain.Notification{Kind: domain.NotifyResponded, Event: event, From: 2}).Return(nil).Once()
+
+		require.NoError(t, uc.RespondToInvitation(t.Context(), "evt-1", 2, domain.StatusTentative))
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		repo := repoMocks.NewMockEventRepository(t)
+		uc := NewEventUseCase(repo)
+
+		err := uc.RespondToInvitation(t.Context(), "evt-1", 2, "maybe")
+		require.ErrorIs(t, err, domain.ErrStatusInvalid)
+	})
+
+	t.Run("not invited", func(t *testing.T) {
+		repo := repoMocks.NewMockEventRepository(t)
+		uc := NewEventUseCase(repo)
+
+		repo.EXPECT().SetAttendeeStatus(mock.Anything, "evt-1", 5, domain.StatusAccepted).Return(domain.ErrNotInvited).Once()
+
+		err := uc.RespondToInvitation(t.Context(), "evt-1", 5, domain.StatusAccepted)
+		require.ErrorIs(t, err, domain.ErrNotInvited)
+	})
+}
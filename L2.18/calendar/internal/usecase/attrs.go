@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"calendar/internal/domain"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// normalizeAttrs приводит теги к нижнему регистру без дублей и пробелов и проверяет приоритет и длительность.
+func normalizeAttrs(a domain.EventAttrs) (domain.EventAttrs, error) {
+	if a.Priority < 0 {
+		return a, fmt.Errorf("%w: priority must not be negative", domain.ErrAttrsInvalid)
+	}
+	if a.Duration < 0 {
+		return a, fmt.Errorf("%w: duration must not be negative", domain.ErrAttrsInvalid)
+	}
+	if a.Duration > domain.MaxDuration {
+		return a, fmt.Errorf("%w: duration must not exceed %d minutes", domain.ErrAttrsInvalid, domain.MaxDuration)
+	}
+	a.Tags = normalizeTags(a.Tags)
+	a.Category = strings.TrimSpace(a.Category)
+	a.UID = strings.TrimSpace(a.UID)
+	a.Shift = domain.ShiftNone // уже применён к дате
+	return a, nil
+}
+
+// checkUID проверяет, что у организатора нет другого события (кроме exceptID) с тем же UID:
+// по UID событие находят CalDAV-клиенты, и у дубля ресурс оказался бы общим.
+func (uc *EventUseCase) checkUID(ctx context.Context, userID int, uid, exceptID string) error {
+	if uid == "" {
+		return nil
+	}
+	events, err := uc.repo.GetByUID(ctx, userID, uid)
+	if err != nil {
+		return err
+	}
+	for _, e := range events {
+		if e.ID != exceptID {
+			return fmt.Errorf("%w: %q is used by %s", domain.ErrUIDConflict, uid, e.ID)
+		}
+	}
+	return nil
+}
+
+func normalizeTags(tags []string) []string {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(tags))
+	res := make([]string, 0, len(tags))
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		res = append(res, t)
+	}
+	if len(res) == 0 {
+		return nil
+	}
+	return res
+}
+
+// find достаёт события за период с учётом фильтра. Теги ищутся по индексу репозитория,
+// категория и приоритет проверяются уже на отобранных событиях.
+func (uc *EventUseCase) find(ctx context.Context, userID int, from, to time.Time, f domain.EventFilter) ([]domain.Event, error) {
+	f.Tags = normalizeTags(f.Tags)
+	f.Category = strings.TrimSpace(f.Category)
+
+	var events []domain.Event
+	var err error
+	if len(f.Tags) > 0 {
+		events, err = uc.repo.GetByUserTagsAndRange(ctx, userID, f.Tags, f.MatchAll, from, to)
+	} else {
+		events, err = uc.repo.GetByUserAndRange(ctx, userID, from, to)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if f.Category == "" && f.MinPriority == 0 {
+		return events, nil
+	}
+	var res []domain.Event
+	for _, e := range events {
+		if f.Match(e) {
+			res = append(res, e)
+		}
+	}
+	return res, nil
+}
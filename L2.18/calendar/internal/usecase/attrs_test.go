@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"calendar/internal/domain"
+	"calendar/internal/repository"
+	repoMocks "calendar/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventUseCase_CreateEvent_NormalizesAttrs(t *testing.T) {
+	repo := repoMocks.NewMockEventRepository(t)
+	uc := NewEventUseCase(repo)
+
+	repo.EXPECT().
+		Create(mock.Anything, domain.Event{
+			UserID: 1,
+			Title:  "Release",
+			Date:   time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC),
+			EventAttrs: domain.EventAttrs{
+				Tags:     []string{"work", "urgent"},
+				Category: "meeting",
+				Priority: 3,
+			},
+		}).
+		Return("evt-1", nil).
+		Once()
+
+	_, err := uc.CreateEvent(t.Context(), 1, "2026-10-16", "Release", domain.EventAttrs{
+		Tags:     []string{" Work", "urgent", "work", ""},
+		Category: " meeting ",
+		Priority: 3,
+	})
+	require.NoError(t, err)
+}
+
+func TestEventUseCase_NegativePriority(t *testing.T) {
+	repo := repoMocks.NewMockEventRepository(t)
+	uc := NewEventUseCase(repo)
+
+	_, err := uc.CreateEvent(t.Context(), 1, "2026-10-16", "t", domain.EventAttrs{Priority: -1})
+	require.ErrorIs(t, err, domain.ErrAttrsInvalid)
+
+	err = uc.UpdateEvent(t.Context(), "evt-1", 1, "2026-10-16", "t", domain.EventAttrs{Priority: -1})
+	require.ErrorIs(t, err, domain.ErrAttrsInvalid)
+}
+
+func TestEventUseCase_DurationBounds(t *testing.T) {
+	repo := repoMocks.NewMockEventRepository(t)
+	uc := NewEventUseCase(repo)
+
+	for _, d := range []int{-1, domain.MaxDuration + 1, 1 << 40} {
+		_, err := uc.CreateEvent(t.Context(), 1, "2026-10-16", "t", domain.EventAttrs{Duration: d})
+		require.ErrorIs(t, err, domain.ErrAttrsInvalid, "duration %d", d)
+
+		err = uc.UpdateEvent(t.Context(), "evt-1", 1, "2026-10-16", "t", domain.EventAttrs{Duration: d})
+		require.ErrorIs(t, err, domain.ErrAttrsInvalid, "duration %d", d)
+	}
+
+	repo.EXPECT().Create(mock.Anything, mock.Anything).Return("evt-1", nil).Once()
+	_, err := uc.CreateEvent(t.Context(), 1, "2026-10-16", "t", domain.EventAttrs{Duration: domain.MaxDuration})
+	require.NoError(t, err)
+}
+
+func TestEventUseCase_Filters(t *testing.T) {
+	from := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+
+	low := domain.Event{ID: "1", Date: from, EventAttrs: domain.EventAttrs{Tags: []string{"work"}, Category: "meeting", Priority: 1}}
+	high := domain.Event{ID: "2", Date: from, EventAttrs: domain.EventAttrs{Tags: []string{"work", "urgent"}, Category: "meeting", Priority: 5}}
+	other := domain.Event{ID: "3", Date: from, EventAttrs: domain.EventAttrs{Category: "personal", Priority: 5}}
+
+	t.Run("tags go through the index", func(t *testing.T) {
+		repo := repoMocks.NewMockEventRepository(t)
+		uc := NewEventUseCase(repo)
+
+		repo.EXPECT().
+			GetByUserTagsAndRange(mock.Anything, 1, []string{"work", "urgent"}, true, from, to).
+			Return([]domain.Event{high}, nil).
+			Once()
+
+		got, err := uc.GetEventsForDay(t.Context(), 1, "2026-10-16", domain.PeriodOptions{},
+			domain.EventFilter{Tags: []string{"Work", "urgent"}, MatchAll: true})
+		require.NoError(t, err)
+		require.Equal(t, []domain.Event{high}, got)
+	})
+
+	t.Run("category and priority without tags", func(t *testing.T) {
+		repo := repoMocks.NewMockEventRepository(t)
+		uc := NewEventUseCase(repo)
+
+		repo.EXPECT().
+			GetByUserAndRange(mock.Anything, 1, from, to).
+			Return([]domain.Event{low, high, other}, nil).
+			Once()
+
+		got, err := uc.GetEventsForDay(t.Context(), 1, "2026-10-16", domain.PeriodOptions{},
+			domain.EventFilter{Category: "meeting", MinPriority: 3})
+		require.NoError(t, err)
+		require.Equal(t, []domain.Event{high}, got)
+	})
+}
+
+func TestEventFilter_Match(t *testing.T) {
+	e := domain.Event{EventAttrs: domain.EventAttrs{Tags: []string{"a", "b"}, Category: "c", Priority: 2}}
+
+	tests := []struct {
+		name   string
+		filter domain.EventFilter
+		want   bool
+	}{
+		{"empty filter", domain.EventFilter{}, true},
+		{"any of, one matches", domain.EventFilter{Tags: []string{"x", "b"}}, true},
+		{"any of, none matches", domain.EventFilter{Tags: []string{"x", "y"}}, false},
+		{"all of, all present", domain.EventFilter{Tags: []string{"a", "b"}, MatchAll: true}, true},
+		{"all of, one missing", domain.EventFilter{Tags: []string{"a", "x"}, MatchAll: true}, false},
+		{"category mismatch", domain.EventFilter{Category: "d"}, false},
+		{"priority too low", domain.EventFilter{MinPriority: 3}, false},
+		{"priority enough", domain.EventFilter{MinPriority: 2, Category: "c"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.filter.Match(e))
+		})
+	}
+}
+
+func TestEventUseCase_UID(t *testing.T) {
+	ctx := t.Context()
+	uc := NewEventUseCase(repository.NewLocalStorage())
+
+	id, err := uc.CreateEvent(ctx, 1, "2026-10-16", "Synced", domain.EventAttrs{UID: "abc-1"})
+	require.NoError(t, err)
+
+	// Обновление без UID (обычный REST-клиент) сохраняет прежний.
+	require.NoError(t, uc.UpdateEvent(ctx, id, 1, "2026-10-17", "Renamed", domain.EventAttrs{}))
+	e, err := uc.repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, "abc-1", e.UID)
+
+	// Второй UID у того же пользователя занять нельзя — ни созданием, ни правкой.
+	_, err = uc.CreateEvent(ctx, 1, "2026-10-18", "Copy", domain.EventAttrs{UID: "abc-1"})
+	require.ErrorIs(t, err, domain.ErrUIDConflict)
+	other, err := uc.CreateEvent(ctx, 1, "2026-10-18", "Other", domain.EventAttrs{UID: "abc-2"})
+	require.NoError(t, err)
+	err = uc.UpdateEvent(ctx, other, 1, "2026-10-18", "Other", domain.EventAttrs{UID: "abc-1"})
+	require.ErrorIs(t, err, domain.ErrUIDConflict)
+
+	// У другого пользователя свой набор UID.
+	_, err = uc.CreateEvent(ctx, 2, "2026-10-16", "Synced", domain.EventAttrs{UID: "abc-1"})
+	require.NoError(t, err)
+}
+
+// Проверка UID ищет по индексу репозитория, а не перебирает все события пользователя.
+func TestEventUseCase_UIDLookup(t *testing.T) {
+	repo := repoMocks.NewMockEventRepository(t)
+	uc := NewEventUseCase(repo)
+
+	repo.EXPECT().GetByUID(mock.Anything, 1, "abc-1").Return([]domain.Event{{ID: "event_7", UserID: 1}}, nil).Once()
+
+	_, err := uc.CreateEvent(t.Context(), 1, "2026-10-16", "Copy", domain.EventAttrs{UID: "abc-1"})
+	require.ErrorIs(t, err, domain.ErrUIDConflict)
+	require.ErrorContains(t, err, "event_7")
+}
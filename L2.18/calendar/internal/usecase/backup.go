@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"bufio"
+	"calendar/internal/domain"
+	"calendar/internal/reqlog"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Режимы загрузки дампа.
+const (
+	BackupMerge   = "merge"   // события дампа заменяют события с теми же ID, остальные остаются
+	BackupReplace = "replace" // прежнее содержимое удаляется
+)
+
+const (
+	backupFormat  = "calendar-backup"
+	backupVersion = 1
+)
+
+// Дамп — gzip с NDJSON: первая строка — заголовок, дальше по событию на строку.
+// Заголовок хранит число записей, так что оборванный дамп не загрузится частично.
+// Вложения и настройки пользователей в дамп не входят.
+type backupHeader struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	domain.BackupSummary
+}
+
+// backupRecord — событие из дампа; DeletedAt задан у событий из корзины.
+type backupRecord struct {
+	domain.Event
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// Backup пишет в w дамп всех событий вместе с корзиной на один момент времени.
+// Если снимок получить не удалось, в w ничего не записано.
+func (uc *EventUseCase) Backup(ctx context.Context, w io.Writer) (domain.BackupSummary, error) {
+	snap, err := uc.repo.Snapshot(ctx)
+	if err != nil {
+		return domain.BackupSummary{}, err
+	}
+	// Порядок записей не важен для загрузки, но одинаковые хранилища дают одинаковые дампы.
+	slices.SortFunc(snap.Events, func(a, b domain.Event) int { return strings.Compare(a.ID, b.ID) })
+	slices.SortFunc(snap.Deleted, func(a, b domain.DeletedEvent) int { return strings.Compare(a.ID, b.ID) })
+
+	summary := domain.BackupSummary{Events: len(snap.Events), Deleted: len(snap.Deleted)}
+	gz := gzip.NewWriter(w)
+	enc := json.NewEncoder(gz)
+	if err := enc.Encode(backupHeader{Format: backupFormat, Version: backupVersion, CreatedAt: uc.now().UTC(), BackupSummary: summary}); err != nil {
+		return domain.BackupSummary{}, err
+	}
+	for _, e := range snap.Events {
+		if err := enc.Encode(backupRecord{Event: e}); err != nil {
+			return domain.BackupSummary{}, err
+		}
+	}
+	for _, d := range snap.Deleted {
+		if err := enc.Encode(backupRecord{Event: d.Event, DeletedAt: &d.DeletedAt}); err != nil {
+			return domain.BackupSummary{}, err
+		}
+	}
+	if err := gz.Close(); err != nil {
+		return domain.BackupSummary{}, err
+	}
+
+	reqlog.Printf(ctx, "[BACKUP] dumped %d events and %d deleted", summary.Events, summary.Deleted)
+	return summary, nil
+}
+
+// LoadBackup загружает дамп, созданный Backup (сжатый или нет), в режиме BackupMerge
+// или BackupReplace. Дамп читается и проверяется целиком до того, как хранилище изменится.
+// Квоты при загрузке не проверяются.
+func (uc *EventUseCase) LoadBackup(ctx context.Context, r io.Reader, mode string) (domain.BackupSummary, error) {
+	if mode != BackupMerge && mode != BackupReplace {
+		return domain.BackupSummary{}, fmt.Errorf("%w: unknown mode %q, want %q or %q", domain.ErrBackupInvalid, mode, BackupMerge, BackupReplace)
+	}
+
+	snap, err := readBackup(r)
+	if err != nil {
+		return domain.BackupSummary{}, err
+	}
+	if err := uc.repo.Load(ctx, snap, mode == BackupReplace); err != nil {
+		return domain.BackupSummary{}, err
+	}
+
+	summary := domain.BackupSummary{Events: len(snap.Events), Deleted: len(snap.Deleted)}
+	reqlog.Printf(ctx, "[BACKUP] loaded %d events and %d deleted (%s)", summary.Events, summary.Deleted, mode)
+	return summary, nil
+}
+
+func readBackup(r io.Reader) (domain.Snapshot, error) {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w: "+format, append([]any{domain.ErrBackupInvalid}, args...)...)
+	}
+
+	br := bufio.NewReader(r)
+	var src io.Reader = br
+	if magic, _ := br.Peek(2); len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return domain.Snapshot{}, invalid("%v", err)
+		}
+		defer gz.Close()
+		src = gz
+	}
+	dec := json.NewDecoder(src)
+
+	var h backupHeader
+	if err := dec.Decode(&h); err != nil {
+		return domain.Snapshot{}, invalid("header: %v", err)
+	}
+	if h.Format != backupFormat || h.Version != backupVersion {
+		return domain.Snapshot{}, invalid("unsupported format %q version %d", h.Format, h.Version)
+	}
+
+	var snap domain.Snapshot
+	seen := make(map[string]bool)
+	for n := 1; ; n++ {
+		var rec backupRecord
+		err := dec.Decode(&rec)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return domain.Snapshot{}, invalid("record %d: %v", n, err)
+		}
+		switch {
+		case rec.ID == "":
+			return domain.Snapshot{}, invalid("record %d: missing id", n)
+		case seen[rec.ID]:
+			return domain.Snapshot{}, invalid("record %d: duplicate id %q", n, rec.ID)
+		case rec.Date.IsZero():
+			return domain.Snapshot{}, invalid("record %d: missing date", n)
+		}
+		seen[rec.ID] = true
+
+		if rec.DeletedAt != nil {
+			snap.Deleted = append(snap.Deleted, domain.DeletedEvent{Event: rec.Event, DeletedAt: *rec.DeletedAt})
+		} else {
+			snap.Events = append(snap.Events, rec.Event)
+		}
+	}
+
+	if len(snap.Events) != h.Events || len(snap.Deleted) != h.Deleted {
+		return domain.Snapshot{}, invalid("truncated: header promises %d events and %d deleted, got %d and %d",
+			h.Events, h.Deleted, len(snap.Events), len(snap.Deleted))
+	}
+	return snap, nil
+}
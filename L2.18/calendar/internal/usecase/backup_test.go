@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"calendar/internal/domain"
+	"calendar/internal/repository"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventUseCase_BackupRoundTrip(t *testing.T) {
+	ctx := t.Context()
+	now := func() time.Time { return time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC) }
+	src := NewEventUseCase(repository.NewLocalStorage(), WithClock(now))
+
+	_, err := src.CreateEvent(ctx, 1, "2026-10-20 10:00", "Review", domain.EventAttrs{
+		Tags: []string{"work"}, Duration: 45, Attendees: []domain.Attendee{{UserID: 2}},
+	})
+	require.NoError(t, err)
+	gone, err := src.CreateEvent(ctx, 1, "2026-10-21", "Cancelled", domain.EventAttrs{})
+	require.NoError(t, err)
+	require.NoError(t, src.DeleteEvent(ctx, gone, 1))
+
+	var dump bytes.Buffer
+	summary, err := src.Backup(ctx, &dump)
+	require.NoError(t, err)
+	require.Equal(t, domain.BackupSummary{Events: 1, Deleted: 1}, summary)
+
+	// Дамп — gzip с NDJSON: заголовок и по строке на событие.
+	gz, err := gzip.NewReader(bytes.NewReader(dump.Bytes()))
+	require.NoError(t, err)
+	plain, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(string(plain), "\n"), "\n")
+	require.Len(t, lines, 3)
+	require.JSONEq(t, `{"format":"calendar-backup","version":1,"created_at":"2026-10-18T09:00:00Z","events":1,"deleted":1}`, lines[0])
+
+	// Другой бэкенд, уже с данными: replace их убирает.
+	dst := NewEventUseCase(repository.NewShardedStorage(4), WithClock(now))
+	_, err = dst.CreateEvent(ctx, 3, "2026-10-20", "Old", domain.EventAttrs{})
+	require.NoError(t, err)
+
+	summary, err = dst.LoadBackup(ctx, bytes.NewReader(dump.Bytes()), BackupReplace)
+	require.NoError(t, err)
+	require.Equal(t, domain.BackupSummary{Events: 1, Deleted: 1}, summary)
+
+	events, err := dst.GetEventsForDay(ctx, 2, "2026-10-20", domain.PeriodOptions{}, domain.EventFilter{})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, "Review", events[0].Title)
+	events, err = dst.GetEventsForDay(ctx, 3, "2026-10-20", domain.PeriodOptions{}, domain.EventFilter{})
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	trash, err := dst.ListTrash(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, trash, 1)
+	require.Equal(t, "Cancelled", trash[0].Title)
+
+	// Повторный дамп загруженного хранилища совпадает с исходным.
+	var again bytes.Buffer
+	_, err = dst.Backup(ctx, &again)
+	require.NoError(t, err)
+	gz, err = gzip.NewReader(&again)
+	require.NoError(t, err)
+	plainAgain, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	require.Equal(t, string(plain), string(plainAgain))
+
+	// Несжатый NDJSON тоже принимается.
+	summary, err = dst.LoadBackup(ctx, bytes.NewReader(plain), BackupMerge)
+	require.NoError(t, err)
+	require.Equal(t, 1, summary.Events)
+}
+
+func TestEventUseCase_LoadBackup_Invalid(t *testing.T) {
+	header := `{"format":"calendar-backup","version":1,"events":1,"deleted":0}` + "\n"
+	event := `{"id":"event_1","user_id":1,"title":"a","date":"2026-10-20T00:00:00Z"}` + "\n"
+
+	tests := []struct {
+		name, mode, body string
+	}{
+		{name: "unknown mode", mode: "upsert", body: header + event},
+		{name: "empty", mode: BackupMerge, body: ""},
+		{name: "foreign format", mode: BackupMerge, body: `{"format":"ics","version":1}` + "\n"},
+		{name: "truncated", mode: BackupMerge, body: header},
+		{name: "broken record", mode: BackupMerge, body: header + `{"id":`},
+		{name: "missing id", mode: BackupMerge, body: header + `{"user_id":1,"date":"2026-10-20T00:00:00Z"}` + "\n"},
+		{name: "duplicate id", mode: BackupMerge, body: strings.Replace(header, `"events":1`, `"events":2`, 1) + event + event},
+		{name: "corrupt gzip", mode: BackupMerge, body: "\x1f\x8bgarbage"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repository.NewLocalStorage()
+			uc := NewEventUseCase(repo)
+			_, err := uc.CreateEvent(t.Context(), 1, "2026-10-20", "Existing", domain.EventAttrs{})
+			require.NoError(t, err)
+
+			_, err = uc.LoadBackup(t.Context(), strings.NewReader(tt.body), tt.mode)
+			require.ErrorIs(t, err, domain.ErrBackupInvalid)
+
+			// Хранилище не изменилось.
+			events, err := uc.GetEventsForDay(t.Context(), 1, "2026-10-20", domain.PeriodOptions{}, domain.EventFilter{})
+			require.NoError(t, err)
+			require.Len(t, events, 1)
+			require.Equal(t, "Existing", events[0].Title)
+		})
+	}
+}
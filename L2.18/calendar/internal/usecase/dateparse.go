@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"calendar/internal/domain"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Разбор дат запроса: кроме YYYY-MM-DD понимаем относительные выражения на английском
+// и русском — «tomorrow», «next friday», «in 3 days», «завтра», «в пятницу», «через неделю».
+// Результат — полночь найденного дня в часовом поясе пользователя; у абсолютной даты
+// можно указать и время: «2026-10-19 14:30».
+
+var relativeDays = map[string]int{
+	"today": 0, "сегодня": 0,
+	"tomorrow": 1, "завтра": 1,
+	"yesterday": -1, "вчера": -1,
+	"day after tomorrow": 2, "the day after tomorrow": 2, "послезавтра": 2,
+	"day before yesterday": -2, "the day before yesterday": -2, "позавчера": -2,
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"sunday": time.Sunday, "воскресенье": time.Sunday,
+	"monday": time.Monday, "понедельник": time.Monday,
+	"tuesday": time.Tuesday, "вторник": time.Tuesday,
+	"wednesday": time.Wednesday, "среда": time.Wednesday, "среду": time.Wednesday,
+	"thursday": time.Thursday, "четверг": time.Thursday,
+	"friday": time.Friday, "пятница": time.Friday, "пятницу": time.Friday,
+	"saturday": time.Saturday, "суббота": time.Saturday, "субботу": time.Saturday,
+}
+
+// Модификаторы перед днём недели.
+const (
+	weekdayThis = iota // ближайший, включая сегодня
+	weekdayNext        // ближайший после сегодня
+	weekdayLast        // ближайший до сегодня
+)
+
+var weekdayModifiers = map[string]int{
+	"this": weekdayThis, "эту": weekdayThis, "этот": weekdayThis, "это": weekdayThis, "эта": weekdayThis,
+	"next": weekdayNext, "следующую": weekdayNext, "следующий": weekdayNext, "следующее": weekdayNext, "следующая": weekdayNext,
+	"last": weekdayLast, "прошлую": weekdayLast, "прошлый": weekdayLast, "прошлое": weekdayLast, "прошлая": weekdayLast,
+}
+
+// unitSteps: единица измерения -> (лет, месяцев, дней) на одну единицу.
+var unitSteps = map[string][3]int{
+	"day": {0, 0, 1}, "days": {0, 0, 1}, "день": {0, 0, 1}, "дня": {0, 0, 1}, "дней": {0, 0, 1},
+	"week": {0, 0, 7}, "weeks": {0, 0, 7}, "неделю": {0, 0, 7}, "недели": {0, 0, 7}, "недель": {0, 0, 7}, "неделе": {0, 0, 7},
+	"month": {0, 1, 0}, "months": {0, 1, 0}, "месяц": {0, 1, 0}, "месяца": {0, 1, 0}, "месяцев": {0, 1, 0}, "месяце": {0, 1, 0},
+	"year": {1, 0, 0}, "years": {1, 0, 0}, "год": {1, 0, 0}, "года": {1, 0, 0}, "лет": {1, 0, 0}, "году": {1, 0, 0},
+}
+
+var (
+	reIn    = regexp.MustCompile(`^(?:in|через) (?:(\d+|a|an|one) )?(\pL+)$`)
+	reAgo   = regexp.MustCompile(`^(?:(\d+|a|an|one) )?(\pL+) (?:ago|назад)$`)
+	reNextP = regexp.MustCompile(`^(?:next|на следующей|в следующем) (\pL+)$`)
+)
+
+var absoluteLayouts = []string{"2006-01-02", "2006-01-02 15:04", "2006-01-02T15:04"}
+
+// parseDate разбирает s относительно момента now в зоне loc.
+func parseDate(s string, now time.Time, loc *time.Location) (time.Time, error) {
+	for _, layout := range absoluteLayouts {
+		if t, err := time.ParseInLocation(layout, strings.TrimSpace(s), loc); err == nil {
+			return t, nil
+		}
+	}
+
+	s = normalizeDate(s)
+	y, m, d := now.In(loc).Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, loc)
+
+	if n, ok := relativeDays[s]; ok {
+		return today.AddDate(0, 0, n), nil
+	}
+
+	if t, ok := parseWeekday(s, today); ok {
+		return t, nil
+	}
+
+	if mm := reIn.FindStringSubmatch(s); mm != nil {
+		if t, ok := shift(today, mm[1], mm[2], 1); ok {
+			return t, nil
+		}
+	}
+	if mm := reAgo.FindStringSubmatch(s); mm != nil {
+		if t, ok := shift(today, mm[1], mm[2], -1); ok {
+			return t, nil
+		}
+	}
+	// «next week», «на следующей неделе», «в следующем месяце» = через одну единицу.
+	if mm := reNextP.FindStringSubmatch(s); mm != nil {
+		if t, ok := shift(today, "", mm[1], 1); ok {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, domain.ErrDateInvalid
+}
+
+func normalizeDate(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.ReplaceAll(s, "ё", "е")
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// parseWeekday понимает «friday», «next friday», «on friday», «в пятницу», «во вторник»,
+// «в следующую пятницу», «в прошлый понедельник».
+func parseWeekday(s string, today time.Time) (time.Time, bool) {
+	words := strings.Fields(s)
+	if len(words) > 0 {
+		switch words[0] {
+		case "on", "в", "во":
+			words = words[1:]
+		}
+	}
+
+	mod := weekdayThis
+	if len(words) == 2 {
+		m, ok := weekdayModifiers[words[0]]
+		if !ok {
+			return time.Time{}, false
+		}
+		mod, words = m, words[1:]
+	}
+	if len(words) != 1 {
+		return time.Time{}, false
+	}
+	wd, ok := weekdayNames[words[0]]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	switch mod {
+	case weekdayNext:
+		days := (int(wd) - int(today.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		return today.AddDate(0, 0, days), true
+	case weekdayLast:
+		days := (int(today.Weekday()) - int(wd) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		return today.AddDate(0, 0, -days), true
+	default:
+		return today.AddDate(0, 0, (int(wd)-int(today.Weekday())+7)%7), true
+	}
+}
+
+// shift сдвигает дату на count единиц unit в направлении sign. Пустое count значит одну единицу.
+func shift(today time.Time, count, unit string, sign int) (time.Time, bool) {
+	step, ok := unitSteps[unit]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	n := 1
+	switch count {
+	case "", "a", "an", "one":
+	default:
+		v, err := strconv.Atoi(count)
+		if err != nil {
+			return time.Time{}, false
+		}
+		n = v
+	}
+
+	n *= sign
+	return today.AddDate(step[0]*n, step[1]*n, step[2]*n), true
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"calendar/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDate(t *testing.T) {
+	// Среда, 14 октября 2026, поздний вечер по UTC.
+	now := time.Date(2026, 10, 14, 22, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"2026-02-09", "2026-02-09"},
+		{"today", "2026-10-14"},
+		{"Tomorrow", "2026-10-15"},
+		{"  yesterday ", "2026-10-13"},
+		{"day after tomorrow", "2026-10-16"},
+		{"friday", "2026-10-16"},
+		{"on friday", "2026-10-16"},
+		{"wednesday", "2026-10-14"},
+		{"next wednesday", "2026-10-21"},
+		{"next friday", "2026-10-16"},
+		{"last monday", "2026-10-12"},
+		{"in 3 days", "2026-10-17"},
+		{"in a week", "2026-10-21"},
+		{"in 2 months", "2026-12-14"},
+		{"next month", "2026-11-14"},
+		{"2 weeks ago", "2026-09-30"},
+		{"сегодня", "2026-10-14"},
+		{"Завтра", "2026-10-15"},
+		{"послезавтра", "2026-10-16"},
+		{"вчера", "2026-10-13"},
+		{"в пятницу", "2026-10-16"},
+		{"во вторник", "2026-10-20"},
+		{"в следующую среду", "2026-10-21"},
+		{"в прошлый четверг", "2026-10-08"},
+		{"через 3 дня", "2026-10-17"},
+		{"через неделю", "2026-10-21"},
+		{"через 5 лет", "2031-10-14"},
+		{"на следующей неделе", "2026-10-21"},
+		{"в следующем месяце", "2026-11-14"},
+		{"3 дня назад", "2026-10-11"},
+		{"неделю назад", "2026-10-07"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseDate(tt.in, now, time.UTC)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got.Format("2006-01-02"))
+			require.Equal(t, time.UTC, got.Location())
+			require.Zero(t, got.Hour())
+		})
+	}
+}
+
+func TestParseDate_UsesUserTimeZone(t *testing.T) {
+	// В UTC ещё 14 октября, а во Владивостоке (UTC+10) уже 15-е.
+	now := time.Date(2026, 10, 14, 22, 30, 0, 0, time.UTC)
+	vladivostok, err := time.LoadLocation("Asia/Vladivostok")
+	require.NoError(t, err)
+
+	got, err := parseDate("tomorrow", now, vladivostok)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2026, 10, 16, 0, 0, 0, 0, vladivostok), got)
+}
+
+func TestParseDate_Invalid(t *testing.T) {
+	now := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+
+	for _, in := range []string{"", "not-a-date", "2026-13-01", "next blursday", "in many days", "через 3 попугая", "this week friday"} {
+		_, err := parseDate(in, now, time.UTC)
+		require.ErrorIs(t, err, domain.ErrDateInvalid, in)
+	}
+}
+
+func TestParseDate_WithTime(t *testing.T) {
+	now := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	for _, in := range []string{"2026-10-19 14:30", "2026-10-19T14:30"} {
+		got, err := parseDate(in, now, moscow)
+		require.NoError(t, err, in)
+		require.Equal(t, time.Date(2026, 10, 19, 14, 30, 0, 0, moscow), got, in)
+	}
+}
@@ -1,73 +1,294 @@
 package usecase
 
 import (
+	"calendar/internal/blob"
 	"calendar/internal/domain"
+	"calendar/internal/holiday"
 	"calendar/internal/repository"
+	"calendar/internal/reqlog"
+	"context"
+	"sync"
 	"time"
 )
 
 type EventUseCase struct {
-	repo repository.EventRepository
+	repo     repository.EventRepository
+	settings repository.SettingsRepository
+	notifier Notifier
+	now      func() time.Time
+
+	trashRetention time.Duration
+	holidays       *holiday.Calendar
+
+	quota     domain.Quota
+	userLocks [64]sync.Mutex // см. lockUser
+
+	attachments     repository.AttachmentRepository
+	blobs           blob.Store // nil — вложения выключены
+	maxAttachment   int64
+	attachmentTypes []string
+}
+
+// Option настраивает EventUseCase.
+type Option func(*EventUseCase)
+
+// WithSettings задаёт хранилище пользовательских настроек
+// (по умолчанию используется in-memory хранилище).
+func WithSettings(settings repository.SettingsRepository) Option {
+	return func(uc *EventUseCase) {
+		uc.settings = settings
+	}
 }
 
-func NewEventUseCase(repo repository.EventRepository) *EventUseCase {
-	return &EventUseCase{repo: repo}
+// WithClock задаёт источник текущего времени для относительных дат («завтра», «next friday»).
+func WithClock(now func() time.Time) Option {
+	return func(uc *EventUseCase) {
+		uc.now = now
+	}
 }
 
-func (uc *EventUseCase) CreateEvent(userID int, dateStr, title string) (string, error) {
-	date, err := time.Parse("2006-01-02", dateStr)
+func NewEventUseCase(repo repository.EventRepository, opts ...Option) *EventUseCase {
+	uc := &EventUseCase{
+		repo:     repo,
+		settings: repository.NewLocalSettingsStorage(),
+		notifier: logNotifier{},
+		now:      time.Now,
+
+		trashRetention: DefaultTrashRetention,
+		holidays:       holiday.Weekends(),
+
+		attachments:     repository.NewLocalAttachmentStorage(),
+		maxAttachment:   DefaultMaxAttachmentSize,
+		attachmentTypes: DefaultAttachmentTypes,
+	}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
+}
+
+func (uc *EventUseCase) CreateEvent(ctx context.Context, userID int, dateStr, title string, attrs domain.EventAttrs) (string, error) {
+	event, err := uc.newEvent(ctx, userID, dateStr, title, attrs)
 	if err != nil {
-		return "", domain.ErrDateInvalid
+		return "", err
 	}
+	return uc.createEvent(ctx, event, quotaPending{})
+}
 
-	event := domain.Event{
-		UserID: userID,
-		Title:  title,
-		Date:   date,
+// newEvent разбирает и проверяет поля нового события, ничего не сохраняя.
+func (uc *EventUseCase) newEvent(ctx context.Context, userID int, dateStr, title string, attrs domain.EventAttrs) (domain.Event, error) {
+	date, err := uc.EventDate(ctx, userID, dateStr, attrs.Shift)
+	if err != nil {
+		return domain.Event{}, err
 	}
-	return uc.repo.Create(event)
+	if err := uc.checkTitle(title); err != nil {
+		return domain.Event{}, err
+	}
+	attrs, err = normalizeAttrs(attrs)
+	if err != nil {
+		return domain.Event{}, err
+	}
+	attrs.Attendees, err = normalizeAttendees(userID, attrs.Attendees, nil)
+	if err != nil {
+		return domain.Event{}, err
+	}
+
+	return domain.Event{
+		UserID:     userID,
+		Title:      title,
+		Date:       date,
+		EventAttrs: attrs,
+	}, nil
+}
+
+// createEvent сохраняет проверенное событие в пределах квоты и рассылает приглашения.
+func (uc *EventUseCase) createEvent(ctx context.Context, event domain.Event, pending quotaPending) (string, error) {
+	unlock := uc.lockUser(event.UserID)
+	if err := uc.checkNewEvent(ctx, event.UserID, event.Date, pending); err != nil {
+		unlock()
+		return "", err
+	}
+	if err := uc.checkUID(ctx, event.UserID, event.UID, ""); err != nil {
+		unlock()
+		return "", err
+	}
+	id, err := uc.repo.Create(ctx, event)
+	unlock()
+	if err != nil {
+		return "", err
+	}
+
+	event.ID = id
+	reqlog.Printf(ctx, "[EVENT] user %d created %s on %s", event.UserID, id, event.Date.Format("2006-01-02"))
+	uc.notify(ctx, domain.NotifyInvited, event, event.UserID, attendeeIDs(event.Attendees)...)
+	return id, nil
 }
 
-func (uc *EventUseCase) UpdateEvent(id string, userID int, dateStr, title string) error {
-	date, err := time.Parse("2006-01-02", dateStr)
+// UpdateEvent изменяет событие; это может сделать только организатор.
+// Участники получают уведомления, а при переносе на другую дату их ответы сбрасываются.
+func (uc *EventUseCase) UpdateEvent(ctx context.Context, id string, userID int, dateStr, title string, attrs domain.EventAttrs) error {
+	date, err := uc.EventDate(ctx, userID, dateStr, attrs.Shift)
+	if err != nil {
+		return err
+	}
+	if err := uc.checkTitle(title); err != nil {
+		return err
+	}
+	attrs, err = normalizeAttrs(attrs)
 	if err != nil {
-		return domain.ErrDateInvalid
+		return err
+	}
+
+	old, err := uc.ownedEvent(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	// REST-клиенты про UID не знают: без него событие сменило бы имя ресурса в CalDAV.
+	if attrs.UID == "" {
+		attrs.UID = old.UID
+	}
+	prev := old.Attendees
+	if !old.Date.Equal(date) {
+		prev = nil
+	}
+	attrs.Attendees, err = normalizeAttendees(userID, attrs.Attendees, prev)
+	if err != nil {
+		return err
 	}
 
 	event := domain.Event{
-		ID:     id,
-		UserID: userID,
-		Title:  title,
-		Date:   date,
+		ID:         id,
+		UserID:     userID,
+		Title:      title,
+		Date:       date,
+		EventAttrs: attrs,
+	}
+	unlock := uc.lockUser(userID)
+	if !sameDay(old.Date, date) {
+		if err := uc.checkDay(ctx, userID, date, id, 0); err != nil {
+			unlock()
+			return err
+		}
+	}
+	if attrs.UID != old.UID {
+		if err := uc.checkUID(ctx, userID, attrs.UID, id); err != nil {
+			unlock()
+			return err
+		}
+	}
+	err = uc.repo.Update(ctx, event)
+	unlock()
+	if err != nil {
+		return err
+	}
+	reqlog.Printf(ctx, "[EVENT] user %d updated %s", userID, id)
+
+	var invited, updated, removed []int
+	for _, a := range event.Attendees {
+		if old.HasAttendee(a.UserID) {
+			updated = append(updated, a.UserID)
+		} else {
+			invited = append(invited, a.UserID)
+		}
+	}
+	for _, a := range old.Attendees {
+		if !event.HasAttendee(a.UserID) {
+			removed = append(removed, a.UserID)
+		}
+	}
+	uc.notify(ctx, domain.NotifyInvited, event, userID, invited...)
+	uc.notify(ctx, domain.NotifyUpdated, event, userID, updated...)
+	uc.notify(ctx, domain.NotifyCancelled, event, userID, removed...)
+	return nil
+}
+
+// DeleteEvent переносит событие организатора в корзину и сообщает участникам об отмене.
+func (uc *EventUseCase) DeleteEvent(ctx context.Context, id string, userID int) error {
+	event, err := uc.ownedEvent(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	// Событие ушло в корзину; вложения удаляются вместе с ним при очистке корзины.
+	reqlog.Printf(ctx, "[EVENT] user %d moved %s to trash", userID, id)
+
+	uc.notify(ctx, domain.NotifyCancelled, event, userID, attendeeIDs(event.Attendees)...)
+	return nil
+}
+
+func (uc *EventUseCase) GetEventsForDay(ctx context.Context, userID int, dateStr string, opts domain.PeriodOptions, filter domain.EventFilter) ([]domain.Event, error) {
+	p, t, err := uc.parseQuery(ctx, userID, dateStr, opts)
+	if err != nil {
+		return nil, err
+	}
+	from, to := p.day(t)
+	return uc.find(ctx, userID, from, to, filter)
+}
+
+func (uc *EventUseCase) GetEventsForWeek(ctx context.Context, userID int, dateStr string, opts domain.PeriodOptions, filter domain.EventFilter) ([]domain.Event, error) {
+	p, t, err := uc.parseQuery(ctx, userID, dateStr, opts)
+	if err != nil {
+		return nil, err
+	}
+	from, to := p.week(t)
+	return uc.find(ctx, userID, from, to, filter)
+}
+
+func (uc *EventUseCase) GetEventsForMonth(ctx context.Context, userID int, dateStr string, opts domain.PeriodOptions, filter domain.EventFilter) ([]domain.Event, error) {
+	p, t, err := uc.parseQuery(ctx, userID, dateStr, opts)
+	if err != nil {
+		return nil, err
 	}
-	return uc.repo.Update(event)
+	from, to := p.month(t)
+	return uc.find(ctx, userID, from, to, filter)
+}
+
+// GetEventsInRange возвращает события, видимые пользователю, с началом в [from, to).
+func (uc *EventUseCase) GetEventsInRange(ctx context.Context, userID int, from, to time.Time) ([]domain.Event, error) {
+	return uc.repo.GetByUserAndRange(ctx, userID, from, to)
 }
 
-func (uc *EventUseCase) DeleteEvent(id string) error {
-	return uc.repo.Delete(id)
+func (uc *EventUseCase) GetUserSettings(ctx context.Context, userID int) (domain.UserSettings, error) {
+	return uc.settings.GetSettings(ctx, userID)
 }
 
-func (uc *EventUseCase) GetEventsForDay(userID int, dateStr string) ([]domain.Event, error) {
-	t, err := time.Parse("2006-01-02", dateStr)
+// UpdateUserSettings сохраняет настройки пользователя, предварительно проверив их.
+func (uc *EventUseCase) UpdateUserSettings(ctx context.Context, s domain.UserSettings) error {
+	if _, err := parsePeriod(s.PeriodOptions); err != nil {
+		return err
+	}
+	return uc.settings.SaveSettings(ctx, s)
+}
+
+// ResolveDate превращает дату запроса — YYYY-MM-DD или выражение вроде «tomorrow», «завтра»,
+// «next friday» — в полночь этого дня в часовом поясе пользователя (или opts.TimeZone).
+func (uc *EventUseCase) ResolveDate(ctx context.Context, userID int, dateStr string, opts domain.PeriodOptions) (time.Time, error) {
+	p, err := uc.userPeriod(ctx, userID, opts)
 	if err != nil {
-		return nil, domain.ErrDateInvalid
+		return time.Time{}, err
 	}
-	return uc.repo.GetByUserAndRange(userID, t, t.AddDate(0, 0, 1))
+	return parseDate(dateStr, uc.now(), p.loc)
 }
 
-func (uc *EventUseCase) GetEventsForWeek(userID int, dateStr string) ([]domain.Event, error) {
-	t, err := time.Parse("2006-01-02", dateStr)
+// userPeriod объединяет опции запроса с настройками пользователя и разбирает их.
+func (uc *EventUseCase) userPeriod(ctx context.Context, userID int, opts domain.PeriodOptions) (period, error) {
+	s, err := uc.settings.GetSettings(ctx, userID)
 	if err != nil {
-		return nil, domain.ErrDateInvalid
+		return period{}, err
 	}
-	
-	return uc.repo.GetByUserAndRange(userID, t, t.AddDate(0, 0, 7))
+	return parsePeriod(mergeOptions(opts, s.PeriodOptions))
 }
 
-func (uc *EventUseCase) GetEventsForMonth(userID int, dateStr string) ([]domain.Event, error) {
-	t, err := time.Parse("2006-01-02", dateStr)
+func (uc *EventUseCase) parseQuery(ctx context.Context, userID int, dateStr string, opts domain.PeriodOptions) (period, time.Time, error) {
+	p, err := uc.userPeriod(ctx, userID, opts)
+	if err != nil {
+		return period{}, time.Time{}, err
+	}
+	t, err := parseDate(dateStr, uc.now(), p.loc)
 	if err != nil {
-		return nil, domain.ErrDateInvalid
+		return period{}, time.Time{}, err
 	}
-	return uc.repo.GetByUserAndRange(userID, t, t.AddDate(0, 1, 0))
-}
\ No newline at end of file
+	return p, t, nil
+}
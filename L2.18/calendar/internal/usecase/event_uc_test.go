@@ -2,9 +2,11 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
+	_ "time/tzdata" // тесты с часовыми поясами не должны зависеть от системной tzdata
 
 	"calendar/internal/domain"
 	repoMocks "calendar/mocks"
@@ -17,13 +19,13 @@ func TestEventUseCase_CreateEvent_InvalidDate(t *testing.T) {
 	repo := repoMocks.NewMockEventRepository(t)
 	uc := NewEventUseCase(repo)
 
-	id, err := uc.CreateEvent(1, "not-a-date", "title")
+	id, err := uc.CreateEvent(t.Context(), 1, "not-a-date", "title", domain.EventAttrs{})
 
 	require.ErrorIs(t, err, domain.ErrDateInvalid)
 	require.Empty(t, id)
 
 	// If date invalid, repo.Create must not be called; AssertExpectations is handled by mock cleanup.
-	repo.AssertNotCalled(t, "Create", mock.Anything)
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
 }
 
 func TestEventUseCase_CreateEvent_OK(t *testing.T) {
@@ -39,7 +41,7 @@ func TestEventUseCase_CreateEvent_OK(t *testing.T) {
 	require.NoError(t, err)
 
 	repo.EXPECT().
-		Create(domain.Event{
+		Create(mock.Anything, domain.Event{
 			UserID: userID,
 			Title:  title,
 			Date:   wantDate,
@@ -47,7 +49,7 @@ func TestEventUseCase_CreateEvent_OK(t *testing.T) {
 		Return(wantID, nil).
 		Once()
 
-	id, err := uc.CreateEvent(userID, dateStr, title)
+	id, err := uc.CreateEvent(t.Context(), userID, dateStr, title, domain.EventAttrs{})
 
 	require.NoError(t, err)
 	require.Equal(t, wantID, id)
@@ -66,11 +68,11 @@ func TestEventUseCase_CreateEvent_RepoError(t *testing.T) {
 	require.NoError(t, err)
 
 	repo.EXPECT().
-		Create(domain.Event{UserID: userID, Title: title, Date: wantDate}).
+		Create(mock.Anything, domain.Event{UserID: userID, Title: title, Date: wantDate}).
 		Return("", wantErr).
 		Once()
 
-	id, err := uc.CreateEvent(userID, dateStr, title)
+	id, err := uc.CreateEvent(t.Context(), userID, dateStr, title, domain.EventAttrs{})
 
 	require.ErrorIs(t, err, wantErr)
 	require.Empty(t, id)
@@ -80,10 +82,10 @@ func TestEventUseCase_UpdateEvent_InvalidDate(t *testing.T) {
 	repo := repoMocks.NewMockEventRepository(t)
 	uc := NewEventUseCase(repo)
 
-	err := uc.UpdateEvent("id1", 1, "bad-date", "title")
+	err := uc.UpdateEvent(t.Context(), "id1", 1, "bad-date", "title", domain.EventAttrs{})
 
 	require.ErrorIs(t, err, domain.ErrDateInvalid)
-	repo.AssertNotCalled(t, "Update", mock.Anything)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
 }
 
 func TestEventUseCase_UpdateEvent_OK(t *testing.T) {
@@ -98,8 +100,9 @@ func TestEventUseCase_UpdateEvent_OK(t *testing.T) {
 	wantDate, err := time.Parse("2006-01-02", dateStr)
 	require.NoError(t, err)
 
+	repo.EXPECT().GetByID(mock.Anything, id).Return(domain.Event{ID: id, UserID: userID}, nil).Once()
 	repo.EXPECT().
-		Update(domain.Event{
+		Update(mock.Anything, domain.Event{
 			ID:     id,
 			UserID: userID,
 			Title:  title,
@@ -108,7 +111,7 @@ func TestEventUseCase_UpdateEvent_OK(t *testing.T) {
 		Return(nil).
 		Once()
 
-	err = uc.UpdateEvent(id, userID, dateStr, title)
+	err = uc.UpdateEvent(t.Context(), id, userID, dateStr, title, domain.EventAttrs{})
 	require.NoError(t, err)
 }
 
@@ -125,12 +128,13 @@ func TestEventUseCase_UpdateEvent_RepoError(t *testing.T) {
 	wantDate, err := time.Parse("2006-01-02", dateStr)
 	require.NoError(t, err)
 
+	repo.EXPECT().GetByID(mock.Anything, id).Return(domain.Event{ID: id, UserID: userID}, nil).Once()
 	repo.EXPECT().
-		Update(domain.Event{ID: id, UserID: userID, Title: title, Date: wantDate}).
+		Update(mock.Anything, domain.Event{ID: id, UserID: userID, Title: title, Date: wantDate}).
 		Return(wantErr).
 		Once()
 
-	err = uc.UpdateEvent(id, userID, dateStr, title)
+	err = uc.UpdateEvent(t.Context(), id, userID, dateStr, title, domain.EventAttrs{})
 	require.ErrorIs(t, err, wantErr)
 }
 
@@ -138,9 +142,10 @@ func TestEventUseCase_DeleteEvent_OK(t *testing.T) {
 	repo := repoMocks.NewMockEventRepository(t)
 	uc := NewEventUseCase(repo)
 
-	repo.EXPECT().Delete("evt-1").Return(nil).Once()
+	repo.EXPECT().GetByID(mock.Anything, "evt-1").Return(domain.Event{ID: "evt-1", UserID: 1}, nil).Once()
+	repo.EXPECT().Delete(mock.Anything, "evt-1").Return(nil).Once()
 
-	err := uc.DeleteEvent("evt-1")
+	err := uc.DeleteEvent(t.Context(), "evt-1", 1)
 	require.NoError(t, err)
 }
 
@@ -149,9 +154,10 @@ func TestEventUseCase_DeleteEvent_RepoError(t *testing.T) {
 	uc := NewEventUseCase(repo)
 
 	wantErr := errors.New("delete failed")
-	repo.EXPECT().Delete("evt-1").Return(wantErr).Once()
+	repo.EXPECT().GetByID(mock.Anything, "evt-1").Return(domain.Event{ID: "evt-1", UserID: 1}, nil).Once()
+	repo.EXPECT().Delete(mock.Anything, "evt-1").Return(wantErr).Once()
 
-	err := uc.DeleteEvent("evt-1")
+	err := uc.DeleteEvent(t.Context(), "evt-1", 1)
 	require.ErrorIs(t, err, wantErr)
 }
 
@@ -159,11 +165,11 @@ func TestEventUseCase_GetEventsForDay_InvalidDate(t *testing.T) {
 	repo := repoMocks.NewMockEventRepository(t)
 	uc := NewEventUseCase(repo)
 
-	events, err := uc.GetEventsForDay(1, "bad-date")
+	events, err := uc.GetEventsForDay(t.Context(), 1, "bad-date", domain.PeriodOptions{}, domain.EventFilter{})
 
 	require.ErrorIs(t, err, domain.ErrDateInvalid)
 	require.Nil(t, events)
-	repo.AssertNotCalled(t, "GetByUserAndRange", mock.Anything, mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "GetByUserAndRange", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestEventUseCase_GetEventsForDay_OK(t *testing.T) {
@@ -182,11 +188,11 @@ func TestEventUseCase_GetEventsForDay_OK(t *testing.T) {
 	}
 
 	repo.EXPECT().
-		GetByUserAndRange(userID, from, to).
+		GetByUserAndRange(mock.Anything, userID, from, to).
 		Return(want, nil).
 		Once()
 
-	got, err := uc.GetEventsForDay(userID, dateStr)
+	got, err := uc.GetEventsForDay(t.Context(), userID, dateStr, domain.PeriodOptions{}, domain.EventFilter{})
 	require.NoError(t, err)
 	require.Equal(t, want, got)
 }
@@ -195,11 +201,11 @@ func TestEventUseCase_GetEventsForWeek_InvalidDate(t *testing.T) {
 	repo := repoMocks.NewMockEventRepository(t)
 	uc := NewEventUseCase(repo)
 
-	events, err := uc.GetEventsForWeek(1, "bad-date")
+	events, err := uc.GetEventsForWeek(t.Context(), 1, "bad-date", domain.PeriodOptions{}, domain.EventFilter{})
 
 	require.ErrorIs(t, err, domain.ErrDateInvalid)
 	require.Nil(t, events)
-	repo.AssertNotCalled(t, "GetByUserAndRange", mock.Anything, mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "GetByUserAndRange", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestEventUseCase_GetEventsForWeek_OK(t *testing.T) {
@@ -218,11 +224,11 @@ func TestEventUseCase_GetEventsForWeek_OK(t *testing.T) {
 	}
 
 	repo.EXPECT().
-		GetByUserAndRange(userID, from, to).
+		GetByUserAndRange(mock.Anything, userID, from, to).
 		Return(want, nil).
 		Once()
 
-	got, err := uc.GetEventsForWeek(userID, dateStr)
+	got, err := uc.GetEventsForWeek(t.Context(), userID, dateStr, domain.PeriodOptions{}, domain.EventFilter{})
 	require.NoError(t, err)
 	require.Equal(t, want, got)
 }
@@ -231,11 +237,11 @@ func TestEventUseCase_GetEventsForMonth_InvalidDate(t *testing.T) {
 	repo := repoMocks.NewMockEventRepository(t)
 	uc := NewEventUseCase(repo)
 
-	events, err := uc.GetEventsForMonth(1, "bad-date")
+	events, err := uc.GetEventsForMonth(t.Context(), 1, "bad-date", domain.PeriodOptions{}, domain.EventFilter{})
 
 	require.ErrorIs(t, err, domain.ErrDateInvalid)
 	require.Nil(t, events)
-	repo.AssertNotCalled(t, "GetByUserAndRange", mock.Anything, mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "GetByUserAndRange", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestEventUseCase_GetEventsForMonth_OK(t *testing.T) {
@@ -254,11 +260,223 @@ func TestEventUseCase_GetEventsForMonth_OK(t *testing.T) {
 	}
 
 	repo.EXPECT().
-		GetByUserAndRange(userID, from, to).
+		GetByUserAndRange(mock.Anything, userID, from, to).
 		Return(want, nil).
 		Once()
 
-	got, err := uc.GetEventsForMonth(userID, dateStr)
+	got, err := uc.GetEventsForMonth(t.Context(), userID, dateStr, domain.PeriodOptions{}, domain.EventFilter{})
 	require.NoError(t, err)
 	require.Equal(t, want, got)
 }
+
+func TestEventUseCase_CalendarAlignedPeriods(t *testing.T) {
+	day := func(s string) time.Time {
+		d, err := time.Parse("2006-01-02", s)
+		require.NoError(t, err)
+		return d
+	}
+
+	tests := []struct {
+		name     string
+		call     func(uc *EventUseCase) ([]domain.Event, error)
+		from, to time.Time
+	}{
+		{
+			name: "ISO week from wednesday",
+			call: func(uc *EventUseCase) ([]domain.Event, error) {
+				return uc.GetEventsForWeek(t.Context(), 1, "2026-10-14", domain.PeriodOptions{Align: domain.AlignCalendar, WeekStart: "iso"}, domain.EventFilter{})
+			},
+			from: day("2026-10-12"), to: day("2026-10-19"),
+		},
+		{
+			name: "week starting on sunday",
+			call: func(uc *EventUseCase) ([]domain.Event, error) {
+				return uc.GetEventsForWeek(t.Context(), 1, "2026-10-14", domain.PeriodOptions{Align: domain.AlignCalendar, WeekStart: "sunday"}, domain.EventFilter{})
+			},
+			from: day("2026-10-11"), to: day("2026-10-18"),
+		},
+		{
+			name: "date is the week start itself",
+			call: func(uc *EventUseCase) ([]domain.Event, error) {
+				return uc.GetEventsForWeek(t.Context(), 1, "2026-10-12", domain.PeriodOptions{Align: domain.AlignCalendar}, domain.EventFilter{})
+			},
+			from: day("2026-10-12"), to: day("2026-10-19"),
+		},
+		{
+			name: "week crossing a year boundary",
+			call: func(uc *EventUseCase) ([]domain.Event, error) {
+				return uc.GetEventsForWeek(t.Context(), 1, "2027-01-01", domain.PeriodOptions{Align: domain.AlignCalendar}, domain.EventFilter{})
+			},
+			from: day("2026-12-28"), to: day("2027-01-04"),
+		},
+		{
+			name: "calendar month",
+			call: func(uc *EventUseCase) ([]domain.Event, error) {
+				return uc.GetEventsForMonth(t.Context(), 1, "2026-02-17", domain.PeriodOptions{Align: domain.AlignCalendar}, domain.EventFilter{})
+			},
+			from: day("2026-02-01"), to: day("2026-03-01"),
+		},
+		{
+			name: "explicit rolling week",
+			call: func(uc *EventUseCase) ([]domain.Event, error) {
+				return uc.GetEventsForWeek(t.Context(), 1, "2026-10-14", domain.PeriodOptions{Align: domain.AlignRolling}, domain.EventFilter{})
+			},
+			from: day("2026-10-14"), to: day("2026-10-21"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repoMocks.NewMockEventRepository(t)
+			uc := NewEventUseCase(repo)
+
+			repo.EXPECT().GetByUserAndRange(mock.Anything, 1, tt.from, tt.to).Return(nil, nil).Once()
+
+			_, err := tt.call(uc)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestEventUseCase_PeriodsAreDSTCorrect(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	repo := repoMocks.NewMockEventRepository(t)
+	uc := NewEventUseCase(repo)
+	opts := domain.PeriodOptions{Align: domain.AlignCalendar, TimeZone: "Europe/Berlin"}
+
+	// 29 марта 2026 в Берлине переводят часы вперёд: сутки длятся 23 часа.
+	dayFrom := time.Date(2026, 3, 29, 0, 0, 0, 0, berlin)
+	dayTo := time.Date(2026, 3, 30, 0, 0, 0, 0, berlin)
+	repo.EXPECT().
+		GetByUserAndRange(mock.Anything, 1, dayFrom, dayTo).
+		Run(func(_ context.Context, _ int, from, to time.Time) {
+			require.Equal(t, 23*time.Hour, to.Sub(from))
+		}).
+		Return(nil, nil).
+		Once()
+	_, err = uc.GetEventsForDay(t.Context(), 1, "2026-03-29", opts, domain.EventFilter{})
+	require.NoError(t, err)
+
+	// Неделя, содержащая этот день, — на час короче 7*24h.
+	weekFrom := time.Date(2026, 3, 23, 0, 0, 0, 0, berlin)
+	weekTo := time.Date(2026, 3, 30, 0, 0, 0, 0, berlin)
+	repo.EXPECT().
+		GetByUserAndRange(mock.Anything, 1, weekFrom, weekTo).
+		Run(func(_ context.Context, _ int, from, to time.Time) {
+			require.Equal(t, 7*24*time.Hour-time.Hour, to.Sub(from))
+		}).
+		Return(nil, nil).
+		Once()
+	_, err = uc.GetEventsForWeek(t.Context(), 1, "2026-03-25", opts, domain.EventFilter{})
+	require.NoError(t, err)
+
+	// Октябрьский месяц содержит обратный переход: на час длиннее 31 суток.
+	monthFrom := time.Date(2026, 10, 1, 0, 0, 0, 0, berlin)
+	monthTo := time.Date(2026, 11, 1, 0, 0, 0, 0, berlin)
+	repo.EXPECT().
+		GetByUserAndRange(mock.Anything, 1, monthFrom, monthTo).
+		Run(func(_ context.Context, _ int, from, to time.Time) {
+			require.Equal(t, 31*24*time.Hour+time.Hour, to.Sub(from))
+		}).
+		Return(nil, nil).
+		Once()
+	_, err = uc.GetEventsForMonth(t.Context(), 1, "2026-10-16", opts, domain.EventFilter{})
+	require.NoError(t, err)
+}
+
+func TestEventUseCase_UserSettingsAreDefaults(t *testing.T) {
+	repo := repoMocks.NewMockEventRepository(t)
+	settings := repoMocks.NewMockSettingsRepository(t)
+	uc := NewEventUseCase(repo, WithSettings(settings))
+
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	settings.EXPECT().
+		GetSettings(mock.Anything, 5).
+		Return(domain.UserSettings{UserID: 5, PeriodOptions: domain.PeriodOptions{
+			Align:     domain.AlignCalendar,
+			WeekStart: "sunday",
+			TimeZone:  "Europe/Moscow",
+		}}, nil)
+
+	// Настройки пользователя: календарная неделя с воскресенья по Москве.
+	repo.EXPECT().
+		GetByUserAndRange(mock.Anything, 5, time.Date(2026, 10, 11, 0, 0, 0, 0, moscow), time.Date(2026, 10, 18, 0, 0, 0, 0, moscow)).
+		Return(nil, nil).
+		Once()
+	_, err = uc.GetEventsForWeek(t.Context(), 5, "2026-10-14", domain.PeriodOptions{}, domain.EventFilter{})
+	require.NoError(t, err)
+
+	// Параметры запроса важнее настроек.
+	repo.EXPECT().
+		GetByUserAndRange(mock.Anything, 5, time.Date(2026, 10, 14, 0, 0, 0, 0, moscow), time.Date(2026, 10, 21, 0, 0, 0, 0, moscow)).
+		Return(nil, nil).
+		Once()
+	_, err = uc.GetEventsForWeek(t.Context(), 5, "2026-10-14", domain.PeriodOptions{Align: domain.AlignRolling}, domain.EventFilter{})
+	require.NoError(t, err)
+
+	// Событие создаётся в полночь по часовому поясу пользователя.
+	repo.EXPECT().
+		Create(mock.Anything, domain.Event{UserID: 5, Title: "t", Date: time.Date(2026, 10, 14, 0, 0, 0, 0, moscow)}).
+		Return("evt-1", nil).
+		Once()
+	_, err = uc.CreateEvent(t.Context(), 5, "2026-10-14", "t", domain.EventAttrs{})
+	require.NoError(t, err)
+}
+
+func TestEventUseCase_InvalidPeriodOptions(t *testing.T) {
+	tests := []domain.PeriodOptions{
+		{Align: "fortnight"},
+		{WeekStart: "someday"},
+		{TimeZone: "Mars/Olympus_Mons"},
+	}
+
+	for _, opts := range tests {
+		repo := repoMocks.NewMockEventRepository(t)
+		uc := NewEventUseCase(repo)
+
+		_, err := uc.GetEventsForWeek(t.Context(), 1, "2026-10-14", opts, domain.EventFilter{})
+		require.ErrorIs(t, err, domain.ErrPeriodInvalid)
+
+		err = uc.UpdateUserSettings(t.Context(), domain.UserSettings{UserID: 1, PeriodOptions: opts})
+		require.ErrorIs(t, err, domain.ErrPeriodInvalid)
+	}
+}
+
+func TestEventUseCase_RelativeDates(t *testing.T) {
+	repo := repoMocks.NewMockEventRepository(t)
+	settings := repoMocks.NewMockSettingsRepository(t)
+	now := time.Date(2026, 10, 14, 22, 30, 0, 0, time.UTC) // среда
+	uc := NewEventUseCase(repo, WithSettings(settings), WithClock(func() time.Time { return now }))
+
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	settings.EXPECT().
+		GetSettings(mock.Anything, 1).
+		Return(domain.UserSettings{UserID: 1, PeriodOptions: domain.PeriodOptions{TimeZone: "Europe/Moscow"}}, nil)
+
+	// В Москве уже 15 октября, так что «завтра» — 16-е.
+	got, err := uc.ResolveDate(t.Context(), 1, "завтра", domain.PeriodOptions{})
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2026, 10, 16, 0, 0, 0, 0, moscow), got)
+
+	repo.EXPECT().
+		Create(mock.Anything, domain.Event{UserID: 1, Title: "demo", Date: time.Date(2026, 10, 16, 0, 0, 0, 0, moscow)}).
+		Return("evt-1", nil).
+		Once()
+	_, err = uc.CreateEvent(t.Context(), 1, "next friday", "demo", domain.EventAttrs{})
+	require.NoError(t, err)
+
+	repo.EXPECT().
+		GetByUserAndRange(mock.Anything, 1, time.Date(2026, 10, 18, 0, 0, 0, 0, moscow), time.Date(2026, 10, 19, 0, 0, 0, 0, moscow)).
+		Return(nil, nil).
+		Once()
+	_, err = uc.GetEventsForDay(t.Context(), 1, "in 3 days", domain.PeriodOptions{}, domain.EventFilter{})
+	require.NoError(t, err)
+
+	_, err = uc.ResolveDate(t.Context(), 1, "someday", domain.PeriodOptions{})
+	require.ErrorIs(t, err, domain.ErrDateInvalid)
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"calendar/internal/domain"
+	"calendar/internal/holiday"
+	"calendar/internal/reqlog"
+	"context"
+	"fmt"
+	"time"
+)
+
+// maxWorkingDays — предел |n| для AddWorkingDays: десять лет. Календарь перебирает
+// дни по одному, и без предела один запрос занимал бы процессор надолго.
+const maxWorkingDays = 10 * 366
+
+// WithHolidays задаёт производственный календарь (по умолчанию — только выходные
+// суббота и воскресенье).
+func WithHolidays(cal *holiday.Calendar) Option {
+	return func(uc *EventUseCase) {
+		uc.holidays = cal
+	}
+}
+
+// GetCalendarDays возвращает все дни периода с их видом по производственному календарю.
+func (uc *EventUseCase) GetCalendarDays(ctx context.Context, userID int, dateStr, kind string, opts domain.PeriodOptions) ([]domain.CalendarDay, error) {
+	p, t, err := uc.parseQuery(ctx, userID, dateStr, opts)
+	if err != nil {
+		return nil, err
+	}
+	from, to, err := p.bounds(kind, t)
+	if err != nil {
+		return nil, err
+	}
+
+	var days []domain.CalendarDay
+	for day := from; day.Before(to); {
+		_, next := p.day(day)
+		days = append(days, uc.holidays.Day(day))
+		day = next
+	}
+	return days, nil
+}
+
+// AddWorkingDays прибавляет к dateStr n рабочих дней (n < 0 — отнимает).
+// Сама дата не считается; при n = 0 нерабочая дата сдвигается на следующий рабочий день.
+func (uc *EventUseCase) AddWorkingDays(ctx context.Context, userID int, dateStr string, n int, opts domain.PeriodOptions) (time.Time, error) {
+	if n > maxWorkingDays || n < -maxWorkingDays {
+		return time.Time{}, fmt.Errorf("%w: %d, limit is %d", domain.ErrDaysInvalid, n, maxWorkingDays)
+	}
+	_, t, err := uc.parseQuery(ctx, userID, dateStr, opts)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return uc.holidays.AddWorkingDays(t, n), nil
+}
+
+// EventDate — дата, на которую CreateEvent и UpdateEvent поставят событие: dateStr
+// в часовом поясе пользователя, перенесённая с нерабочего дня по shift (domain.ShiftNone — без переноса).
+func (uc *EventUseCase) EventDate(ctx context.Context, userID int, dateStr, shift string) (time.Time, error) {
+	date, err := uc.ResolveDate(ctx, userID, dateStr, domain.PeriodOptions{})
+	if err != nil {
+		return time.Time{}, err
+	}
+	return uc.ShiftToWorkingDay(ctx, date, shift)
+}
+
+// ShiftToWorkingDay переносит дату с нерабочего дня на ближайший рабочий
+// в направлении dir; domain.ShiftNone оставляет дату как есть.
+func (uc *EventUseCase) ShiftToWorkingDay(ctx context.Context, date time.Time, dir string) (time.Time, error) {
+	switch dir {
+	case domain.ShiftNone:
+		return date, nil
+	case domain.ShiftNext, domain.ShiftPrevious:
+	default:
+		return time.Time{}, domain.ErrShiftInvalid
+	}
+	shifted := uc.holidays.Shift(date, dir)
+	if !shifted.Equal(date) {
+		reqlog.Printf(ctx, "[HOLIDAY] moved %s off a non-working day to %s", date.Format("2006-01-02"), shifted.Format("2006-01-02"))
+	}
+	return shifted, nil
+}
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"calendar/internal/domain"
+	"calendar/internal/holiday"
+	repoMocks "calendar/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventUseCase_GetCalendarDays(t *testing.T) {
+	uc := NewEventUseCase(repoMocks.NewMockEventRepository(t), WithHolidays(holiday.RU()))
+
+	days, err := uc.GetCalendarDays(t.Context(), 1, "2026-05-06", domain.PeriodWeek, domain.PeriodOptions{Align: domain.AlignCalendar})
+	require.NoError(t, err)
+
+	kinds := map[string]domain.DayKind{}
+	for _, d := range days {
+		kinds[d.Date.Format(time.DateOnly)] = d.Kind
+	}
+	require.Equal(t, map[string]domain.DayKind{
+		"2026-05-04": domain.DayWorking,
+		"2026-05-05": domain.DayWorking,
+		"2026-05-06": domain.DayWorking,
+		"2026-05-07": domain.DayWorking,
+		"2026-05-08": domain.DayShort,
+		"2026-05-09": domain.DayHoliday,
+		"2026-05-10": domain.DayWeekend,
+	}, kinds)
+	require.Equal(t, "День Победы", days[5].Name)
+}
+
+func TestEventUseCase_AddWorkingDays(t *testing.T) {
+	uc := NewEventUseCase(repoMocks.NewMockEventRepository(t), WithHolidays(holiday.RU()))
+
+	got, err := uc.AddWorkingDays(t.Context(), 1, "2026-12-30 18:00", 1, domain.PeriodOptions{TimeZone: "Europe/Moscow"})
+	require.NoError(t, err)
+	moscow, _ := time.LoadLocation("Europe/Moscow")
+	require.Equal(t, time.Date(2027, 1, 11, 18, 0, 0, 0, moscow), got)
+
+	_, err = uc.AddWorkingDays(t.Context(), 1, "someday", 1, domain.PeriodOptions{})
+	require.ErrorIs(t, err, domain.ErrDateInvalid)
+
+	for _, n := range []int{maxWorkingDays + 1, -maxWorkingDays - 1, 2000000000} {
+		_, err = uc.AddWorkingDays(t.Context(), 1, "2026-10-16", n, domain.PeriodOptions{})
+		require.ErrorIs(t, err, domain.ErrDaysInvalid, "n = %d", n)
+	}
+}
+
+func TestEventUseCase_CreateEvent_Shift(t *testing.T) {
+	repo := repoMocks.NewMockEventRepository(t)
+	uc := NewEventUseCase(repo, WithHolidays(holiday.RU()))
+
+	// Перенос применяется к дате, но в событии не сохраняется.
+	repo.EXPECT().
+		Create(mock.Anything, domain.Event{UserID: 1, Title: "Kickoff", Date: time.Date(2026, 1, 12, 10, 0, 0, 0, time.UTC)}).
+		Return("evt-1", nil).
+		Once()
+	_, err := uc.CreateEvent(t.Context(), 1, "2026-01-01 10:00", "Kickoff", domain.EventAttrs{Shift: domain.ShiftNext})
+	require.NoError(t, err)
+
+	_, err = uc.CreateEvent(t.Context(), 1, "2026-01-01", "Kickoff", domain.EventAttrs{Shift: "sideways"})
+	require.ErrorIs(t, err, domain.ErrShiftInvalid)
+}
+
+func TestEventUseCase_ShiftToWorkingDay(t *testing.T) {
+	uc := NewEventUseCase(repoMocks.NewMockEventRepository(t), WithHolidays(holiday.RU()))
+	newYear := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	got, err := uc.ShiftToWorkingDay(t.Context(), newYear, domain.ShiftNext)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2026, 1, 12, 10, 0, 0, 0, time.UTC), got)
+
+	got, err = uc.ShiftToWorkingDay(t.Context(), newYear, domain.ShiftPrevious)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2025, 12, 30, 10, 0, 0, 0, time.UTC), got)
+
+	got, err = uc.ShiftToWorkingDay(t.Context(), newYear, domain.ShiftNone)
+	require.NoError(t, err)
+	require.Equal(t, newYear, got)
+
+	_, err = uc.ShiftToWorkingDay(t.Context(), newYear, "sideways")
+	require.ErrorIs(t, err, domain.ErrShiftInvalid)
+}
+
+func TestEventUseCase_GetAgenda_MarksHolidays(t *testing.T) {
+	repo := repoMocks.NewMockEventRepository(t)
+	uc := NewEventUseCase(repo, WithHolidays(holiday.RU()))
+	repo.EXPECT().GetByUserAndRange(mock.Anything, 1, mock.Anything, mock.Anything).Return(nil, nil).Once()
+
+	agenda, err := uc.GetAgenda(t.Context(), 1, "2026-06-11", domain.PeriodDay, domain.PeriodOptions{})
+	require.NoError(t, err)
+	require.Equal(t, domain.DayShort, agenda.Days[0].Kind)
+
+	repo.EXPECT().GetByUserAndRange(mock.Anything, 1, mock.Anything, mock.Anything).Return(nil, nil).Once()
+	agenda, err = uc.GetAgenda(t.Context(), 1, "2026-06-12", domain.PeriodDay, domain.PeriodOptions{})
+	require.NoError(t, err)
+	require.Equal(t, domain.DayHoliday, agenda.Days[0].Kind)
+	require.Equal(t, "День России", agenda.Days[0].Holiday)
+}
@@ -0,0 +1,286 @@
+package usecase
+
+import (
+	"calendar/internal/domain"
+	"calendar/internal/reqlog"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxImportRows — сколько строк принимает один импорт.
+const maxImportRows = 50000
+
+// importRow — строка импорта до проверки; в JSON те же поля, что и в теле /create_event.
+type importRow struct {
+	Date  string `json:"date"`
+	Title string `json:"event"`
+	domain.EventAttrs
+}
+
+// csvFields — поля события, которые можно взять из столбцов CSV.
+var csvFields = []string{"date", "title", "tags", "category", "priority", "duration", "attendees", "uid", "shift"}
+
+// parsedRow — прочитанная строка импорта; err — ошибка разбора именно этой строки.
+type parsedRow struct {
+	importRow
+	err error
+}
+
+// ImportEvents создаёт у пользователя события из CSV или JSON-массива. Каждая строка
+// проверяется так же, как в CreateEvent, включая квоты; ошибка строки попадает в отчёт
+// и не прерывает импорт. Файл сначала читается целиком: если его нельзя прочитать
+// (нет нужных столбцов, сломан CSV или JSON, слишком много строк), импорт заканчивается
+// ошибкой, и ни одно событие не создаётся. После этого отчёт возвращается всегда —
+// даже если запрос отменили на середине, в нём есть ID уже созданных событий.
+func (uc *EventUseCase) ImportEvents(ctx context.Context, userID int, r io.Reader, opts domain.ImportOptions) (domain.ImportReport, error) {
+	rows, err := readImport(r, opts)
+	if err != nil {
+		return domain.ImportReport{}, err
+	}
+
+	report := domain.ImportReport{DryRun: opts.DryRun, Rows: make([]domain.ImportResult, 0, len(rows))}
+	var pending quotaPending
+	for i, row := range rows {
+		res := domain.ImportResult{Row: i + 1}
+		err := row.err
+		if err == nil {
+			err = ctx.Err()
+		}
+		if err == nil {
+			res.Date, res.ID, err = uc.importRow(ctx, userID, row.importRow, opts.DryRun, &pending)
+		}
+		if err != nil {
+			e, msg := importError(ctx, res.Row, err)
+			res.Code, res.Error = e.Code, msg
+			report.Failed++
+		} else {
+			res.OK = true
+			report.Succeeded++
+		}
+		report.Total++
+		report.Rows = append(report.Rows, res)
+	}
+
+	reqlog.Printf(ctx, "[IMPORT] user %d: %d rows, %d ok, %d failed (dry run: %t)", userID, report.Total, report.Succeeded, report.Failed, opts.DryRun)
+	return report, nil
+}
+
+// readImport читает весь файл импорта; ошибка — файл нельзя импортировать целиком.
+func readImport(r io.Reader, opts domain.ImportOptions) ([]parsedRow, error) {
+	var rows []parsedRow
+	add := func(row importRow, rowErr error) error {
+		if len(rows) >= maxImportRows {
+			return fmt.Errorf("%w: more than %d rows", domain.ErrImportInvalid, maxImportRows)
+		}
+		rows = append(rows, parsedRow{row, rowErr})
+		return nil
+	}
+
+	var err error
+	switch opts.Format {
+	case domain.ImportCSV:
+		err = readCSV(r, opts, add)
+	case domain.ImportJSON:
+		err = readJSONRows(r, add)
+	default:
+		err = fmt.Errorf("%w: unknown format %q, want %q or %q", domain.ErrImportInvalid, opts.Format, domain.ImportCSV, domain.ImportJSON)
+	}
+	return rows, err
+}
+
+// importError сопоставляет ошибке строки запись каталога и текст для отчёта. Отмена
+// запроса и таймаут получают свои коды; текст ошибок вне каталога наружу не уходит.
+func importError(ctx context.Context, row int, err error) (*domain.Error, string) {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return domain.ErrTimeout, domain.ErrTimeout.Message
+	case errors.Is(err, context.Canceled):
+		return domain.ErrCanceled, domain.ErrCanceled.Message
+	}
+	if e, ok := domain.AsError(err); ok {
+		return e, err.Error()
+	}
+	reqlog.Printf(ctx, "[ERROR] import row %d: %v", row, err)
+	return domain.ErrInternal, domain.ErrInternal.Message
+}
+
+// importRow проверяет строку и, если это не пробный импорт, создаёт событие.
+// Пробный импорт копит принятые строки в pending, чтобы квоты учитывали и их.
+func (uc *EventUseCase) importRow(ctx context.Context, userID int, row importRow, dryRun bool, pending *quotaPending) (time.Time, string, error) {
+	event, err := uc.newEvent(ctx, userID, row.Date, row.Title, row.EventAttrs)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	if dryRun {
+		if err := uc.checkNewEvent(ctx, userID, event.Date, *pending); err != nil {
+			return time.Time{}, "", err
+		}
+		*pending = pending.add(event.Date)
+		return event.Date, "", nil
+	}
+	id, err := uc.createEvent(ctx, event, quotaPending{})
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	return event.Date, id, nil
+}
+
+func readCSV(r io.Reader, opts domain.ImportOptions, add func(importRow, error) error) error {
+	cr := csv.NewReader(r)
+	if opts.Delimiter != 0 {
+		cr.Comma = opts.Delimiter
+	}
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: empty file", domain.ErrImportInvalid)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrImportInvalid, err)
+	}
+	cols, err := csvColumns(header, opts.Columns)
+	if err != nil {
+		return err
+	}
+
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %v", domain.ErrImportInvalid, err)
+		}
+		row, rowErr := csvRow(record, cols)
+		if err := add(row, rowErr); err != nil {
+			return err
+		}
+	}
+}
+
+// csvColumns находит номер столбца для каждого поля события. Заголовки сравниваются
+// без учёта регистра и пробелов по краям.
+func csvColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	index := make(map[string]int, len(header))
+	for i, h := range header {
+		if i == 0 {
+			h = strings.TrimPrefix(h, "\ufeff") // BOM, который оставляет Excel
+		}
+		index[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+
+	for field := range mapping {
+		if !slices.Contains(csvFields, field) {
+			return nil, fmt.Errorf("%w: unknown field %q in column mapping", domain.ErrImportInvalid, field)
+		}
+	}
+
+	cols := make(map[string]int)
+	for _, field := range csvFields {
+		name, mapped := mapping[field]
+		if !mapped {
+			name = field
+		}
+		i, ok := index[strings.ToLower(strings.TrimSpace(name))]
+		switch {
+		case ok:
+			cols[field] = i
+		case mapped:
+			return nil, fmt.Errorf("%w: column %q for %s not found", domain.ErrImportInvalid, name, field)
+		case field == "date" || field == "title":
+			return nil, fmt.Errorf("%w: no column for %s", domain.ErrImportInvalid, field)
+		}
+	}
+	return cols, nil
+}
+
+func csvRow(record []string, cols map[string]int) (importRow, error) {
+	get := func(field string) string {
+		if i, ok := cols[field]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	number := func(field string) (int, error) {
+		v := get(field)
+		if v == "" {
+			return 0, nil
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s %q is not a number", domain.ErrAttrsInvalid, field, v)
+		}
+		return n, nil
+	}
+
+	row := importRow{Date: get("date"), Title: get("title")}
+	row.Tags = splitList(get("tags"))
+	row.Category = get("category")
+	row.UID = get("uid")
+	row.Shift = get("shift")
+
+	var err error
+	if row.Priority, err = number("priority"); err != nil {
+		return row, err
+	}
+	if row.Duration, err = number("duration"); err != nil {
+		return row, err
+	}
+	for _, v := range splitList(get("attendees")) {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			return row, fmt.Errorf("%w: attendee %q is not a user ID", domain.ErrAttrsInvalid, v)
+		}
+		row.Attendees = append(row.Attendees, domain.Attendee{UserID: id})
+	}
+	return row, nil
+}
+
+// splitList делит ячейку со списком: элементы разделены запятыми или точками с запятой.
+func splitList(s string) []string {
+	var res []string
+	for _, v := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ';' }) {
+		if v = strings.TrimSpace(v); v != "" {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// readJSONRows читает JSON-массив строк по одной. Строка с полем не того типа
+// попадает в отчёт как ошибочная, синтаксическая ошибка прерывает импорт.
+func readJSONRows(r io.Reader, add func(importRow, error) error) error {
+	dec := json.NewDecoder(r)
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+		return fmt.Errorf("%w: expected a JSON array", domain.ErrImportInvalid)
+	}
+	for dec.More() {
+		var row importRow
+		err := dec.Decode(&row)
+		var typeErr *json.UnmarshalTypeError
+		if err != nil && !errors.As(err, &typeErr) {
+			return fmt.Errorf("%w: %v", domain.ErrImportInvalid, err)
+		}
+		var rowErr error
+		if err != nil {
+			rowErr = fmt.Errorf("%w: %v", domain.ErrImportInvalid, err)
+		}
+		if err := add(row, rowErr); err != nil {
+			return err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrImportInvalid, err)
+	}
+	return nil
+}
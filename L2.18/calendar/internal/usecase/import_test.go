@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"calendar/internal/domain"
+	"calendar/internal/holiday"
+	"calendar/internal/repository"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventUseCase_ImportCSV(t *testing.T) {
+	ctx := t.Context()
+	uc := NewEventUseCase(repository.NewLocalStorage(), WithQuota(domain.Quota{MaxTitleLength: 20}))
+
+	// Выгрузка из таблицы: свои заголовки, точка с запятой и BOM от Excel.
+	file := "\ufeffStart;Subject;Labels;Prio;Guests\n" +
+		"2026-10-20 10:00;Planning;Work, Q4;2;2;3\n" +
+		"someday;Broken date;;;\n" +
+		"2026-10-21;Negative;;-1;\n" +
+		"2026-10-22;A title that is far too long;;;\n" +
+		"2026-10-23;Bad guest;;;bob\n" +
+		"2026-10-24;Short row\n"
+
+	report, err := uc.ImportEvents(ctx, 1, strings.NewReader(file), domain.ImportOptions{
+		Format:    domain.ImportCSV,
+		Columns:   map[string]string{"date": "start", "title": "Subject", "tags": "Labels", "priority": "Prio", "attendees": "Guests"},
+		Delimiter: ';',
+	})
+	require.NoError(t, err)
+	require.Equal(t, 6, report.Total)
+	require.Equal(t, 2, report.Succeeded)
+	require.Equal(t, 4, report.Failed)
+
+	codes := make([]string, 0, len(report.Rows))
+	for i, row := range report.Rows {
+		require.Equal(t, i+1, row.Row)
+		codes = append(codes, row.Code)
+	}
+	require.Equal(t, []string{"", "date_invalid", "attrs_invalid", "title_too_long", "attrs_invalid", ""}, codes)
+	require.True(t, report.Rows[0].OK)
+	require.NotEmpty(t, report.Rows[0].ID)
+	require.Equal(t, time.Date(2026, 10, 20, 10, 0, 0, 0, time.UTC), report.Rows[0].Date)
+	require.Contains(t, report.Rows[4].Error, `"bob"`)
+
+	event, err := uc.repo.GetByID(ctx, report.Rows[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, "Planning", event.Title)
+	require.Equal(t, []string{"work", "q4"}, event.Tags)
+	require.Equal(t, 2, event.Priority)
+	// Лишний столбец в строке не мешает, участник берётся из своего столбца.
+	require.Equal(t, []domain.Attendee{{UserID: 2, Status: domain.StatusNeedsAction}}, event.Attendees)
+}
+
+func TestEventUseCase_ImportDryRun(t *testing.T) {
+	ctx := t.Context()
+	repo := repository.NewLocalStorage()
+	uc := NewEventUseCase(repo, WithQuota(domain.Quota{MaxEventsPerDay: 2}))
+
+	file := "date,title\n2026-10-20,One\n2026-10-20,Two\n2026-10-20,Three\n2026-10-21,Four\n"
+	report, err := uc.ImportEvents(ctx, 1, strings.NewReader(file), domain.ImportOptions{Format: domain.ImportCSV, DryRun: true})
+	require.NoError(t, err)
+	require.True(t, report.DryRun)
+	require.Equal(t, 3, report.Succeeded)
+	// Пробный импорт учитывает в квоте принятые до этого строки.
+	require.Equal(t, "daily_event_quota_exceeded", report.Rows[2].Code)
+	for _, row := range report.Rows {
+		require.Empty(t, row.ID)
+	}
+
+	n, err := repo.CountByUser(ctx, 1)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	// Настоящий импорт того же файла даёт тот же результат.
+	real, err := uc.ImportEvents(ctx, 1, strings.NewReader(file), domain.ImportOptions{Format: domain.ImportCSV})
+	require.NoError(t, err)
+	require.Equal(t, report.Succeeded, real.Succeeded)
+	require.Equal(t, "daily_event_quota_exceeded", real.Rows[2].Code)
+}
+
+func TestEventUseCase_ImportCSV_Shift(t *testing.T) {
+	uc := NewEventUseCase(repository.NewLocalStorage(), WithHolidays(holiday.RU()))
+
+	file := "date,title,shift\n2026-05-09,Parade rehearsal,previous\n2026-05-09,Parade,\n2026-05-09,Oops,sideways\n"
+	report, err := uc.ImportEvents(t.Context(), 1, strings.NewReader(file), domain.ImportOptions{Format: domain.ImportCSV})
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2026, 5, 8, 0, 0, 0, 0, time.UTC), report.Rows[0].Date)
+	require.Equal(t, time.Date(2026, 5, 9, 0, 0, 0, 0, time.UTC), report.Rows[1].Date)
+	require.Equal(t, "shift_invalid", report.Rows[2].Code)
+}
+
+func TestEventUseCase_ImportJSON(t *testing.T) {
+	uc := NewEventUseCase(repository.NewLocalStorage())
+
+	body := `[
+		{"date": "2026-10-20", "event": "One", "tags": ["a"]},
+		{"date": "2026-10-21", "event": "Two", "priority": "high"},
+		{"date": "2026-10-22", "event": "Three", "duration": -5},
+		{"date": "2026-10-23", "event": "Four"}
+	]`
+	report, err := uc.ImportEvents(t.Context(), 1, strings.NewReader(body), domain.ImportOptions{Format: domain.ImportJSON})
+	require.NoError(t, err)
+	require.Equal(t, 4, report.Total)
+	require.Equal(t, 2, report.Succeeded)
+	require.Equal(t, "import_invalid", report.Rows[1].Code)
+	require.Equal(t, "attrs_invalid", report.Rows[2].Code)
+	require.True(t, report.Rows[3].OK)
+}
+
+func TestEventUseCase_Import_InvalidFile(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		opts domain.ImportOptions
+	}{
+		{name: "unknown format", body: "[]", opts: domain.ImportOptions{Format: "xlsx"}},
+		{name: "empty csv", body: "", opts: domain.ImportOptions{Format: domain.ImportCSV}},
+		{name: "no title column", body: "date,name\n2026-10-20,x\n", opts: domain.ImportOptions{Format: domain.ImportCSV}},
+		{name: "mapped column missing", body: "date,title\n", opts: domain.ImportOptions{Format: domain.ImportCSV, Columns: map[string]string{"tags": "labels"}}},
+		{name: "unknown field", body: "date,title\n", opts: domain.ImportOptions{Format: domain.ImportCSV, Columns: map[string]string{"colour": "title"}}},
+		{name: "broken quotes", body: "date,title\n2026-10-20,\"x\"y\n", opts: domain.ImportOptions{Format: domain.ImportCSV}},
+		{name: "not an array", body: `{"date": "2026-10-20"}`, opts: domain.ImportOptions{Format: domain.ImportJSON}},
+		{name: "broken json", body: `[{"date": "2026-10-20", "event": "x"}, {"date":`, opts: domain.ImportOptions{Format: domain.ImportJSON}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewEventUseCase(repository.NewLocalStorage())
+			_, err := uc.ImportEvents(t.Context(), 1, strings.NewReader(tt.body), tt.opts)
+			require.ErrorIs(t, err, domain.ErrImportInvalid)
+		})
+	}
+}
+
+func TestEventUseCase_Import_NothingCreatedOnBrokenFile(t *testing.T) {
+	valid := "date,title\n" + strings.Repeat("2026-10-20,Valid\n", 3)
+	tests := []struct {
+		name string
+		body string
+		opts domain.ImportOptions
+	}{
+		{name: "csv breaks after valid rows", body: valid + "2026-10-21,\"x\"y\n", opts: domain.ImportOptions{Format: domain.ImportCSV}},
+		{name: "json breaks after valid rows", body: `[{"date": "2026-10-20", "event": "x"}, {"date": "2026-10-21", "event": "y"}, {"date":`, opts: domain.ImportOptions{Format: domain.ImportJSON}},
+		{name: "too many rows", body: "date,title\n" + strings.Repeat("2026-10-20,x\n", maxImportRows+1), opts: domain.ImportOptions{Format: domain.ImportCSV}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repository.NewLocalStorage()
+			uc := NewEventUseCase(repo)
+			_, err := uc.ImportEvents(t.Context(), 1, strings.NewReader(tt.body), tt.opts)
+			require.ErrorIs(t, err, domain.ErrImportInvalid)
+
+			n, err := repo.CountByUser(t.Context(), 1)
+			require.NoError(t, err)
+			require.Zero(t, n, "events created before the file turned out to be broken")
+		})
+	}
+}
+
+// cancelingRepo отменяет контекст запроса после n созданных событий.
+type cancelingRepo struct {
+	repository.EventRepository
+	n      int
+	cancel context.CancelFunc
+}
+
+func (r *cancelingRepo) Create(ctx context.Context, e domain.Event) (string, error) {
+	id, err := r.EventRepository.Create(ctx, e)
+	if r.n--; r.n == 0 {
+		r.cancel()
+	}
+	return id, err
+}
+
+func TestEventUseCase_Import_CanceledKeepsReport(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	uc := NewEventUseCase(&cancelingRepo{EventRepository: repository.NewLocalStorage(), n: 2, cancel: cancel})
+
+	file := "date,title\n" + strings.Repeat("2026-10-20,Row\n", 4)
+	report, err := uc.ImportEvents(ctx, 1, strings.NewReader(file), domain.ImportOptions{Format: domain.ImportCSV})
+	require.NoError(t, err)
+	require.Equal(t, 4, report.Total)
+	require.Equal(t, 2, report.Succeeded)
+	require.NotEmpty(t, report.Rows[0].ID)
+	require.NotEmpty(t, report.Rows[1].ID)
+	require.Equal(t, "canceled", report.Rows[2].Code)
+	require.Equal(t, "canceled", report.Rows[3].Code)
+}
@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"calendar/internal/domain"
+	"calendar/internal/reqlog"
+	"context"
+)
+
+// Notifier доставляет пользователю уведомление об изменении встречи.
+type Notifier interface {
+	Notify(ctx context.Context, userID int, n domain.Notification) error
+}
+
+// WithNotifier задаёт способ доставки уведомлений (по умолчанию — запись в лог).
+func WithNotifier(n Notifier) Option {
+	return func(uc *EventUseCase) {
+		uc.notifier = n
+	}
+}
+
+type logNotifier struct{}
+
+func (logNotifier) Notify(ctx context.Context, userID int, n domain.Notification) error {
+	reqlog.Printf(ctx, "[NOTIFY] user %d: event %s %s by user %d", userID, n.Event.ID, n.Kind, n.From)
+	return nil
+}
+
+// notify рассылает уведомление; ошибка доставки не отменяет уже сделанное изменение.
+func (uc *EventUseCase) notify(ctx context.Context, kind string, e domain.Event, from int, users ...int) {
+	for _, userID := range users {
+		if err := uc.notifier.Notify(ctx, userID, domain.Notification{Kind: kind, Event: e, From: from}); err != nil {
+			reqlog.Printf(ctx, "[ERROR] notifying user %d about event %s: %v", userID, e.ID, err)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"calendar/internal/domain"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// period — разобранные и провалидированные PeriodOptions.
+type period struct {
+	align     string
+	weekStart time.Weekday
+	loc       *time.Location
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday, "iso": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+// mergeOptions дополняет опции запроса настройками пользователя: явно заданное в запросе важнее.
+func mergeOptions(req, user domain.PeriodOptions) domain.PeriodOptions {
+	if req.Align == "" {
+		req.Align = user.Align
+	}
+	if req.WeekStart == "" {
+		req.WeekStart = user.WeekStart
+	}
+	if req.TimeZone == "" {
+		req.TimeZone = user.TimeZone
+	}
+	return req
+}
+
+func parsePeriod(opts domain.PeriodOptions) (period, error) {
+	p := period{align: domain.AlignRolling, weekStart: time.Monday, loc: time.UTC}
+
+	switch a := strings.ToLower(opts.Align); a {
+	case "":
+	case domain.AlignRolling, domain.AlignCalendar:
+		p.align = a
+	default:
+		return p, fmt.Errorf("%w: unknown align %q", domain.ErrPeriodInvalid, opts.Align)
+	}
+
+	if opts.WeekStart != "" {
+		wd, ok := weekdays[strings.ToLower(opts.WeekStart)]
+		if !ok {
+			return p, fmt.Errorf("%w: unknown week start %q", domain.ErrPeriodInvalid, opts.WeekStart)
+		}
+		p.weekStart = wd
+	}
+
+	if opts.TimeZone != "" {
+		loc, err := time.LoadLocation(opts.TimeZone)
+		if err != nil {
+			return p, fmt.Errorf("%w: unknown time zone %q", domain.ErrPeriodInvalid, opts.TimeZone)
+		}
+		p.loc = loc
+	}
+	return p, nil
+}
+
+// Все границы строятся через time.Date в зоне пользователя, а не прибавлением 24h,
+// поэтому сутки с переходом на летнее/зимнее время имеют длину 23h/25h.
+
+func (p period) day(d time.Time) (time.Time, time.Time) {
+	y, m, dd := d.Date()
+	return time.Date(y, m, dd, 0, 0, 0, 0, p.loc), time.Date(y, m, dd+1, 0, 0, 0, 0, p.loc)
+}
+
+func (p period) week(d time.Time) (time.Time, time.Time) {
+	y, m, dd := d.Date()
+	if p.align == domain.AlignCalendar {
+		dd -= (int(d.Weekday()) - int(p.weekStart) + 7) % 7
+	}
+	return time.Date(y, m, dd, 0, 0, 0, 0, p.loc), time.Date(y, m, dd+7, 0, 0, 0, 0, p.loc)
+}
+
+func (p period) month(d time.Time) (time.Time, time.Time) {
+	y, m, dd := d.Date()
+	if p.align == domain.AlignCalendar {
+		return time.Date(y, m, 1, 0, 0, 0, 0, p.loc), time.Date(y, m+1, 1, 0, 0, 0, 0, p.loc)
+	}
+	return time.Date(y, m, dd, 0, 0, 0, 0, p.loc), time.Date(y, m+1, dd, 0, 0, 0, 0, p.loc)
+}
+
+// bounds возвращает границы периода вида kind (день, неделя, месяц), содержащего d.
+func (p period) bounds(kind string, d time.Time) (time.Time, time.Time, error) {
+	switch kind {
+	case domain.PeriodDay:
+		from, to := p.day(d)
+		return from, to, nil
+	case domain.PeriodWeek:
+		from, to := p.week(d)
+		return from, to, nil
+	case domain.PeriodMonth:
+		from, to := p.month(d)
+		return from, to, nil
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("%w: unknown period %q", domain.ErrPeriodInvalid, kind)
+}
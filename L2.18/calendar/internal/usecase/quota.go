@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"calendar/internal/domain"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+	"unicode/utf8"
+)
+
+// DefaultQuota — ограничения, с которыми запускается сервер. Их хватает живому человеку
+// с запасом, но не даёт сломанной интеграции создать сотни тысяч событий.
+var DefaultQuota = domain.Quota{
+	MaxEvents:       10000,
+	MaxEventsPerDay: 200,
+	MaxTitleLength:  500,
+}
+
+// WithQuota включает ограничения на пользователя (по умолчанию их нет).
+func WithQuota(q domain.Quota) Option {
+	return func(uc *EventUseCase) {
+		uc.quota = q
+	}
+}
+
+// lockUser сериализует проверку квоты и запись для одного пользователя, иначе параллельные
+// запросы прошли бы проверку вместе и превысили лимит.
+func (uc *EventUseCase) lockUser(userID int) func() {
+	mu := &uc.userLocks[uint(userID)%uint(len(uc.userLocks))]
+	mu.Lock()
+	return mu.Unlock
+}
+
+func (uc *EventUseCase) checkTitle(title string) error {
+	if uc.quota.MaxTitleLength > 0 {
+		if n := utf8.RuneCountInString(title); n > uc.quota.MaxTitleLength {
+			return fmt.Errorf("%w: %d characters, limit is %d", domain.ErrTitleTooLong, n, uc.quota.MaxTitleLength)
+		}
+	}
+	return nil
+}
+
+// quotaPending — события пользователя, которые ещё не записаны, но должны учитываться
+// в квоте: так пробный импорт видит строки, принятые до текущей.
+type quotaPending struct {
+	total int
+	byDay map[string]int // день (2006-01-02) -> число событий
+}
+
+func (p quotaPending) add(date time.Time) quotaPending {
+	if p.byDay == nil {
+		p.byDay = make(map[string]int)
+	}
+	p.total++
+	p.byDay[date.Format(time.DateOnly)]++
+	return p
+}
+
+// checkNewEvent проверяет, что у пользователя есть место ещё на одно событие.
+func (uc *EventUseCase) checkNewEvent(ctx context.Context, userID int, date time.Time, pending quotaPending) error {
+	if uc.quota.MaxEvents > 0 {
+		n, err := uc.repo.CountByUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if n+pending.total >= uc.quota.MaxEvents {
+			return fmt.Errorf("%w: limit is %d", domain.ErrEventQuota, uc.quota.MaxEvents)
+		}
+	}
+	return uc.checkDay(ctx, userID, date, "", pending.byDay[date.Format(time.DateOnly)])
+}
+
+// checkDay проверяет, что на день date можно поставить ещё одно событие пользователя;
+// само событие exceptID (при переносе) не считается, а pending ещё не записанных — считаются.
+func (uc *EventUseCase) checkDay(ctx context.Context, userID int, date time.Time, exceptID string, pending int) error {
+	if uc.quota.MaxEventsPerDay <= 0 {
+		return nil
+	}
+	y, m, d := date.Date()
+	from := time.Date(y, m, d, 0, 0, 0, 0, date.Location())
+	events, err := uc.repo.GetByUserAndRange(ctx, userID, from, from.AddDate(0, 0, 1))
+	if err != nil {
+		return err
+	}
+	n := pending
+	for _, e := range events {
+		if e.UserID == userID && e.ID != exceptID {
+			n++
+		}
+	}
+	if n >= uc.quota.MaxEventsPerDay {
+		return fmt.Errorf("%w: %s, limit is %d", domain.ErrDailyQuota, from.Format("2006-01-02"), uc.quota.MaxEventsPerDay)
+	}
+	return nil
+}
+
+// Usage возвращает квоты и число событий у каждого организатора.
+func (uc *EventUseCase) Usage(ctx context.Context) (domain.UsageReport, error) {
+	counts, err := uc.repo.CountPerUser(ctx)
+	if err != nil {
+		return domain.UsageReport{}, err
+	}
+	users := make([]domain.UserUsage, 0, len(counts))
+	for userID, n := range counts {
+		users = append(users, domain.UserUsage{UserID: userID, Events: n})
+	}
+	slices.SortFunc(users, func(a, b domain.UserUsage) int {
+		if c := cmp.Compare(b.Events, a.Events); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.UserID, b.UserID)
+	})
+	return domain.UsageReport{Quota: uc.quota, Users: users}, nil
+}
+
+// sameDay сообщает, приходятся ли a и b на один календарный день в часовом поясе b.
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.In(b.Location()).Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"calendar/internal/domain"
+	"calendar/internal/repository"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventUseCase_Quota(t *testing.T) {
+	ctx := t.Context()
+	quota := domain.Quota{MaxEvents: 3, MaxEventsPerDay: 2, MaxTitleLength: 10}
+	uc := NewEventUseCase(repository.NewLocalStorage(), WithQuota(quota))
+
+	_, err := uc.CreateEvent(ctx, 1, "2026-10-20", "Совещание!!", domain.EventAttrs{})
+	require.ErrorIs(t, err, domain.ErrTitleTooLong)
+
+	// Приглашения не занимают квоту приглашённого.
+	_, err = uc.CreateEvent(ctx, 2, "2026-10-20", "Invite", domain.EventAttrs{Attendees: []domain.Attendee{{UserID: 1}}})
+	require.NoError(t, err)
+
+	a, err := uc.CreateEvent(ctx, 1, "2026-10-20 10:00", "A", domain.EventAttrs{})
+	require.NoError(t, err)
+	_, err = uc.CreateEvent(ctx, 1, "2026-10-20 12:00", "B", domain.EventAttrs{})
+	require.NoError(t, err)
+	_, err = uc.CreateEvent(ctx, 1, "2026-10-20 15:00", "C", domain.EventAttrs{})
+	require.ErrorIs(t, err, domain.ErrDailyQuota)
+
+	c, err := uc.CreateEvent(ctx, 1, "2026-10-21", "C", domain.EventAttrs{})
+	require.NoError(t, err)
+	_, err = uc.CreateEvent(ctx, 1, "2026-10-22", "D", domain.EventAttrs{})
+	require.ErrorIs(t, err, domain.ErrEventQuota)
+
+	// Перенос на заполненный день запрещён, изменение в пределах дня — нет.
+	require.ErrorIs(t, uc.UpdateEvent(ctx, c, 1, "2026-10-20", "C", domain.EventAttrs{}), domain.ErrDailyQuota)
+	require.NoError(t, uc.UpdateEvent(ctx, a, 1, "2026-10-20 11:00", "A2", domain.EventAttrs{}))
+	require.ErrorIs(t, uc.UpdateEvent(ctx, a, 1, "2026-10-20 11:00", "Слишком длинно", domain.EventAttrs{}), domain.ErrTitleTooLong)
+
+	// Удалённое событие освобождает место, а восстановление снова его занимает.
+	require.NoError(t, uc.DeleteEvent(ctx, c, 1))
+	d, err := uc.CreateEvent(ctx, 1, "2026-10-22", "D", domain.EventAttrs{})
+	require.NoError(t, err)
+	_, err = uc.RestoreEvent(ctx, c, 1)
+	require.ErrorIs(t, err, domain.ErrEventQuota)
+	require.NoError(t, uc.DeleteEvent(ctx, d, 1))
+	_, err = uc.RestoreEvent(ctx, c, 1)
+	require.NoError(t, err)
+
+	report, err := uc.Usage(ctx)
+	require.NoError(t, err)
+	require.Equal(t, domain.UsageReport{Quota: quota, Users: []domain.UserUsage{
+		{UserID: 1, Events: 3},
+		{UserID: 2, Events: 1},
+	}}, report)
+}
+
+func TestEventUseCase_Quota_Concurrent(t *testing.T) {
+	uc := NewEventUseCase(repository.NewShardedStorage(4), WithQuota(domain.Quota{MaxEvents: 5}))
+
+	var created atomic.Int32
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Go(func() {
+			if _, err := uc.CreateEvent(t.Context(), 1, "2026-10-20", "Spam", domain.EventAttrs{}); err == nil {
+				created.Add(1)
+			}
+		})
+	}
+	wg.Wait()
+	require.EqualValues(t, 5, created.Load())
+}
@@ -0,0 +1,287 @@
+package usecase
+
+import (
+	"calendar/internal/domain"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	maxSlotUsers      = 100
+	maxSlotWindowDays = 92
+	defaultWorkStart  = "09:00"
+	defaultWorkEnd    = "18:00"
+	defaultSlotStep   = 30
+	defaultSlotLimit  = 10
+
+	// slotBufferCap — сколько минут запаса до и после слота ещё добавляют к его оценке.
+	slotBufferCap = 60
+
+	minutesPerDay = 24 * 60
+)
+
+// interval — занятый отрезок времени [start, end).
+type interval struct {
+	start, end time.Time
+}
+
+// slotQuery — разобранный и провалидированный domain.SlotQuery.
+type slotQuery struct {
+	users              []int
+	duration, step     time.Duration
+	from, to           time.Time // to — полночь после последнего дня окна
+	workStart, workEnd int       // минуты от полуночи
+	workdays           map[time.Weekday]bool
+	limit              int
+}
+
+// FindFreeSlots ищет интервалы длиной q.Duration в рабочее время, когда свободны все
+// пользователи, и возвращает лучшие из них. Отклонённые приглашения время не занимают.
+func (uc *EventUseCase) FindFreeSlots(ctx context.Context, q domain.SlotQuery) ([]domain.Slot, error) {
+	sq, err := uc.parseSlotQuery(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	busy, err := uc.busyIntervals(ctx, sq.users, sq.from, sq.to)
+	if err != nil {
+		return nil, err
+	}
+
+	return freeSlots(sq, busy), nil
+}
+
+func (uc *EventUseCase) parseSlotQuery(ctx context.Context, q domain.SlotQuery) (slotQuery, error) {
+	invalid := func(format string, args ...interface{}) (slotQuery, error) {
+		return slotQuery{}, fmt.Errorf("%w: "+format, append([]interface{}{domain.ErrSlotQueryInvalid}, args...)...)
+	}
+
+	sq := slotQuery{limit: q.Limit}
+
+	seen := make(map[int]bool, len(q.UserIDs))
+	for _, id := range q.UserIDs {
+		if !seen[id] {
+			seen[id] = true
+			sq.users = append(sq.users, id)
+		}
+	}
+	switch {
+	case len(sq.users) == 0:
+		return invalid("user_ids must not be empty")
+	case len(sq.users) > maxSlotUsers:
+		return invalid("at most %d users are supported", maxSlotUsers)
+	case q.Duration <= 0:
+		return invalid("duration must be positive")
+	case q.Step < 0 || q.Limit < 0:
+		return invalid("step and limit must not be negative")
+	case q.Duration > minutesPerDay || q.Step > minutesPerDay:
+		// Проверяем до умножения на time.Minute: огромные значения переполнили бы Duration.
+		return invalid("duration and step must not exceed %d minutes", minutesPerDay)
+	}
+	sq.duration = time.Duration(q.Duration) * time.Minute
+	sq.step = time.Duration(q.Step) * time.Minute
+	if sq.step == 0 {
+		sq.step = defaultSlotStep * time.Minute
+	}
+	if sq.limit == 0 {
+		sq.limit = defaultSlotLimit
+	}
+
+	p, err := uc.userPeriod(ctx, sq.users[0], domain.PeriodOptions{TimeZone: q.TimeZone})
+	if err != nil {
+		return sq, err
+	}
+	now := uc.now()
+	if sq.from, err = parseDate(q.From, now, p.loc); err != nil {
+		return sq, err
+	}
+	last, err := parseDate(q.To, now, p.loc)
+	if err != nil {
+		return sq, err
+	}
+	_, sq.to = p.day(last)
+	if !sq.from.Before(sq.to) {
+		return invalid("window ends before it starts")
+	}
+	if sq.to.Sub(sq.from) > maxSlotWindowDays*24*time.Hour {
+		return invalid("window must not exceed %d days", maxSlotWindowDays)
+	}
+
+	start, end := orDefault(q.Start, defaultWorkStart), orDefault(q.End, defaultWorkEnd)
+	if sq.workStart, err = parseClock(start); err != nil {
+		return invalid("bad work_start %q", start)
+	}
+	if sq.workEnd, err = parseClock(end); err != nil {
+		return invalid("bad work_end %q", end)
+	}
+	if time.Duration(sq.workEnd-sq.workStart)*time.Minute < sq.duration {
+		return invalid("meeting does not fit into working hours")
+	}
+
+	sq.workdays = make(map[time.Weekday]bool)
+	for _, d := range q.Workdays {
+		wd, ok := weekdays[strings.ToLower(d)]
+		if !ok {
+			return invalid("unknown weekday %q", d)
+		}
+		sq.workdays[wd] = true
+	}
+	if len(sq.workdays) == 0 {
+		for wd := time.Monday; wd <= time.Friday; wd++ {
+			sq.workdays[wd] = true
+		}
+	}
+	return sq, nil
+}
+
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
+
+// parseClock разбирает "HH:MM" (допускается "24:00") в минуты от полуночи.
+func parseClock(s string) (int, error) {
+	var h, m int
+	if _, err := fmt.Sscanf(s, "%d:%d", &h, &m); err != nil {
+		return 0, err
+	}
+	if h < 0 || m < 0 || m > 59 || h*60+m > 24*60 {
+		return 0, fmt.Errorf("clock %q out of range", s)
+	}
+	return h*60 + m, nil
+}
+
+// busyIntervals собирает занятость всех пользователей в один отсортированный список
+// непересекающихся интервалов. События берутся с запасом в domain.MaxDuration до окна,
+// чтобы учесть встречи, которые начались раньше и ещё идут.
+func (uc *EventUseCase) busyIntervals(ctx context.Context, users []int, from, to time.Time) ([]interval, error) {
+	var busy []interval
+	for _, userID := range users {
+		events, err := uc.repo.GetByUserAndRange(ctx, userID, from.Add(-domain.MaxDuration*time.Minute), to)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range events {
+			if declined(e, userID) {
+				continue
+			}
+			start, end := e.Span()
+			if end.After(from) && start.Before(to) {
+				busy = append(busy, interval{start, end})
+			}
+		}
+	}
+
+	return mergeIntervals(busy), nil
+}
+
+// mergeIntervals сортирует интервалы и склеивает пересекающиеся и смежные.
+// Результат использует память busy.
+func mergeIntervals(busy []interval) []interval {
+	sort.Slice(busy, func(i, j int) bool { return busy[i].start.Before(busy[j].start) })
+	merged := busy[:0]
+	for _, b := range busy {
+		if n := len(merged); n > 0 && !b.start.After(merged[n-1].end) {
+			if b.end.After(merged[n-1].end) {
+				merged[n-1].end = b.end
+			}
+			continue
+		}
+		merged = append(merged, b)
+	}
+	return merged
+}
+
+func declined(e domain.Event, userID int) bool {
+	for _, a := range e.Attendees {
+		if a.UserID == userID {
+			return a.Status == domain.StatusDeclined
+		}
+	}
+	return false
+}
+
+// freeSlots перебирает рабочие интервалы каждого дня окна, режет свободные промежутки
+// на слоты с шагом step и возвращает не больше limit лучших: по убыванию оценки,
+// при равной оценке — более ранние.
+func freeSlots(sq slotQuery, busy []interval) []domain.Slot {
+	var slots []domain.Slot
+	loc := sq.from.Location()
+	for day := sq.from; day.Before(sq.to); day = day.AddDate(0, 0, 1) {
+		if !sq.workdays[day.Weekday()] {
+			continue
+		}
+		y, m, d := day.Date()
+		ws := time.Date(y, m, d, 0, sq.workStart, 0, 0, loc)
+		we := time.Date(y, m, d, 0, sq.workEnd, 0, 0, loc)
+
+		// Первый занятый интервал, который заканчивается позже начала рабочего дня.
+		i := sort.Search(len(busy), func(i int) bool { return busy[i].end.After(ws) })
+
+		cursor, afterBusy := ws, false
+		for ; i < len(busy) && busy[i].start.Before(we); i++ {
+			if busy[i].start.After(cursor) {
+				slots = appendSlots(slots, sq, cursor, busy[i].start, afterBusy, true)
+			}
+			if busy[i].end.After(cursor) {
+				cursor, afterBusy = busy[i].end, true
+			}
+		}
+		if cursor.Before(we) {
+			slots = appendSlots(slots, sq, cursor, we, afterBusy, false)
+		}
+	}
+	return slots
+}
+
+// appendSlots добавляет слоты из свободного промежутка [gs, ge). busyBefore/busyAfter —
+// граничит ли промежуток со встречей (а не с краем рабочего дня): запас до встречи
+// учитывается в оценке, край рабочего дня оценку не снижает.
+func appendSlots(slots []domain.Slot, sq slotQuery, gs, ge time.Time, busyBefore, busyAfter bool) []domain.Slot {
+	for s := alignUp(gs, sq.step); !s.Add(sq.duration).After(ge); s = s.Add(sq.step) {
+		before, after := slotBufferCap, slotBufferCap
+		if busyBefore {
+			before = min(int(s.Sub(gs)/time.Minute), slotBufferCap)
+		}
+		if busyAfter {
+			after = min(int(ge.Sub(s.Add(sq.duration))/time.Minute), slotBufferCap)
+		}
+		slots = keepBest(slots, domain.Slot{Start: s, End: s.Add(sq.duration), Score: before + after}, sq.limit)
+	}
+	return slots
+}
+
+// keepBest вставляет slot в упорядоченный список лучших слотов, не давая ему вырасти
+// больше limit. Слоты приходят по возрастанию начала, поэтому при равной оценке
+// новый встаёт после уже найденных.
+func keepBest(best []domain.Slot, slot domain.Slot, limit int) []domain.Slot {
+	i := sort.Search(len(best), func(i int) bool { return best[i].Score < slot.Score })
+	if i >= limit {
+		return best
+	}
+	if len(best) < limit {
+		best = append(best, domain.Slot{})
+	}
+	copy(best[i+1:], best[i:])
+	best[i] = slot
+	return best
+}
+
+// alignUp округляет t вверх до ближайшей отметки step от полуночи (по настенным часам).
+func alignUp(t time.Time, step time.Duration) time.Time {
+	stepMin := int(step / time.Minute)
+	mins := t.Hour()*60 + t.Minute()
+	if rem := mins % stepMin; rem != 0 || t.Second() != 0 || t.Nanosecond() != 0 {
+		if rem == 0 {
+			rem = stepMin // есть секунды — текущая отметка уже прошла
+		}
+		mins += stepMin - rem
+	}
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, mins, 0, 0, t.Location())
+}
@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"calendar/internal/domain"
+	repoMocks "calendar/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func at(d, h, m int) time.Time {
+	return time.Date(2026, 10, d, h, m, 0, 0, time.UTC)
+}
+
+// lookback — откуда поиск свободного времени читает события окна, начинающегося в from.
+func lookback(from time.Time) time.Time {
+	return from.Add(-domain.MaxDuration * time.Minute)
+}
+
+func TestEventUseCase_FindFreeSlots(t *testing.T) {
+	repo := repoMocks.NewMockEventRepository(t)
+	uc := NewEventUseCase(repo)
+
+	// 2026-10-19 — понедельник.
+	from, to := lookback(at(19, 0, 0)), at(20, 0, 0)
+	repo.EXPECT().GetByUserAndRange(mock.Anything, 1, from, to).Return([]domain.Event{
+		{ID: "a", UserID: 1, Date: at(19, 9, 0), EventAttrs: domain.EventAttrs{Duration: 60}},
+	}, nil).Twice()
+	repo.EXPECT().GetByUserAndRange(mock.Anything, 2, from, to).Return([]domain.Event{
+		{ID: "b", UserID: 2, Date: at(19, 11, 0), EventAttrs: domain.EventAttrs{Duration: 90}},
+		// Отклонённое приглашение время не занимает.
+		{ID: "c", UserID: 3, Date: at(19, 13, 0), EventAttrs: domain.EventAttrs{
+			Duration:  240,
+			Attendees: []domain.Attendee{{UserID: 2, Status: domain.StatusDeclined}},
+		}},
+	}, nil).Twice()
+
+	slots, err := uc.FindFreeSlots(t.Context(), domain.SlotQuery{
+		UserIDs:  []int{1, 2, 1},
+		Duration: 60,
+		From:     "2026-10-19",
+		To:       "2026-10-19",
+		Limit:    100,
+	})
+	require.NoError(t, err)
+
+	// Свободно: 10:00–11:00 (между встречами) и 12:30–18:00.
+	require.Len(t, slots, 1+10)
+	require.Equal(t, domain.Slot{Start: at(19, 13, 30), End: at(19, 14, 30), Score: 120}, slots[0],
+		"the best slot has an hour of room after the previous meeting")
+	require.Equal(t, domain.Slot{Start: at(19, 10, 0), End: at(19, 11, 0), Score: 0}, slots[len(slots)-1],
+		"a slot squeezed between two meetings ranks last")
+
+	// Меньший limit даёт те же лучшие слоты, а не первые найденные.
+	best, err := uc.FindFreeSlots(t.Context(), domain.SlotQuery{UserIDs: []int{1, 2}, Duration: 60, From: "2026-10-19", To: "2026-10-19", Limit: 3})
+	require.NoError(t, err)
+	require.Equal(t, slots[:3], best)
+}
+
+func TestEventUseCase_FindFreeSlots_WorkingDays(t *testing.T) {
+	repo := repoMocks.NewMockEventRepository(t)
+	uc := NewEventUseCase(repo)
+
+	// Пт 16 — весь день занят, сб 17 и вс 18 — выходные, пн 19 — первый свободный день.
+	repo.EXPECT().GetByUserAndRange(mock.Anything, 1, lookback(at(16, 0, 0)), at(20, 0, 0)).Return([]domain.Event{
+		{ID: "holiday", UserID: 1, Date: at(16, 0, 0)},
+	}, nil).Once()
+
+	slots, err := uc.FindFreeSlots(t.Context(), domain.SlotQuery{
+		UserIDs:  []int{1},
+		Duration: 120,
+		From:     "2026-10-16",
+		To:       "2026-10-19",
+		Start:    "10:00",
+		End:      "12:00",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []domain.Slot{{Start: at(19, 10, 0), End: at(19, 12, 0), Score: 120}}, slots)
+}
+
+// Командировка началась за неделю до окна и ещё идёт — всё окно занято.
+func TestEventUseCase_FindFreeSlots_LongEventStartedEarlier(t *testing.T) {
+	repo := repoMocks.NewMockEventRepository(t)
+	uc := NewEventUseCase(repo)
+
+	repo.EXPECT().GetByUserAndRange(mock.Anything, 1, lookback(at(19, 0, 0)), at(20, 0, 0)).Return([]domain.Event{
+		{ID: "trip", UserID: 1, Date: at(12, 9, 0), EventAttrs: domain.EventAttrs{Duration: 10 * 24 * 60}},
+	}, nil).Once()
+
+	slots, err := uc.FindFreeSlots(t.Context(), domain.SlotQuery{UserIDs: []int{1}, Duration: 30, From: "2026-10-19", To: "2026-10-19"})
+	require.NoError(t, err)
+	require.Empty(t, slots)
+}
+
+func TestEventUseCase_FindFreeSlots_Invalid(t *testing.T) {
+	valid := domain.SlotQuery{UserIDs: []int{1}, Duration: 30, From: "2026-10-19", To: "2026-10-23"}
+
+	tests := []struct {
+		name   string
+		modify func(q *domain.SlotQuery)
+		want   error
+	}{
+		{name: "no users", modify: func(q *domain.SlotQuery) { q.UserIDs = nil }, want: domain.ErrSlotQueryInvalid},
+		{name: "no duration", modify: func(q *domain.SlotQuery) { q.Duration = 0 }, want: domain.ErrSlotQueryInvalid},
+		{name: "reversed window", modify: func(q *domain.SlotQuery) { q.From, q.To = q.To, q.From }, want: domain.ErrSlotQueryInvalid},
+		{name: "window too long", modify: func(q *domain.SlotQuery) { q.To = "2027-10-19" }, want: domain.ErrSlotQueryInvalid},
+		{name: "does not fit working day", modify: func(q *domain.SlotQuery) { q.Start, q.End = "09:00", "09:15" }, want: domain.ErrSlotQueryInvalid},
+		{name: "step overflows to zero", modify: func(q *domain.SlotQuery) { q.Step = 307445735 }, want: domain.ErrSlotQueryInvalid},
+		{name: "step overflows negative", modify: func(q *domain.SlotQuery) { q.Step = 153722868 }, want: domain.ErrSlotQueryInvalid},
+		{name: "step longer than a day", modify: func(q *domain.SlotQuery) { q.Step = 24*60 + 1 }, want: domain.ErrSlotQueryInvalid},
+		{name: "duration overflows negative", modify: func(q *domain.SlotQuery) { q.Duration = 153722868 }, want: domain.ErrSlotQueryInvalid},
+		{name: "bad clock", modify: func(q *domain.SlotQuery) { q.Start = "nine" }, want: domain.ErrSlotQueryInvalid},
+		{name: "bad weekday", modify: func(q *domain.SlotQuery) { q.Workdays = []string{"funday"} }, want: domain.ErrSlotQueryInvalid},
+		{name: "bad date", modify: func(q *domain.SlotQuery) { q.From = "someday" }, want: domain.ErrDateInvalid},
+		{name: "bad time zone", modify: func(q *domain.SlotQuery) { q.TimeZone = "Nowhere/Land" }, want: domain.ErrPeriodInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewEventUseCase(repoMocks.NewMockEventRepository(t))
+			q := valid
+			tt.modify(&q)
+
+			_, err := uc.FindFreeSlots(t.Context(), q)
+			require.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+// Несколько десятков пользователей на месяц: у каждого по четыре встречи в рабочий день.
+func TestEventUseCase_FindFreeSlots_Scale(t *testing.T) {
+	repo := repoMocks.NewMockEventRepository(t)
+	uc := NewEventUseCase(repo)
+
+	const users = 40
+	from, to := lookback(time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)), time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)
+	busy := map[int][]domain.Event{}
+	ids := make([]int, 0, users)
+	for u := 1; u <= users; u++ {
+		ids = append(ids, u)
+		for d := 1; d <= 30; d++ {
+			for k := 0; k < 4; k++ {
+				// Встречи смещены у разных пользователей, свободно только 17:00–18:00.
+				start := time.Date(2026, 11, d, 9+2*k, (u%4)*15, 0, 0, time.UTC)
+				busy[u] = append(busy[u], domain.Event{
+					ID: fmt.Sprintf("%d-%d-%d", u, d, k), UserID: u, Date: start,
+					EventAttrs: domain.EventAttrs{Duration: 75},
+				})
+			}
+		}
+		repo.EXPECT().GetByUserAndRange(mock.Anything, u, from, to).Return(busy[u], nil).Once()
+	}
+
+	started := time.Now()
+	slots, err := uc.FindFreeSlots(t.Context(), domain.SlotQuery{
+		UserIDs: ids, Duration: 60, From: "2026-11-01", To: "2026-11-30", Step: 15, Limit: 1000,
+	})
+	require.NoError(t, err)
+	require.Less(t, time.Since(started), time.Second)
+
+	require.Len(t, slots, 21, "one slot per working day of November 2026")
+	for _, s := range slots {
+		require.Equal(t, 17, s.Start.Hour())
+		require.Equal(t, 0, s.Start.Minute())
+		for _, events := range busy {
+			for _, e := range events {
+				start, end := e.Span()
+				require.False(t, s.Start.Before(end) && start.Before(s.End), "slot %v overlaps %s", s.Start, e.ID)
+			}
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"calendar/internal/domain"
+	"cmp"
+	"context"
+	"slices"
+	"time"
+)
+
+// busiestDays — сколько дней попадает в EventStats.BusiestDays.
+const busiestDays = 3
+
+// GetStats собирает статистику по событиям пользователя за день, неделю или месяц,
+// содержащие dateStr. Дни считаются в часовом поясе пользователя, событие относится
+// к дню своего начала. Отклонённые приглашения не считаются, как и в поиске слотов.
+func (uc *EventUseCase) GetStats(ctx context.Context, userID int, dateStr, kind string, opts domain.PeriodOptions) (domain.EventStats, error) {
+	p, t, err := uc.parseQuery(ctx, userID, dateStr, opts)
+	if err != nil {
+		return domain.EventStats{}, err
+	}
+	from, to, err := p.bounds(kind, t)
+	if err != nil {
+		return domain.EventStats{}, err
+	}
+
+	events, err := uc.repo.GetByUserAndRange(ctx, userID, from, to)
+	if err != nil {
+		return domain.EventStats{}, err
+	}
+
+	stats := domain.EventStats{UserID: userID, From: from, To: to, Events: len(events)}
+	dayIndex := map[time.Time]int{}
+	for day := from; day.Before(to); {
+		_, next := p.day(day)
+		dayIndex[day] = len(stats.Days)
+		stats.Days = append(stats.Days, domain.DayStats{Date: day})
+		day = next
+	}
+	for i := range 7 {
+		stats.Weekdays = append(stats.Weekdays, domain.WeekdayStats{Weekday: ((p.weekStart + time.Weekday(i)) % 7).String()})
+	}
+
+	tags := map[string]*domain.GroupStats{}
+	categories := map[string]*domain.GroupStats{}
+	var busy []interval
+	for _, e := range events {
+		if declined(e, userID) {
+			stats.Events--
+			continue
+		}
+		e.Date = e.Date.In(p.loc)
+		booked := 0
+		if start, end := e.Span(); isAllDay(e) {
+			stats.AllDay++
+		} else {
+			booked = int(end.Sub(start) / time.Minute)
+			if end.After(to) {
+				end = to
+			}
+			busy = append(busy, interval{start, end})
+		}
+		stats.BookedMinutes += booked
+
+		dayStart, _ := p.day(e.Date)
+		if i, ok := dayIndex[dayStart]; ok {
+			stats.Days[i].Events++
+			stats.Days[i].BookedMinutes += booked
+		}
+		wd := &stats.Weekdays[(int(e.Date.Weekday())-int(p.weekStart)+7)%7]
+		wd.Events++
+		wd.BookedMinutes += booked
+
+		for _, tag := range e.Tags {
+			addGroup(tags, tag, booked)
+		}
+		if e.Category != "" {
+			addGroup(categories, e.Category, booked)
+		}
+	}
+	for _, b := range mergeIntervals(busy) {
+		stats.BusyMinutes += int(b.end.Sub(b.start) / time.Minute)
+	}
+	stats.BusiestDays = busiest(stats.Days, busiestDays)
+	stats.Tags = sortedGroups(tags)
+	stats.Categories = sortedGroups(categories)
+	return stats, nil
+}
+
+// isAllDay — событие ровно в полночь без длительности, как в повестке и Event.Span.
+func isAllDay(e domain.Event) bool {
+	return e.Duration == 0 && e.Date.Hour() == 0 && e.Date.Minute() == 0 && e.Date.Second() == 0
+}
+
+// busiest возвращает до n дней с событиями: сначала по занятому времени, затем
+// по числу событий, при равенстве — более ранний.
+func busiest(days []domain.DayStats, n int) []domain.DayStats {
+	res := []domain.DayStats{}
+	for _, d := range days {
+		if d.Events > 0 {
+			res = append(res, d)
+		}
+	}
+	slices.SortStableFunc(res, func(a, b domain.DayStats) int {
+		return cmp.Or(cmp.Compare(b.BookedMinutes, a.BookedMinutes), cmp.Compare(b.Events, a.Events))
+	})
+	return res[:min(n, len(res))]
+}
+
+func addGroup(groups map[string]*domain.GroupStats, name string, booked int) {
+	g, ok := groups[name]
+	if !ok {
+		g = &domain.GroupStats{Name: name}
+		groups[name] = g
+	}
+	g.Events++
+	g.BookedMinutes += booked
+}
+
+// sortedGroups упорядочивает группы по числу событий, затем по имени.
+func sortedGroups(groups map[string]*domain.GroupStats) []domain.GroupStats {
+	res := make([]domain.GroupStats, 0, len(groups))
+	for _, g := range groups {
+		res = append(res, *g)
+	}
+	slices.SortFunc(res, func(a, b domain.GroupStats) int {
+		return cmp.Or(cmp.Compare(b.Events, a.Events), cmp.Compare(a.Name, b.Name))
+	})
+	return res
+}
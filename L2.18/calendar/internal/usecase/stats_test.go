@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"calendar/internal/domain"
+	repoMocks "calendar/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventUseCase_GetStats(t *testing.T) {
+	repo := repoMocks.NewMockEventRepository(t)
+	uc := NewEventUseCase(repo)
+
+	at := func(d, h, m int) time.Time { return time.Date(2026, 10, d, h, m, 0, 0, time.UTC) }
+	from, to := at(12, 0, 0), at(19, 0, 0)
+
+	repo.EXPECT().
+		GetByUserAndRange(mock.Anything, 1, from, to).
+		Return([]domain.Event{
+			// Понедельник: две встречи по часу, вторая начинается в середине первой.
+			{ID: "1", UserID: 1, Date: at(12, 10, 0), EventAttrs: domain.EventAttrs{Duration: 60, Category: "meeting", Tags: []string{"team"}}},
+			{ID: "2", UserID: 1, Date: at(12, 10, 30), EventAttrs: domain.EventAttrs{Duration: 60, Category: "meeting", Tags: []string{"team", "sync"}}},
+			// Среда: событие со временем без длительности — час, и событие на весь день.
+			{ID: "3", UserID: 1, Date: at(14, 15, 0), EventAttrs: domain.EventAttrs{Category: "focus"}},
+			{ID: "4", UserID: 1, Date: at(14, 0, 0), EventAttrs: domain.EventAttrs{Tags: []string{"team"}}},
+			// Воскресенье: встреча через полночь — занятость обрезается концом периода.
+			{ID: "5", UserID: 1, Date: at(18, 23, 0), EventAttrs: domain.EventAttrs{Duration: 120}},
+			// Отклонённое приглашение не считается.
+			{ID: "6", UserID: 2, Date: at(15, 9, 0), EventAttrs: domain.EventAttrs{
+				Duration:  240,
+				Attendees: []domain.Attendee{{UserID: 1, Status: domain.StatusDeclined}},
+			}},
+		}, nil).
+		Once()
+
+	stats, err := uc.GetStats(t.Context(), 1, "2026-10-14", domain.PeriodWeek, domain.PeriodOptions{Align: domain.AlignCalendar})
+	require.NoError(t, err)
+
+	require.Equal(t, from, stats.From)
+	require.Equal(t, to, stats.To)
+	require.Equal(t, 5, stats.Events)
+	require.Equal(t, 1, stats.AllDay)
+	require.Equal(t, 60+60+60+120, stats.BookedMinutes)
+	require.Equal(t, 90+60+60, stats.BusyMinutes)
+
+	require.Len(t, stats.Days, 7)
+	require.Equal(t, domain.DayStats{Date: at(12, 0, 0), Events: 2, BookedMinutes: 120}, stats.Days[0])
+	require.Equal(t, domain.DayStats{Date: at(13, 0, 0)}, stats.Days[1], "empty days must be present")
+	require.Equal(t, domain.DayStats{Date: at(14, 0, 0), Events: 2, BookedMinutes: 60}, stats.Days[2])
+
+	require.Equal(t, "Monday", stats.Weekdays[0].Weekday)
+	require.Equal(t, domain.WeekdayStats{Weekday: "Sunday", Events: 1, BookedMinutes: 120}, stats.Weekdays[6])
+
+	require.Equal(t, []domain.DayStats{
+		{Date: at(12, 0, 0), Events: 2, BookedMinutes: 120},
+		{Date: at(18, 0, 0), Events: 1, BookedMinutes: 120},
+		{Date: at(14, 0, 0), Events: 2, BookedMinutes: 60},
+	}, stats.BusiestDays)
+
+	require.Equal(t, []domain.GroupStats{
+		{Name: "team", Events: 3, BookedMinutes: 120},
+		{Name: "sync", Events: 1, BookedMinutes: 60},
+	}, stats.Tags)
+	require.Equal(t, []domain.GroupStats{
+		{Name: "meeting", Events: 2, BookedMinutes: 120},
+		{Name: "focus", Events: 1, BookedMinutes: 60},
+	}, stats.Categories)
+}
+
+func TestEventUseCase_GetStats_WeekStartAndTimeZone(t *testing.T) {
+	repo := repoMocks.NewMockEventRepository(t)
+	uc := NewEventUseCase(repo)
+
+	// 23:30 UTC субботы — уже воскресенье в Москве (UTC+3).
+	repo.EXPECT().GetByUserAndRange(mock.Anything, 1, mock.Anything, mock.Anything).
+		Return([]domain.Event{{ID: "1", UserID: 1, Date: time.Date(2026, 10, 17, 23, 30, 0, 0, time.UTC), EventAttrs: domain.EventAttrs{Duration: 30}}}, nil).
+		Once()
+
+	stats, err := uc.GetStats(t.Context(), 1, "2026-10-18", domain.PeriodWeek,
+		domain.PeriodOptions{Align: domain.AlignCalendar, WeekStart: "sunday", TimeZone: "Europe/Moscow"})
+	require.NoError(t, err)
+
+	require.Equal(t, "Sunday", stats.Weekdays[0].Weekday)
+	require.Equal(t, 1, stats.Weekdays[0].Events)
+	require.Equal(t, 1, stats.Days[0].Events)
+	require.Equal(t, 30, stats.BusyMinutes)
+	require.Empty(t, stats.Tags)
+}
+
+func TestEventUseCase_GetStats_UnknownPeriod(t *testing.T) {
+	uc := NewEventUseCase(repoMocks.NewMockEventRepository(t))
+
+	_, err := uc.GetStats(t.Context(), 1, "2026-10-14", "year", domain.PeriodOptions{})
+	require.ErrorIs(t, err, domain.ErrPeriodInvalid)
+}
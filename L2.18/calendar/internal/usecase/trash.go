@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"calendar/internal/domain"
+	"calendar/internal/reqlog"
+	"context"
+	"slices"
+	"time"
+)
+
+// DefaultTrashRetention — сколько удалённое событие лежит в корзине, прежде чем его вычистят.
+const DefaultTrashRetention = 30 * 24 * time.Hour
+
+// WithTrashRetention задаёт срок хранения событий в корзине.
+func WithTrashRetention(d time.Duration) Option {
+	return func(uc *EventUseCase) {
+		uc.trashRetention = d
+	}
+}
+
+// ListTrash возвращает события, которые пользователь организовывал и удалил, — недавние первыми.
+func (uc *EventUseCase) ListTrash(ctx context.Context, userID int) ([]domain.DeletedEvent, error) {
+	return uc.repo.ListDeleted(ctx, userID)
+}
+
+// RestoreEvent возвращает событие из корзины; это может сделать только организатор.
+// Участники получают приглашение заново, их прежние ответы сохраняются.
+func (uc *EventUseCase) RestoreEvent(ctx context.Context, id string, userID int) (domain.Event, error) {
+	trash, err := uc.repo.ListDeleted(ctx, userID)
+	if err != nil {
+		return domain.Event{}, err
+	}
+	// Чужая корзина не отличается от пустой: не подсказываем, что такой ID существует.
+	i := slices.IndexFunc(trash, func(d domain.DeletedEvent) bool { return d.ID == id })
+	if i < 0 {
+		return domain.Event{}, domain.ErrEventNotFound
+	}
+
+	// Восстановленное событие снова занимает место в квоте.
+	unlock := uc.lockUser(userID)
+	if err := uc.checkNewEvent(ctx, userID, trash[i].Date, quotaPending{}); err != nil {
+		unlock()
+		return domain.Event{}, err
+	}
+	event, err := uc.repo.Restore(ctx, id)
+	unlock()
+	if err != nil {
+		return domain.Event{}, err
+	}
+	reqlog.Printf(ctx, "[EVENT] user %d restored %s", userID, id)
+	uc.notify(ctx, domain.NotifyInvited, event, userID, attendeeIDs(event.Attendees)...)
+	return event, nil
+}
+
+// PurgeTrash окончательно удаляет события, пролежавшие в корзине дольше срока хранения,
+// вместе с их вложениями, и возвращает, сколько событий удалено.
+func (uc *EventUseCase) PurgeTrash(ctx context.Context) (int, error) {
+	purged, err := uc.repo.Purge(ctx, uc.now().Add(-uc.trashRetention))
+	if err != nil {
+		return 0, err
+	}
+	for _, e := range purged {
+		uc.collectAttachments(ctx, e.ID)
+	}
+	if len(purged) > 0 {
+		reqlog.Printf(ctx, "[TRASH] purged %d events", len(purged))
+	}
+	return len(purged), nil
+}
+
+// PurgeTrashEvery вызывает PurgeTrash раз в interval, пока не отменён ctx.
+func (uc *EventUseCase) PurgeTrashEvery(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := uc.PurgeTrash(ctx); err != nil && ctx.Err() == nil {
+				reqlog.Printf(ctx, "[ERROR] trash purge failed: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"calendar/internal/domain"
+	"calendar/internal/repository"
+	repoMocks "calendar/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventUseCase_Trash(t *testing.T) {
+	ctx := t.Context()
+	uc := NewEventUseCase(repository.NewLocalStorage())
+
+	id, err := uc.CreateEvent(ctx, 1, "2026-10-20", "Retro", domain.EventAttrs{Attendees: []domain.Attendee{{UserID: 2}}})
+	require.NoError(t, err)
+	require.NoError(t, uc.DeleteEvent(ctx, id, 1))
+
+	for _, user := range []int{1, 2} {
+		events, err := uc.GetEventsForDay(ctx, user, "2026-10-20", domain.PeriodOptions{}, domain.EventFilter{})
+		require.NoError(t, err)
+		require.Empty(t, events, "user %d", user)
+	}
+
+	trash, err := uc.ListTrash(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, trash, 1)
+	require.Equal(t, "Retro", trash[0].Title)
+
+	// Участник не видит корзину организатора и не может восстановить событие.
+	trash, err = uc.ListTrash(ctx, 2)
+	require.NoError(t, err)
+	require.Empty(t, trash)
+	_, err = uc.RestoreEvent(ctx, id, 2)
+	require.ErrorIs(t, err, domain.ErrEventNotFound)
+
+	restored, err := uc.RestoreEvent(ctx, id, 1)
+	require.NoError(t, err)
+	require.Equal(t, "Retro", restored.Title)
+	events, err := uc.GetEventsForDay(ctx, 2, "2026-10-20", domain.PeriodOptions{}, domain.EventFilter{})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	_, err = uc.RestoreEvent(ctx, id, 1)
+	require.ErrorIs(t, err, domain.ErrEventNotFound)
+}
+
+func TestEventUseCase_RestoreEvent_NotifiesAttendees(t *testing.T) {
+	repo := repoMocks.NewMockEventRepository(t)
+	notifier := repoMocks.NewMockNotifier(t)
+	uc := NewEventUseCase(repo, WithNotifier(notifier))
+
+	event := domain.Event{ID: "evt-1", UserID: 1, EventAttrs: domain.EventAttrs{
+		Attendees: []domain.Attendee{{UserID: 2, Status: domain.StatusAccepted}},
+	}}
+	repo.EXPECT().ListDeleted(mock.Anything, 1).Return([]domain.DeletedEvent{{Event: event}}, nil).Once()
+	repo.EXPECT().Restore(mock.Anything, "evt-1").Return(event, nil).Once()
+	notifier.EXPECT().Notify(mock.Anything, 2, domain.Notification{Kind: domain.NotifyInvited, Event: event, From: 1}).Return(nil).Once()
+
+	got, err := uc.RestoreEvent(t.Context(), "evt-1", 1)
+	require.NoError(t, err)
+	require.Equal(t, event, got)
+}
+
+func TestEventUseCase_PurgeTrash_UsesRetention(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	repo := repoMocks.NewMockEventRepository(t)
+	uc := NewEventUseCase(repo, WithClock(func() time.Time { return now }), WithTrashRetention(7*24*time.Hour))
+
+	repo.EXPECT().Purge(mock.Anything, time.Date(2026, 10, 11, 9, 0, 0, 0, time.UTC)).Return(nil, nil).Once()
+
+	n, err := uc.PurgeTrash(t.Context())
+	require.NoError(t, err)
+	require.Zero(t, n)
+}
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	"calendar/internal/domain"
+	"context"
 	"time"
 
 	mock "github.com/stretchr/testify/mock"
@@ -38,9 +39,137 @@ func (_m *MockEventRepository) EXPECT() *MockEventRepository_Expecter {
 	return &MockEventRepository_Expecter{mock: &_m.Mock}
 }
 
+// CountByUser provides a mock function for the type MockEventRepository
+func (_mock *MockEventRepository) CountByUser(ctx context.Context, userID int) (int, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByUser")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (int, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEventRepository_CountByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountByUser'
+type MockEventRepository_CountByUser_Call struct {
+	*mock.Call
+}
+
+// CountByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+func (_e *MockEventRepository_Expecter) CountByUser(ctx interface{}, userID interface{}) *MockEventRepository_CountByUser_Call {
+	return &MockEventRepository_CountByUser_Call{Call: _e.mock.On("CountByUser", ctx, userID)}
+}
+
+func (_c *MockEventRepository_CountByUser_Call) Run(run func(ctx context.Context, userID int)) *MockEventRepository_CountByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventRepository_CountByUser_Call) Return(n int, err error) *MockEventRepository_CountByUser_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockEventRepository_CountByUser_Call) RunAndReturn(run func(ctx context.Context, userID int) (int, error)) *MockEventRepository_CountByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountPerUser provides a mock function for the type MockEventRepository
+func (_mock *MockEventRepository) CountPerUser(ctx context.Context) (map[int]int, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountPerUser")
+	}
+
+	var r0 map[int]int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (map[int]int, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) map[int]int); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int]int)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEventRepository_CountPerUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountPerUser'
+type MockEventRepository_CountPerUser_Call struct {
+	*mock.Call
+}
+
+// CountPerUser is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockEventRepository_Expecter) CountPerUser(ctx interface{}) *MockEventRepository_CountPerUser_Call {
+	return &MockEventRepository_CountPerUser_Call{Call: _e.mock.On("CountPerUser", ctx)}
+}
+
+func (_c *MockEventRepository_CountPerUser_Call) Run(run func(ctx context.Context)) *MockEventRepository_CountPerUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventRepository_CountPerUser_Call) Return(m map[int]int, err error) *MockEventRepository_CountPerUser_Call {
+	_c.Call.Return(m, err)
+	return _c
+}
+
+func (_c *MockEventRepository_CountPerUser_Call) RunAndReturn(run func(ctx context.Context) (map[int]int, error)) *MockEventRepository_CountPerUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function for the type MockEventRepository
-func (_mock *MockEventRepository) Create(e domain.Event) (string, error) {
-	ret := _mock.Called(e)
+func (_mock *MockEventRepository) Create(ctx context.Context, e domain.Event) (string, error) {
+	ret := _mock.Called(ctx, e)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
@@ -48,16 +177,16 @@ func (_mock *MockEventRepository) Create(e domain.Event) (string, error) {
 
 	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(domain.Event) (string, error)); ok {
-		return returnFunc(e)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Event) (string, error)); ok {
+		return returnFunc(ctx, e)
 	}
-	if returnFunc, ok := ret.Get(0).(func(domain.Event) string); ok {
-		r0 = returnFunc(e)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Event) string); ok {
+		r0 = returnFunc(ctx, e)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(domain.Event) error); ok {
-		r1 = returnFunc(e)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Event) error); ok {
+		r1 = returnFunc(ctx, e)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -70,19 +199,25 @@ type MockEventRepository_Create_Call struct {
 }
 
 // Create is a helper method to define mock.On call
+//   - ctx context.Context
 //   - e domain.Event
-func (_e *MockEventRepository_Expecter) Create(e interface{}) *MockEventRepository_Create_Call {
-	return &MockEventRepository_Create_Call{Call: _e.mock.On("Create", e)}
+func (_e *MockEventRepository_Expecter) Create(ctx interface{}, e interface{}) *MockEventRepository_Create_Call {
+	return &MockEventRepository_Create_Call{Call: _e.mock.On("Create", ctx, e)}
 }
 
-func (_c *MockEventRepository_Create_Call) Run(run func(e domain.Event)) *MockEventRepository_Create_Call {
+func (_c *MockEventRepository_Create_Call) Run(run func(ctx context.Context, e domain.Event)) *MockEventRepository_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 domain.Event
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(domain.Event)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Event
+		if args[1] != nil {
+			arg1 = args[1].(domain.Event)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -93,22 +228,22 @@ func (_c *MockEventRepository_Create_Call) Return(s string, err error) *MockEven
 	return _c
 }
 
-func (_c *MockEventRepository_Create_Call) RunAndReturn(run func(e domain.Event) (string, error)) *MockEventRepository_Create_Call {
+func (_c *MockEventRepository_Create_Call) RunAndReturn(run func(ctx context.Context, e domain.Event) (string, error)) *MockEventRepository_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Delete provides a mock function for the type MockEventRepository
-func (_mock *MockEventRepository) Delete(id string) error {
-	ret := _mock.Called(id)
+func (_mock *MockEventRepository) Delete(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Delete")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
-		r0 = returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -121,19 +256,25 @@ type MockEventRepository_Delete_Call struct {
 }
 
 // Delete is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id string
-func (_e *MockEventRepository_Expecter) Delete(id interface{}) *MockEventRepository_Delete_Call {
-	return &MockEventRepository_Delete_Call{Call: _e.mock.On("Delete", id)}
+func (_e *MockEventRepository_Expecter) Delete(ctx interface{}, id interface{}) *MockEventRepository_Delete_Call {
+	return &MockEventRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
 }
 
-func (_c *MockEventRepository_Delete_Call) Run(run func(id string)) *MockEventRepository_Delete_Call {
+func (_c *MockEventRepository_Delete_Call) Run(run func(ctx context.Context, id string)) *MockEventRepository_Delete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -144,14 +285,154 @@ func (_c *MockEventRepository_Delete_Call) Return(err error) *MockEventRepositor
 	return _c
 }
 
-func (_c *MockEventRepository_Delete_Call) RunAndReturn(run func(id string) error) *MockEventRepository_Delete_Call {
+func (_c *MockEventRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockEventRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function for the type MockEventRepository
+func (_mock *MockEventRepository) GetByID(ctx context.Context, id string) (domain.Event, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 domain.Event
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.Event, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.Event); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Event)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEventRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockEventRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockEventRepository_Expecter) GetByID(ctx interface{}, id interface{}) *MockEventRepository_GetByID_Call {
+	return &MockEventRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *MockEventRepository_GetByID_Call) Run(run func(ctx context.Context, id string)) *MockEventRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventRepository_GetByID_Call) Return(event domain.Event, err error) *MockEventRepository_GetByID_Call {
+	_c.Call.Return(event, err)
+	return _c
+}
+
+func (_c *MockEventRepository_GetByID_Call) RunAndReturn(run func(ctx context.Context, id string) (domain.Event, error)) *MockEventRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUID provides a mock function for the type MockEventRepository
+func (_mock *MockEventRepository) GetByUID(ctx context.Context, userID int, uid string) ([]domain.Event, error) {
+	ret := _mock.Called(ctx, userID, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUID")
+	}
+
+	var r0 []domain.Event
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string) ([]domain.Event, error)); ok {
+		return returnFunc(ctx, userID, uid)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string) []domain.Event); ok {
+		r0 = returnFunc(ctx, userID, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Event)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = returnFunc(ctx, userID, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEventRepository_GetByUID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUID'
+type MockEventRepository_GetByUID_Call struct {
+	*mock.Call
+}
+
+// GetByUID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - uid string
+func (_e *MockEventRepository_Expecter) GetByUID(ctx interface{}, userID interface{}, uid interface{}) *MockEventRepository_GetByUID_Call {
+	return &MockEventRepository_GetByUID_Call{Call: _e.mock.On("GetByUID", ctx, userID, uid)}
+}
+
+func (_c *MockEventRepository_GetByUID_Call) Run(run func(ctx context.Context, userID int, uid string)) *MockEventRepository_GetByUID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventRepository_GetByUID_Call) Return(events []domain.Event, err error) *MockEventRepository_GetByUID_Call {
+	_c.Call.Return(events, err)
+	return _c
+}
+
+func (_c *MockEventRepository_GetByUID_Call) RunAndReturn(run func(ctx context.Context, userID int, uid string) ([]domain.Event, error)) *MockEventRepository_GetByUID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetByUserAndRange provides a mock function for the type MockEventRepository
-func (_mock *MockEventRepository) GetByUserAndRange(userID int, from time.Time, to time.Time) ([]domain.Event, error) {
-	ret := _mock.Called(userID, from, to)
+func (_mock *MockEventRepository) GetByUserAndRange(ctx context.Context, userID int, from time.Time, to time.Time) ([]domain.Event, error) {
+	ret := _mock.Called(ctx, userID, from, to)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetByUserAndRange")
@@ -159,18 +440,18 @@ func (_mock *MockEventRepository) GetByUserAndRange(userID int, from time.Time,
 
 	var r0 []domain.Event
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(int, time.Time, time.Time) ([]domain.Event, error)); ok {
-		return returnFunc(userID, from, to)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, time.Time, time.Time) ([]domain.Event, error)); ok {
+		return returnFunc(ctx, userID, from, to)
 	}
-	if returnFunc, ok := ret.Get(0).(func(int, time.Time, time.Time) []domain.Event); ok {
-		r0 = returnFunc(userID, from, to)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, time.Time, time.Time) []domain.Event); ok {
+		r0 = returnFunc(ctx, userID, from, to)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domain.Event)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(int, time.Time, time.Time) error); ok {
-		r1 = returnFunc(userID, from, to)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, time.Time, time.Time) error); ok {
+		r1 = returnFunc(ctx, userID, from, to)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -183,31 +464,37 @@ type MockEventRepository_GetByUserAndRange_Call struct {
 }
 
 // GetByUserAndRange is a helper method to define mock.On call
+//   - ctx context.Context
 //   - userID int
 //   - from time.Time
 //   - to time.Time
-func (_e *MockEventRepository_Expecter) GetByUserAndRange(userID interface{}, from interface{}, to interface{}) *MockEventRepository_GetByUserAndRange_Call {
-	return &MockEventRepository_GetByUserAndRange_Call{Call: _e.mock.On("GetByUserAndRange", userID, from, to)}
+func (_e *MockEventRepository_Expecter) GetByUserAndRange(ctx interface{}, userID interface{}, from interface{}, to interface{}) *MockEventRepository_GetByUserAndRange_Call {
+	return &MockEventRepository_GetByUserAndRange_Call{Call: _e.mock.On("GetByUserAndRange", ctx, userID, from, to)}
 }
 
-func (_c *MockEventRepository_GetByUserAndRange_Call) Run(run func(userID int, from time.Time, to time.Time)) *MockEventRepository_GetByUserAndRange_Call {
+func (_c *MockEventRepository_GetByUserAndRange_Call) Run(run func(ctx context.Context, userID int, from time.Time, to time.Time)) *MockEventRepository_GetByUserAndRange_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 int
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(int)
+			arg0 = args[0].(context.Context)
 		}
-		var arg1 time.Time
+		var arg1 int
 		if args[1] != nil {
-			arg1 = args[1].(time.Time)
+			arg1 = args[1].(int)
 		}
 		var arg2 time.Time
 		if args[2] != nil {
 			arg2 = args[2].(time.Time)
 		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
@@ -218,22 +505,508 @@ func (_c *MockEventRepository_GetByUserAndRange_Call) Return(events []domain.Eve
 	return _c
 }
 
-func (_c *MockEventRepository_GetByUserAndRange_Call) RunAndReturn(run func(userID int, from time.Time, to time.Time) ([]domain.Event, error)) *MockEventRepository_GetByUserAndRange_Call {
+func (_c *MockEventRepository_GetByUserAndRange_Call) RunAndReturn(run func(ctx context.Context, userID int, from time.Time, to time.Time) ([]domain.Event, error)) *MockEventRepository_GetByUserAndRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUserTagsAndRange provides a mock function for the type MockEventRepository
+func (_mock *MockEventRepository) GetByUserTagsAndRange(ctx context.Context, userID int, tags []string, matchAll bool, from time.Time, to time.Time) ([]domain.Event, error) {
+	ret := _mock.Called(ctx, userID, tags, matchAll, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserTagsAndRange")
+	}
+
+	var r0 []domain.Event
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, []string, bool, time.Time, time.Time) ([]domain.Event, error)); ok {
+		return returnFunc(ctx, userID, tags, matchAll, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, []string, bool, time.Time, time.Time) []domain.Event); ok {
+		r0 = returnFunc(ctx, userID, tags, matchAll, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Event)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, []string, bool, time.Time, time.Time) error); ok {
+		r1 = returnFunc(ctx, userID, tags, matchAll, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEventRepository_GetByUserTagsAndRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUserTagsAndRange'
+type MockEventRepository_GetByUserTagsAndRange_Call struct {
+	*mock.Call
+}
+
+// GetByUserTagsAndRange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - tags []string
+//   - matchAll bool
+//   - from time.Time
+//   - to time.Time
+func (_e *MockEventRepository_Expecter) GetByUserTagsAndRange(ctx interface{}, userID interface{}, tags interface{}, matchAll interface{}, from interface{}, to interface{}) *MockEventRepository_GetByUserTagsAndRange_Call {
+	return &MockEventRepository_GetByUserTagsAndRange_Call{Call: _e.mock.On("GetByUserTagsAndRange", ctx, userID, tags, matchAll, from, to)}
+}
+
+func (_c *MockEventRepository_GetByUserTagsAndRange_Call) Run(run func(ctx context.Context, userID int, tags []string, matchAll bool, from time.Time, to time.Time)) *MockEventRepository_GetByUserTagsAndRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
+		}
+		var arg4 time.Time
+		if args[4] != nil {
+			arg4 = args[4].(time.Time)
+		}
+		var arg5 time.Time
+		if args[5] != nil {
+			arg5 = args[5].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventRepository_GetByUserTagsAndRange_Call) Return(events []domain.Event, err error) *MockEventRepository_GetByUserTagsAndRange_Call {
+	_c.Call.Return(events, err)
+	return _c
+}
+
+func (_c *MockEventRepository_GetByUserTagsAndRange_Call) RunAndReturn(run func(ctx context.Context, userID int, tags []string, matchAll bool, from time.Time, to time.Time) ([]domain.Event, error)) *MockEventRepository_GetByUserTagsAndRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDeleted provides a mock function for the type MockEventRepository
+func (_mock *MockEventRepository) ListDeleted(ctx context.Context, userID int) ([]domain.DeletedEvent, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeleted")
+	}
+
+	var r0 []domain.DeletedEvent
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]domain.DeletedEvent, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []domain.DeletedEvent); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.DeletedEvent)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEventRepository_ListDeleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeleted'
+type MockEventRepository_ListDeleted_Call struct {
+	*mock.Call
+}
+
+// ListDeleted is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+func (_e *MockEventRepository_Expecter) ListDeleted(ctx interface{}, userID interface{}) *MockEventRepository_ListDeleted_Call {
+	return &MockEventRepository_ListDeleted_Call{Call: _e.mock.On("ListDeleted", ctx, userID)}
+}
+
+func (_c *MockEventRepository_ListDeleted_Call) Run(run func(ctx context.Context, userID int)) *MockEventRepository_ListDeleted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventRepository_ListDeleted_Call) Return(deletedEvents []domain.DeletedEvent, err error) *MockEventRepository_ListDeleted_Call {
+	_c.Call.Return(deletedEvents, err)
+	return _c
+}
+
+func (_c *MockEventRepository_ListDeleted_Call) RunAndReturn(run func(ctx context.Context, userID int) ([]domain.DeletedEvent, error)) *MockEventRepository_ListDeleted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Load provides a mock function for the type MockEventRepository
+func (_mock *MockEventRepository) Load(ctx context.Context, snap domain.Snapshot, replace bool) error {
+	ret := _mock.Called(ctx, snap, replace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Load")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Snapshot, bool) error); ok {
+		r0 = returnFunc(ctx, snap, replace)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEventRepository_Load_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Load'
+type MockEventRepository_Load_Call struct {
+	*mock.Call
+}
+
+// Load is a helper method to define mock.On call
+//   - ctx context.Context
+//   - snap domain.Snapshot
+//   - replace bool
+func (_e *MockEventRepository_Expecter) Load(ctx interface{}, snap interface{}, replace interface{}) *MockEventRepository_Load_Call {
+	return &MockEventRepository_Load_Call{Call: _e.mock.On("Load", ctx, snap, replace)}
+}
+
+func (_c *MockEventRepository_Load_Call) Run(run func(ctx context.Context, snap domain.Snapshot, replace bool)) *MockEventRepository_Load_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Snapshot
+		if args[1] != nil {
+			arg1 = args[1].(domain.Snapshot)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventRepository_Load_Call) Return(err error) *MockEventRepository_Load_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEventRepository_Load_Call) RunAndReturn(run func(ctx context.Context, snap domain.Snapshot, replace bool) error) *MockEventRepository_Load_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Purge provides a mock function for the type MockEventRepository
+func (_mock *MockEventRepository) Purge(ctx context.Context, before time.Time) ([]domain.Event, error) {
+	ret := _mock.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 []domain.Event
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) ([]domain.Event, error)); ok {
+		return returnFunc(ctx, before)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) []domain.Event); ok {
+		r0 = returnFunc(ctx, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Event)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEventRepository_Purge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Purge'
+type MockEventRepository_Purge_Call struct {
+	*mock.Call
+}
+
+// Purge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *MockEventRepository_Expecter) Purge(ctx interface{}, before interface{}) *MockEventRepository_Purge_Call {
+	return &MockEventRepository_Purge_Call{Call: _e.mock.On("Purge", ctx, before)}
+}
+
+func (_c *MockEventRepository_Purge_Call) Run(run func(ctx context.Context, before time.Time)) *MockEventRepository_Purge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventRepository_Purge_Call) Return(events []domain.Event, err error) *MockEventRepository_Purge_Call {
+	_c.Call.Return(events, err)
+	return _c
+}
+
+func (_c *MockEventRepository_Purge_Call) RunAndReturn(run func(ctx context.Context, before time.Time) ([]domain.Event, error)) *MockEventRepository_Purge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function for the type MockEventRepository
+func (_mock *MockEventRepository) Restore(ctx context.Context, id string) (domain.Event, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 domain.Event
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.Event, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.Event); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Event)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEventRepository_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type MockEventRepository_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockEventRepository_Expecter) Restore(ctx interface{}, id interface{}) *MockEventRepository_Restore_Call {
+	return &MockEventRepository_Restore_Call{Call: _e.mock.On("Restore", ctx, id)}
+}
+
+func (_c *MockEventRepository_Restore_Call) Run(run func(ctx context.Context, id string)) *MockEventRepository_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventRepository_Restore_Call) Return(event domain.Event, err error) *MockEventRepository_Restore_Call {
+	_c.Call.Return(event, err)
+	return _c
+}
+
+func (_c *MockEventRepository_Restore_Call) RunAndReturn(run func(ctx context.Context, id string) (domain.Event, error)) *MockEventRepository_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetAttendeeStatus provides a mock function for the type MockEventRepository
+func (_mock *MockEventRepository) SetAttendeeStatus(ctx context.Context, id string, userID int, status domain.AttendeeStatus) error {
+	ret := _mock.Called(ctx, id, userID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetAttendeeStatus")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, domain.AttendeeStatus) error); ok {
+		r0 = returnFunc(ctx, id, userID, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEventRepository_SetAttendeeStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetAttendeeStatus'
+type MockEventRepository_SetAttendeeStatus_Call struct {
+	*mock.Call
+}
+
+// SetAttendeeStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - userID int
+//   - status domain.AttendeeStatus
+func (_e *MockEventRepository_Expecter) SetAttendeeStatus(ctx interface{}, id interface{}, userID interface{}, status interface{}) *MockEventRepository_SetAttendeeStatus_Call {
+	return &MockEventRepository_SetAttendeeStatus_Call{Call: _e.mock.On("SetAttendeeStatus", ctx, id, userID, status)}
+}
+
+func (_c *MockEventRepository_SetAttendeeStatus_Call) Run(run func(ctx context.Context, id string, userID int, status domain.AttendeeStatus)) *MockEventRepository_SetAttendeeStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 domain.AttendeeStatus
+		if args[3] != nil {
+			arg3 = args[3].(domain.AttendeeStatus)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventRepository_SetAttendeeStatus_Call) Return(err error) *MockEventRepository_SetAttendeeStatus_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEventRepository_SetAttendeeStatus_Call) RunAndReturn(run func(ctx context.Context, id string, userID int, status domain.AttendeeStatus) error) *MockEventRepository_SetAttendeeStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Snapshot provides a mock function for the type MockEventRepository
+func (_mock *MockEventRepository) Snapshot(ctx context.Context) (domain.Snapshot, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Snapshot")
+	}
+
+	var r0 domain.Snapshot
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (domain.Snapshot, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) domain.Snapshot); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(domain.Snapshot)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEventRepository_Snapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Snapshot'
+type MockEventRepository_Snapshot_Call struct {
+	*mock.Call
+}
+
+// Snapshot is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockEventRepository_Expecter) Snapshot(ctx interface{}) *MockEventRepository_Snapshot_Call {
+	return &MockEventRepository_Snapshot_Call{Call: _e.mock.On("Snapshot", ctx)}
+}
+
+func (_c *MockEventRepository_Snapshot_Call) Run(run func(ctx context.Context)) *MockEventRepository_Snapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventRepository_Snapshot_Call) Return(snapshot domain.Snapshot, err error) *MockEventRepository_Snapshot_Call {
+	_c.Call.Return(snapshot, err)
+	return _c
+}
+
+func (_c *MockEventRepository_Snapshot_Call) RunAndReturn(run func(ctx context.Context) (domain.Snapshot, error)) *MockEventRepository_Snapshot_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Update provides a mock function for the type MockEventRepository
-func (_mock *MockEventRepository) Update(e domain.Event) error {
-	ret := _mock.Called(e)
+func (_mock *MockEventRepository) Update(ctx context.Context, e domain.Event) error {
+	ret := _mock.Called(ctx, e)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(domain.Event) error); ok {
-		r0 = returnFunc(e)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Event) error); ok {
+		r0 = returnFunc(ctx, e)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -246,19 +1019,25 @@ type MockEventRepository_Update_Call struct {
 }
 
 // Update is a helper method to define mock.On call
+//   - ctx context.Context
 //   - e domain.Event
-func (_e *MockEventRepository_Expecter) Update(e interface{}) *MockEventRepository_Update_Call {
-	return &MockEventRepository_Update_Call{Call: _e.mock.On("Update", e)}
+func (_e *MockEventRepository_Expecter) Update(ctx interface{}, e interface{}) *MockEventRepository_Update_Call {
+	return &MockEventRepository_Update_Call{Call: _e.mock.On("Update", ctx, e)}
 }
 
-func (_c *MockEventRepository_Update_Call) Run(run func(e domain.Event)) *MockEventRepository_Update_Call {
+func (_c *MockEventRepository_Update_Call) Run(run func(ctx context.Context, e domain.Event)) *MockEventRepository_Update_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 domain.Event
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(domain.Event)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Event
+		if args[1] != nil {
+			arg1 = args[1].(domain.Event)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -269,7 +1048,7 @@ func (_c *MockEventRepository_Update_Call) Return(err error) *MockEventRepositor
 	return _c
 }
 
-func (_c *MockEventRepository_Update_Call) RunAndReturn(run func(e domain.Event) error) *MockEventRepository_Update_Call {
+func (_c *MockEventRepository_Update_Call) RunAndReturn(run func(ctx context.Context, e domain.Event) error) *MockEventRepository_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
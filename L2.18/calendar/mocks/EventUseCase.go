@@ -6,6 +6,9 @@ package mocks
 
 import (
 	"calendar/internal/domain"
+	"context"
+	"io"
+	"time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -37,344 +40,1874 @@ func (_m *MockEventUseCase) EXPECT() *MockEventUseCase_Expecter {
 	return &MockEventUseCase_Expecter{mock: &_m.Mock}
 }
 
+// AddAttachment provides a mock function for the type MockEventUseCase
+func (_mock *MockEventUseCase) AddAttachment(ctx context.Context, eventID string, userID int, name string, contentType string, r io.Reader) (domain.Attachment, error) {
+	ret := _mock.Called(ctx, eventID, userID, name, contentType, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddAttachment")
+	}
+
+	var r0 domain.Attachment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, string, string, io.Reader) (domain.Attachment, error)); ok {
+		return returnFunc(ctx, eventID, userID, name, contentType, r)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, string, string, io.Reader) domain.Attachment); ok {
+		r0 = returnFunc(ctx, eventID, userID, name, contentType, r)
+	} else {
+		r0 = ret.Get(0).(domain.Attachment)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int, string, string, io.Reader) error); ok {
+		r1 = returnFunc(ctx, eventID, userID, name, contentType, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEventUseCase_AddAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddAttachment'
+type MockEventUseCase_AddAttachment_Call struct {
+	*mock.Call
+}
+
+// AddAttachment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventID string
+//   - userID int
+//   - name string
+//   - contentType string
+//   - r io.Reader
+func (_e *MockEventUseCase_Expecter) AddAttachment(ctx interface{}, eventID interface{}, userID interface{}, name interface{}, contentType interface{}, r interface{}) *MockEventUseCase_AddAttachment_Call {
+	return &MockEventUseCase_AddAttachment_Call{Call: _e.mock.On("AddAttachment", ctx, eventID, userID, name, contentType, r)}
+}
+
+func (_c *MockEventUseCase_AddAttachment_Call) Run(run func(ctx context.Context, eventID string, userID int, name string, contentType string, r io.Reader)) *MockEventUseCase_AddAttachment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 io.Reader
+		if args[5] != nil {
+			arg5 = args[5].(io.Reader)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventUseCase_AddAttachment_Call) Return(attachment domain.Attachment, err error) *MockEventUseCase_AddAttachment_Call {
+	_c.Call.Return(attachment, err)
+	return _c
+}
+
+func (_c *MockEventUseCase_AddAttachment_Call) RunAndReturn(run func(ctx context.Context, eventID string, userID int, name string, contentType string, r io.Reader) (domain.Attachment, error)) *MockEventUseCase_AddAttachment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddWorkingDays provides a mock function for the type MockEventUseCase
+func (_mock *MockEventUseCase) AddWorkingDays(ctx context.Context, userID int, dateStr string, n int, opts domain.PeriodOptions) (time.Time, error) {
+	ret := _mock.Called(ctx, userID, dateStr, n, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddWorkingDays")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, int, domain.PeriodOptions) (time.Time, error)); ok {
+		return returnFunc(ctx, userID, dateStr, n, opts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, int, domain.PeriodOptions) time.Time); ok {
+		r0 = returnFunc(ctx, userID, dateStr, n, opts)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, string, int, domain.PeriodOptions) error); ok {
+		r1 = returnFunc(ctx, userID, dateStr, n, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEventUseCase_AddWorkingDays_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddWorkingDays'
+type MockEventUseCase_AddWorkingDays_Call struct {
+	*mock.Call
+}
+
+// AddWorkingDays is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - dateStr string
+//   - n int
+//   - opts domain.PeriodOptions
+func (_e *MockEventUseCase_Expecter) AddWorkingDays(ctx interface{}, userID interface{}, dateStr interface{}, n interface{}, opts interface{}) *MockEventUseCase_AddWorkingDays_Call {
+	return &MockEventUseCase_AddWorkingDays_Call{Call: _e.mock.On("AddWorkingDays", ctx, userID, dateStr, n, opts)}
+}
+
+func (_c *MockEventUseCase_AddWorkingDays_Call) Run(run func(ctx context.Context, userID int, dateStr string, n int, opts domain.PeriodOptions)) *MockEventUseCase_AddWorkingDays_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		var arg4 domain.PeriodOptions
+		if args[4] != nil {
+			arg4 = args[4].(domain.PeriodOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventUseCase_AddWorkingDays_Call) Return(time1 time.Time, err error) *MockEventUseCase_AddWorkingDays_Call {
+	_c.Call.Return(time1, err)
+	return _c
+}
+
+func (_c *MockEventUseCase_AddWorkingDays_Call) RunAndReturn(run func(ctx context.Context, userID int, dateStr string, n int, opts domain.PeriodOptions) (time.Time, error)) *MockEventUseCase_AddWorkingDays_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Backup provides a mock function for the type MockEventUseCase
+func (_mock *MockEventUseCase) Backup(ctx context.Context, w io.Writer) (domain.BackupSummary, error) {
+	ret := _mock.Called(ctx, w)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Backup")
+	}
+
+	var r0 domain.BackupSummary
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, io.Writer) (domain.BackupSummary, error)); ok {
+		return returnFunc(ctx, w)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, io.Writer) domain.BackupSummary); ok {
+		r0 = returnFunc(ctx, w)
+	} else {
+		r0 = ret.Get(0).(domain.BackupSummary)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, io.Writer) error); ok {
+		r1 = returnFunc(ctx, w)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEventUseCase_Backup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Backup'
+type MockEventUseCase_Backup_Call struct {
+	*mock.Call
+}
+
+// Backup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - w io.Writer
+func (_e *MockEventUseCase_Expecter) Backup(ctx interface{}, w interface{}) *MockEventUseCase_Backup_Call {
+	return &MockEventUseCase_Backup_Call{Call: _e.mock.On("Backup", ctx, w)}
+}
+
+func (_c *MockEventUseCase_Backup_Call) Run(run func(ctx context.Context, w io.Writer)) *MockEventUseCase_Backup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 io.Writer
+		if args[1] != nil {
+			arg1 = args[1].(io.Writer)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventUseCase_Backup_Call) Return(backupSummary domain.BackupSummary, err error) *MockEventUseCase_Backup_Call {
+	_c.Call.Return(backupSummary, err)
+	return _c
+}
+
+func (_c *MockEventUseCase_Backup_Call) RunAndReturn(run func(ctx context.Context, w io.Writer) (domain.BackupSummary, error)) *MockEventUseCase_Backup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateEvent provides a mock function for the type MockEventUseCase
-func (_mock *MockEventUseCase) CreateEvent(userID int, dateStr string, title string) (string, error) {
-	ret := _mock.Called(userID, dateStr, title)
+func (_mock *MockEventUseCase) CreateEvent(ctx context.Context, userID int, dateStr string, title string, attrs domain.EventAttrs) (string, error) {
+	ret := _mock.Called(ctx, userID, dateStr, title, attrs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEvent")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, string, domain.EventAttrs) (string, error)); ok {
+		return returnFunc(ctx, userID, dateStr, title, attrs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, string, domain.EventAttrs) string); ok {
+		r0 = returnFunc(ctx, userID, dateStr, title, attrs)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, string, string, domain.EventAttrs) error); ok {
+		r1 = returnFunc(ctx, userID, dateStr, title, attrs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEventUseCase_CreateEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEvent'
+type MockEventUseCase_CreateEvent_Call struct {
+	*mock.Call
+}
+
+// CreateEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - dateStr string
+//   - title string
+//   - attrs domain.EventAttrs
+func (_e *MockEventUseCase_Expecter) CreateEvent(ctx interface{}, userID interface{}, dateStr interface{}, title interface{}, attrs interface{}) *MockEventUseCase_CreateEvent_Call {
+	return &MockEventUseCase_CreateEvent_Call{Call: _e.mock.On("CreateEvent", ctx, userID, dateStr, title, attrs)}
+}
+
+func (_c *MockEventUseCase_CreateEvent_Call) Run(run func(ctx context.Context, userID int, dateStr string, title string, attrs domain.EventAttrs)) *MockEventUseCase_CreateEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 domain.EventAttrs
+		if args[4] != nil {
+			arg4 = args[4].(domain.EventAttrs)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventUseCase_CreateEvent_Call) Return(s string, err error) *MockEventUseCase_CreateEvent_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockEventUseCase_CreateEvent_Call) RunAndReturn(run func(ctx context.Context, userID int, dateStr string, title string, attrs domain.EventAttrs) (string, error)) *MockEventUseCase_CreateEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAttachment provides a mock function for the type MockEventUseCase
+func (_mock *MockEventUseCase) DeleteAttachment(ctx context.Context, eventID string, attachmentID string, userID int) error {
+	ret := _mock.Called(ctx, eventID, attachmentID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAttachment")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int) error); ok {
+		r0 = returnFunc(ctx, eventID, attachmentID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEventUseCase_DeleteAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAttachment'
+type MockEventUseCase_DeleteAttachment_Call struct {
+	*mock.Call
+}
+
+// DeleteAttachment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventID string
+//   - attachmentID string
+//   - userID int
+func (_e *MockEventUseCase_Expecter) DeleteAttachment(ctx interface{}, eventID interface{}, attachmentID interface{}, userID interface{}) *MockEventUseCase_DeleteAttachment_Call {
+	return &MockEventUseCase_DeleteAttachment_Call{Call: _e.mock.On("DeleteAttachment", ctx, eventID, attachmentID, userID)}
+}
+
+func (_c *MockEventUseCase_DeleteAttachment_Call) Run(run func(ctx context.Context, eventID string, attachmentID string, userID int)) *MockEventUseCase_DeleteAttachment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventUseCase_DeleteAttachment_Call) Return(err error) *MockEventUseCase_DeleteAttachment_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEventUseCase_DeleteAttachment_Call) RunAndReturn(run func(ctx context.Context, eventID string, attachmentID string, userID int) error) *MockEventUseCase_DeleteAttachment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteEvent provides a mock function for the type MockEventUseCase
+func (_mock *MockEventUseCase) DeleteEvent(ctx context.Context, id string, userID int) error {
+	ret := _mock.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEvent")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = returnFunc(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEventUseCase_DeleteEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteEvent'
+type MockEventUseCase_DeleteEvent_Call struct {
+	*mock.Call
+}
+
+// DeleteEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - userID int
+func (_e *MockEventUseCase_Expecter) DeleteEvent(ctx interface{}, id interface{}, userID interface{}) *MockEventUseCase_DeleteEvent_Call {
+	return &MockEventUseCase_DeleteEvent_Call{Call: _e.mock.On("DeleteEvent", ctx, id, userID)}
+}
+
+func (_c *MockEventUseCase_DeleteEvent_Call) Run(run func(ctx context.Context, id string, userID int)) *MockEventUseCase_DeleteEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventUseCase_DeleteEvent_Call) Return(err error) *MockEventUseCase_DeleteEvent_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEventUseCase_DeleteEvent_Call) RunAndReturn(run func(ctx context.Context, id string, userID int) error) *MockEventUseCase_DeleteEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EventDate provides a mock function for the type MockEventUseCase
+func (_mock *MockEventUseCase) EventDate(ctx context.Context, userID int, dateStr string, shift string) (time.Time, error) {
+	ret := _mock.Called(ctx, userID, dateStr, shift)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EventDate")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, string) (time.Time, error)); ok {
+		return returnFunc(ctx, userID, dateStr, shift)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, string) time.Time); ok {
+		r0 = returnFunc(ctx, userID, dateStr, shift)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, string, string) error); ok {
+		r1 = returnFunc(ctx, userID, dateStr, shift)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEventUseCase_EventDate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EventDate'
+type MockEventUseCase_EventDate_Call struct {
+	*mock.Call
+}
+
+// EventDate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - dateStr string
+//   - shift string
+func (_e *MockEventUseCase_Expecter) EventDate(ctx interface{}, userID interface{}, dateStr interface{}, shift interface{}) *MockEventUseCase_EventDate_Call {
+	return &MockEventUseCase_EventDate_Call{Call: _e.mock.On("EventDate", ctx, userID, dateStr, shift)}
+}
+
+func (_c *MockEventUseCase_EventDate_Call) Run(run func(ctx context.Context, userID int, dateStr string, shift string)) *MockEventUseCase_EventDate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventUseCase_EventDate_Call) Return(time1 time.Time, err error) *MockEventUseCase_EventDate_Call {
+	_c.Call.Return(time1, err)
+	return _c
+}
+
+func (_c *MockEventUseCase_EventDate_Call) RunAndReturn(run func(ctx context.Context, userID int, dateStr string, shift string) (time.Time, error)) *MockEventUseCase_EventDate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindFreeSlots provides a mock function for the type MockEventUseCase
+func (_mock *MockEventUseCase) FindFreeSlots(ctx context.Context, q domain.SlotQuery) ([]domain.Slot, error) {
+	ret := _mock.Called(ctx, q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindFreeSlots")
+	}
+
+	var r0 []domain.Slot
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.SlotQuery) ([]domain.Slot, error)); ok {
+		return returnFunc(ctx, q)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.SlotQuery) []domain.Slot); ok {
+		r0 = returnFunc(ctx, q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Slot)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.SlotQuery) error); ok {
+		r1 = returnFunc(ctx, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEventUseCase_FindFreeSlots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindFreeSlots'
+type MockEventUseCase_FindFreeSlots_Call struct {
+	*mock.Call
+}
+
+// FindFreeSlots is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q domain.SlotQuery
+func (_e *MockEventUseCase_Expecter) FindFreeSlots(ctx interface{}, q interface{}) *MockEventUseCase_FindFreeSlots_Call {
+	return &MockEventUseCase_FindFreeSlots_Call{Call: _e.mock.On("FindFreeSlots", ctx, q)}
+}
+
+func (_c *MockEventUseCase_FindFreeSlots_Call) Run(run func(ctx context.Context, q domain.SlotQuery)) *MockEventUseCase_FindFreeSlots_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.SlotQuery
+		if args[1] != nil {
+			arg1 = args[1].(domain.SlotQuery)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventUseCase_FindFreeSlots_Call) Return(slots []domain.Slot, err error) *MockEventUseCase_FindFreeSlots_Call {
+	_c.Call.Return(slots, err)
+	return _c
+}
+
+func (_c *MockEventUseCase_FindFreeSlots_Call) RunAndReturn(run func(ctx context.Context, q domain.SlotQuery) ([]domain.Slot, error)) *MockEventUseCase_FindFreeSlots_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAgenda provides a mock function for the type MockEventUseCase
+func (_mock *MockEventUseCase) GetAgenda(ctx context.Context, userID int, dateStr string, kind string, opts domain.PeriodOptions) (domain.Agenda, error) {
+	ret := _mock.Called(ctx, userID, dateStr, kind, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAgenda")
+	}
+
+	var r0 domain.Agenda
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, string, domain.PeriodOptions) (domain.Agenda, error)); ok {
+		return returnFunc(ctx, userID, dateStr, kind, opts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, string, domain.PeriodOptions) domain.Agenda); ok {
+		r0 = returnFunc(ctx, userID, dateStr, kind, opts)
+	} else {
+		r0 = ret.Get(0).(domain.Agenda)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, string, string, domain.PeriodOptions) error); ok {
+		r1 = returnFunc(ctx, userID, dateStr, kind, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEventUseCase_GetAgenda_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAgenda'
+type MockEventUseCase_GetAgenda_Call struct {
+	*mock.Call
+}
+
+// GetAgenda is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - dateStr string
+//   - kind string
+//   - opts domain.PeriodOptions
+func (_e *MockEventUseCase_Expecter) GetAgenda(ctx interface{}, userID interface{}, dateStr interface{}, kind interface{}, opts interface{}) *MockEventUseCase_GetAgenda_Call {
+	return &MockEventUseCase_GetAgenda_Call{Call: _e.mock.On("GetAgenda", ctx, userID, dateStr, kind, opts)}
+}
+
+func (_c *MockEventUseCase_GetAgenda_Call) Run(run func(ctx context.Context, userID int, dateStr string, kind string, opts domain.PeriodOptions)) *MockEventUseCase_GetAgenda_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 domain.PeriodOptions
+		if args[4] != nil {
+			arg4 = args[4].(domain.PeriodOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventUseCase_GetAgenda_Call) Return(agenda domain.Agenda, err error) *MockEventUseCase_GetAgenda_Call {
+	_c.Call.Return(agenda, err)
+	return _c
+}
+
+func (_c *MockEventUseCase_GetAgenda_Call) RunAndReturn(run func(ctx context.Context, userID int, dateStr string, kind string, opts domain.PeriodOptions) (domain.Agenda, error)) *MockEventUseCase_GetAgenda_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCalendarDays provides a mock function for the type MockEventUseCase
+func (_mock *MockEventUseCase) GetCalendarDays(ctx context.Context, userID int, dateStr string, kind string, opts domain.PeriodOptions) ([]domain.CalendarDay, error) {
+	ret := _mock.Called(ctx, userID, dateStr, kind, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCalendarDays")
+	}
+
+	var r0 []domain.CalendarDay
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, string, domain.PeriodOptions) ([]domain.CalendarDay, error)); ok {
+		return returnFunc(ctx, userID, dateStr, kind, opts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, string, domain.PeriodOptions) []domain.CalendarDay); ok {
+		r0 = returnFunc(ctx, userID, dateStr, kind, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.CalendarDay)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, string, string, domain.PeriodOptions) error); ok {
+		r1 = returnFunc(ctx, userID, dateStr, kind, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEventUseCase_GetCalendarDays_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCalendarDays'
+type MockEventUseCase_GetCalendarDays_Call struct {
+	*mock.Call
+}
+
+// GetCalendarDays is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - dateStr string
+//   - kind string
+//   - opts domain.PeriodOptions
+func (_e *MockEventUseCase_Expecter) GetCalendarDays(ctx interface{}, userID interface{}, dateStr interface{}, kind interface{}, opts interface{}) *MockEventUseCase_GetCalendarDays_Call {
+	return &MockEventUseCase_GetCalendarDays_Call{Call: _e.mock.On("GetCalendarDays", ctx, userID, dateStr, kind, opts)}
+}
+
+func (_c *MockEventUseCase_GetCalendarDays_Call) Run(run func(ctx context.Context, userID int, dateStr string, kind string, opts domain.PeriodOptions)) *MockEventUseCase_GetCalendarDays_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 domain.PeriodOptions
+		if args[4] != nil {
+			arg4 = args[4].(domain.PeriodOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventUseCase_GetCalendarDays_Call) Return(calendarDays []domain.CalendarDay, err error) *MockEventUseCase_GetCalendarDays_Call {
+	_c.Call.Return(calendarDays, err)
+	return _c
+}
+
+func (_c *MockEventUseCase_GetCalendarDays_Call) RunAndReturn(run func(ctx context.Context, userID int, dateStr string, kind string, opts domain.PeriodOptions) ([]domain.CalendarDay, error)) *MockEventUseCase_GetCalendarDays_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventsForDay provides a mock function for the type MockEventUseCase
+func (_mock *MockEventUseCase) GetEventsForDay(ctx context.Context, userID int, dateStr string, opts domain.PeriodOptions, filter domain.EventFilter) ([]domain.Event, error) {
+	ret := _mock.Called(ctx, userID, dateStr, opts, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventsForDay")
+	}
+
+	var r0 []domain.Event
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, domain.PeriodOptions, domain.EventFilter) ([]domain.Event, error)); ok {
+		return returnFunc(ctx, userID, dateStr, opts, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, domain.PeriodOptions, domain.EventFilter) []domain.Event); ok {
+		r0 = returnFunc(ctx, userID, dateStr, opts, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Event)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, string, domain.PeriodOptions, domain.EventFilter) error); ok {
+		r1 = returnFunc(ctx, userID, dateStr, opts, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEventUseCase_GetEventsForDay_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventsForDay'
+type MockEventUseCase_GetEventsForDay_Call struct {
+	*mock.Call
+}
+
+// GetEventsForDay is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - dateStr string
+//   - opts domain.PeriodOptions
+//   - filter domain.EventFilter
+func (_e *MockEventUseCase_Expecter) GetEventsForDay(ctx interface{}, userID interface{}, dateStr interface{}, opts interface{}, filter interface{}) *MockEventUseCase_GetEventsForDay_Call {
+	return &MockEventUseCase_GetEventsForDay_Call{Call: _e.mock.On("GetEventsForDay", ctx, userID, dateStr, opts, filter)}
+}
+
+func (_c *MockEventUseCase_GetEventsForDay_Call) Run(run func(ctx context.Context, userID int, dateStr string, opts domain.PeriodOptions, filter domain.EventFilter)) *MockEventUseCase_GetEventsForDay_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 domain.PeriodOptions
+		if args[3] != nil {
+			arg3 = args[3].(domain.PeriodOptions)
+		}
+		var arg4 domain.EventFilter
+		if args[4] != nil {
+			arg4 = args[4].(domain.EventFilter)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventUseCase_GetEventsForDay_Call) Return(events []domain.Event, err error) *MockEventUseCase_GetEventsForDay_Call {
+	_c.Call.Return(events, err)
+	return _c
+}
+
+func (_c *MockEventUseCase_GetEventsForDay_Call) RunAndReturn(run func(ctx context.Context, userID int, dateStr string, opts domain.PeriodOptions, filter domain.EventFilter) ([]domain.Event, error)) *MockEventUseCase_GetEventsForDay_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventsForMonth provides a mock function for the type MockEventUseCase
+func (_mock *MockEventUseCase) GetEventsForMonth(ctx context.Context, userID int, dateStr string, opts domain.PeriodOptions, filter domain.EventFilter) ([]domain.Event, error) {
+	ret := _mock.Called(ctx, userID, dateStr, opts, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventsForMonth")
+	}
+
+	var r0 []domain.Event
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, domain.PeriodOptions, domain.EventFilter) ([]domain.Event, error)); ok {
+		return returnFunc(ctx, userID, dateStr, opts, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, domain.PeriodOptions, domain.EventFilter) []domain.Event); ok {
+		r0 = returnFunc(ctx, userID, dateStr, opts, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Event)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, string, domain.PeriodOptions, domain.EventFilter) error); ok {
+		r1 = returnFunc(ctx, userID, dateStr, opts, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEventUseCase_GetEventsForMonth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventsForMonth'
+type MockEventUseCase_GetEventsForMonth_Call struct {
+	*mock.Call
+}
+
+// GetEventsForMonth is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - dateStr string
+//   - opts domain.PeriodOptions
+//   - filter domain.EventFilter
+func (_e *MockEventUseCase_Expecter) GetEventsForMonth(ctx interface{}, userID interface{}, dateStr interface{}, opts interface{}, filter interface{}) *MockEventUseCase_GetEventsForMonth_Call {
+	return &MockEventUseCase_GetEventsForMonth_Call{Call: _e.mock.On("GetEventsForMonth", ctx, userID, dateStr, opts, filter)}
+}
+
+func (_c *MockEventUseCase_GetEventsForMonth_Call) Run(run func(ctx context.Context, userID int, dateStr string, opts domain.PeriodOptions, filter domain.EventFilter)) *MockEventUseCase_GetEventsForMonth_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 domain.PeriodOptions
+		if args[3] != nil {
+			arg3 = args[3].(domain.PeriodOptions)
+		}
+		var arg4 domain.EventFilter
+		if args[4] != nil {
+			arg4 = args[4].(domain.EventFilter)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventUseCase_GetEventsForMonth_Call) Return(events []domain.Event, err error) *MockEventUseCase_GetEventsForMonth_Call {
+	_c.Call.Return(events, err)
+	return _c
+}
+
+func (_c *MockEventUseCase_GetEventsForMonth_Call) RunAndReturn(run func(ctx context.Context, userID int, dateStr string, opts domain.PeriodOptions, filter domain.EventFilter) ([]domain.Event, error)) *MockEventUseCase_GetEventsForMonth_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventsForWeek provides a mock function for the type MockEventUseCase
+func (_mock *MockEventUseCase) GetEventsForWeek(ctx context.Context, userID int, dateStr string, opts domain.PeriodOptions, filter domain.EventFilter) ([]domain.Event, error) {
+	ret := _mock.Called(ctx, userID, dateStr, opts, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventsForWeek")
+	}
+
+	var r0 []domain.Event
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, domain.PeriodOptions, domain.EventFilter) ([]domain.Event, error)); ok {
+		return returnFunc(ctx, userID, dateStr, opts, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, domain.PeriodOptions, domain.EventFilter) []domain.Event); ok {
+		r0 = returnFunc(ctx, userID, dateStr, opts, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Event)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, string, domain.PeriodOptions, domain.EventFilter) error); ok {
+		r1 = returnFunc(ctx, userID, dateStr, opts, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEventUseCase_GetEventsForWeek_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventsForWeek'
+type MockEventUseCase_GetEventsForWeek_Call struct {
+	*mock.Call
+}
+
+// GetEventsForWeek is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - dateStr string
+//   - opts domain.PeriodOptions
+//   - filter domain.EventFilter
+func (_e *MockEventUseCase_Expecter) GetEventsForWeek(ctx interface{}, userID interface{}, dateStr interface{}, opts interface{}, filter interface{}) *MockEventUseCase_GetEventsForWeek_Call {
+	return &MockEventUseCase_GetEventsForWeek_Call{Call: _e.mock.On("GetEventsForWeek", ctx, userID, dateStr, opts, filter)}
+}
+
+func (_c *MockEventUseCase_GetEventsForWeek_Call) Run(run func(ctx context.Context, userID int, dateStr string, opts domain.PeriodOptions, filter domain.EventFilter)) *MockEventUseCase_GetEventsForWeek_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 domain.PeriodOptions
+		if args[3] != nil {
+			arg3 = args[3].(domain.PeriodOptions)
+		}
+		var arg4 domain.EventFilter
+		if args[4] != nil {
+			arg4 = args[4].(domain.EventFilter)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventUseCase_GetEventsForWeek_Call) Return(events []domain.Event, err error) *MockEventUseCase_GetEventsForWeek_Call {
+	_c.Call.Return(events, err)
+	return _c
+}
+
+func (_c *MockEventUseCase_GetEventsForWeek_Call) RunAndReturn(run func(ctx context.Context, userID int, dateStr string, opts domain.PeriodOptions, filter domain.EventFilter) ([]domain.Event, error)) *MockEventUseCase_GetEventsForWeek_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEventsInRange provides a mock function for the type MockEventUseCase
+func (_mock *MockEventUseCase) GetEventsInRange(ctx context.Context, userID int, from time.Time, to time.Time) ([]domain.Event, error) {
+	ret := _mock.Called(ctx, userID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventsInRange")
+	}
+
+	var r0 []domain.Event
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, time.Time, time.Time) ([]domain.Event, error)); ok {
+		return returnFunc(ctx, userID, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, time.Time, time.Time) []domain.Event); ok {
+		r0 = returnFunc(ctx, userID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Event)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, time.Time, time.Time) error); ok {
+		r1 = returnFunc(ctx, userID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEventUseCase_GetEventsInRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventsInRange'
+type MockEventUseCase_GetEventsInRange_Call struct {
+	*mock.Call
+}
+
+// GetEventsInRange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - from time.Time
+//   - to time.Time
+func (_e *MockEventUseCase_Expecter) GetEventsInRange(ctx interface{}, userID interface{}, from interface{}, to interface{}) *MockEventUseCase_GetEventsInRange_Call {
+	return &MockEventUseCase_GetEventsInRange_Call{Call: _e.mock.On("GetEventsInRange", ctx, userID, from, to)}
+}
+
+func (_c *MockEventUseCase_GetEventsInRange_Call) Run(run func(ctx context.Context, userID int, from time.Time, to time.Time)) *MockEventUseCase_GetEventsInRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventUseCase_GetEventsInRange_Call) Return(events []domain.Event, err error) *MockEventUseCase_GetEventsInRange_Call {
+	_c.Call.Return(events, err)
+	return _c
+}
+
+func (_c *MockEventUseCase_GetEventsInRange_Call) RunAndReturn(run func(ctx context.Context, userID int, from time.Time, to time.Time) ([]domain.Event, error)) *MockEventUseCase_GetEventsInRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStats provides a mock function for the type MockEventUseCase
+func (_mock *MockEventUseCase) GetStats(ctx context.Context, userID int, dateStr string, kind string, opts domain.PeriodOptions) (domain.EventStats, error) {
+	ret := _mock.Called(ctx, userID, dateStr, kind, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStats")
+	}
+
+	var r0 domain.EventStats
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, string, domain.PeriodOptions) (domain.EventStats, error)); ok {
+		return returnFunc(ctx, userID, dateStr, kind, opts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, string, domain.PeriodOptions) domain.EventStats); ok {
+		r0 = returnFunc(ctx, userID, dateStr, kind, opts)
+	} else {
+		r0 = ret.Get(0).(domain.EventStats)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, string, string, domain.PeriodOptions) error); ok {
+		r1 = returnFunc(ctx, userID, dateStr, kind, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEventUseCase_GetStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStats'
+type MockEventUseCase_GetStats_Call struct {
+	*mock.Call
+}
+
+// GetStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - dateStr string
+//   - kind string
+//   - opts domain.PeriodOptions
+func (_e *MockEventUseCase_Expecter) GetStats(ctx interface{}, userID interface{}, dateStr interface{}, kind interface{}, opts interface{}) *MockEventUseCase_GetStats_Call {
+	return &MockEventUseCase_GetStats_Call{Call: _e.mock.On("GetStats", ctx, userID, dateStr, kind, opts)}
+}
+
+func (_c *MockEventUseCase_GetStats_Call) Run(run func(ctx context.Context, userID int, dateStr string, kind string, opts domain.PeriodOptions)) *MockEventUseCase_GetStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 domain.PeriodOptions
+		if args[4] != nil {
+			arg4 = args[4].(domain.PeriodOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventUseCase_GetStats_Call) Return(eventStats domain.EventStats, err error) *MockEventUseCase_GetStats_Call {
+	_c.Call.Return(eventStats, err)
+	return _c
+}
+
+func (_c *MockEventUseCase_GetStats_Call) RunAndReturn(run func(ctx context.Context, userID int, dateStr string, kind string, opts domain.PeriodOptions) (domain.EventStats, error)) *MockEventUseCase_GetStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserSettings provides a mock function for the type MockEventUseCase
+func (_mock *MockEventUseCase) GetUserSettings(ctx context.Context, userID int) (domain.UserSettings, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserSettings")
+	}
+
+	var r0 domain.UserSettings
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (domain.UserSettings, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) domain.UserSettings); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(domain.UserSettings)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEventUseCase_GetUserSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserSettings'
+type MockEventUseCase_GetUserSettings_Call struct {
+	*mock.Call
+}
+
+// GetUserSettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+func (_e *MockEventUseCase_Expecter) GetUserSettings(ctx interface{}, userID interface{}) *MockEventUseCase_GetUserSettings_Call {
+	return &MockEventUseCase_GetUserSettings_Call{Call: _e.mock.On("GetUserSettings", ctx, userID)}
+}
+
+func (_c *MockEventUseCase_GetUserSettings_Call) Run(run func(ctx context.Context, userID int)) *MockEventUseCase_GetUserSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventUseCase_GetUserSettings_Call) Return(userSettings domain.UserSettings, err error) *MockEventUseCase_GetUserSettings_Call {
+	_c.Call.Return(userSettings, err)
+	return _c
+}
+
+func (_c *MockEventUseCase_GetUserSettings_Call) RunAndReturn(run func(ctx context.Context, userID int) (domain.UserSettings, error)) *MockEventUseCase_GetUserSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportEvents provides a mock function for the type MockEventUseCase
+func (_mock *MockEventUseCase) ImportEvents(ctx context.Context, userID int, r io.Reader, opts domain.ImportOptions) (domain.ImportReport, error) {
+	ret := _mock.Called(ctx, userID, r, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportEvents")
+	}
+
+	var r0 domain.ImportReport
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, io.Reader, domain.ImportOptions) (domain.ImportReport, error)); ok {
+		return returnFunc(ctx, userID, r, opts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, io.Reader, domain.ImportOptions) domain.ImportReport); ok {
+		r0 = returnFunc(ctx, userID, r, opts)
+	} else {
+		r0 = ret.Get(0).(domain.ImportReport)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, io.Reader, domain.ImportOptions) error); ok {
+		r1 = returnFunc(ctx, userID, r, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEventUseCase_ImportEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportEvents'
+type MockEventUseCase_ImportEvents_Call struct {
+	*mock.Call
+}
+
+// ImportEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - r io.Reader
+//   - opts domain.ImportOptions
+func (_e *MockEventUseCase_Expecter) ImportEvents(ctx interface{}, userID interface{}, r interface{}, opts interface{}) *MockEventUseCase_ImportEvents_Call {
+	return &MockEventUseCase_ImportEvents_Call{Call: _e.mock.On("ImportEvents", ctx, userID, r, opts)}
+}
+
+func (_c *MockEventUseCase_ImportEvents_Call) Run(run func(ctx context.Context, userID int, r io.Reader, opts domain.ImportOptions)) *MockEventUseCase_ImportEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 io.Reader
+		if args[2] != nil {
+			arg2 = args[2].(io.Reader)
+		}
+		var arg3 domain.ImportOptions
+		if args[3] != nil {
+			arg3 = args[3].(domain.ImportOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventUseCase_ImportEvents_Call) Return(importReport domain.ImportReport, err error) *MockEventUseCase_ImportEvents_Call {
+	_c.Call.Return(importReport, err)
+	return _c
+}
+
+func (_c *MockEventUseCase_ImportEvents_Call) RunAndReturn(run func(ctx context.Context, userID int, r io.Reader, opts domain.ImportOptions) (domain.ImportReport, error)) *MockEventUseCase_ImportEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAttachments provides a mock function for the type MockEventUseCase
+func (_mock *MockEventUseCase) ListAttachments(ctx context.Context, eventID string, userID int) ([]domain.Attachment, error) {
+	ret := _mock.Called(ctx, eventID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAttachments")
+	}
+
+	var r0 []domain.Attachment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) ([]domain.Attachment, error)); ok {
+		return returnFunc(ctx, eventID, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) []domain.Attachment); ok {
+		r0 = returnFunc(ctx, eventID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Attachment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, eventID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEventUseCase_ListAttachments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAttachments'
+type MockEventUseCase_ListAttachments_Call struct {
+	*mock.Call
+}
+
+// ListAttachments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventID string
+//   - userID int
+func (_e *MockEventUseCase_Expecter) ListAttachments(ctx interface{}, eventID interface{}, userID interface{}) *MockEventUseCase_ListAttachments_Call {
+	return &MockEventUseCase_ListAttachments_Call{Call: _e.mock.On("ListAttachments", ctx, eventID, userID)}
+}
+
+func (_c *MockEventUseCase_ListAttachments_Call) Run(run func(ctx context.Context, eventID string, userID int)) *MockEventUseCase_ListAttachments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventUseCase_ListAttachments_Call) Return(attachments []domain.Attachment, err error) *MockEventUseCase_ListAttachments_Call {
+	_c.Call.Return(attachments, err)
+	return _c
+}
+
+func (_c *MockEventUseCase_ListAttachments_Call) RunAndReturn(run func(ctx context.Context, eventID string, userID int) ([]domain.Attachment, error)) *MockEventUseCase_ListAttachments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTrash provides a mock function for the type MockEventUseCase
+func (_mock *MockEventUseCase) ListTrash(ctx context.Context, userID int) ([]domain.DeletedEvent, error) {
+	ret := _mock.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateEvent")
+		panic("no return value specified for ListTrash")
 	}
 
-	var r0 string
+	var r0 []domain.DeletedEvent
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(int, string, string) (string, error)); ok {
-		return returnFunc(userID, dateStr, title)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]domain.DeletedEvent, error)); ok {
+		return returnFunc(ctx, userID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(int, string, string) string); ok {
-		r0 = returnFunc(userID, dateStr, title)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []domain.DeletedEvent); ok {
+		r0 = returnFunc(ctx, userID)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.DeletedEvent)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(int, string, string) error); ok {
-		r1 = returnFunc(userID, dateStr, title)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockEventUseCase_CreateEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEvent'
-type MockEventUseCase_CreateEvent_Call struct {
+// MockEventUseCase_ListTrash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTrash'
+type MockEventUseCase_ListTrash_Call struct {
 	*mock.Call
 }
 
-// CreateEvent is a helper method to define mock.On call
+// ListTrash is a helper method to define mock.On call
+//   - ctx context.Context
 //   - userID int
-//   - dateStr string
-//   - title string
-func (_e *MockEventUseCase_Expecter) CreateEvent(userID interface{}, dateStr interface{}, title interface{}) *MockEventUseCase_CreateEvent_Call {
-	return &MockEventUseCase_CreateEvent_Call{Call: _e.mock.On("CreateEvent", userID, dateStr, title)}
+func (_e *MockEventUseCase_Expecter) ListTrash(ctx interface{}, userID interface{}) *MockEventUseCase_ListTrash_Call {
+	return &MockEventUseCase_ListTrash_Call{Call: _e.mock.On("ListTrash", ctx, userID)}
 }
 
-func (_c *MockEventUseCase_CreateEvent_Call) Run(run func(userID int, dateStr string, title string)) *MockEventUseCase_CreateEvent_Call {
+func (_c *MockEventUseCase_ListTrash_Call) Run(run func(ctx context.Context, userID int)) *MockEventUseCase_ListTrash_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 int
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(int)
+			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 int
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg1 = args[1].(int)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockEventUseCase_CreateEvent_Call) Return(s string, err error) *MockEventUseCase_CreateEvent_Call {
-	_c.Call.Return(s, err)
+func (_c *MockEventUseCase_ListTrash_Call) Return(deletedEvents []domain.DeletedEvent, err error) *MockEventUseCase_ListTrash_Call {
+	_c.Call.Return(deletedEvents, err)
 	return _c
 }
 
-func (_c *MockEventUseCase_CreateEvent_Call) RunAndReturn(run func(userID int, dateStr string, title string) (string, error)) *MockEventUseCase_CreateEvent_Call {
+func (_c *MockEventUseCase_ListTrash_Call) RunAndReturn(run func(ctx context.Context, userID int) ([]domain.DeletedEvent, error)) *MockEventUseCase_ListTrash_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteEvent provides a mock function for the type MockEventUseCase
-func (_mock *MockEventUseCase) DeleteEvent(id string) error {
-	ret := _mock.Called(id)
+// LoadBackup provides a mock function for the type MockEventUseCase
+func (_mock *MockEventUseCase) LoadBackup(ctx context.Context, r io.Reader, mode string) (domain.BackupSummary, error) {
+	ret := _mock.Called(ctx, r, mode)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteEvent")
+		panic("no return value specified for LoadBackup")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
-		r0 = returnFunc(id)
+	var r0 domain.BackupSummary
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, io.Reader, string) (domain.BackupSummary, error)); ok {
+		return returnFunc(ctx, r, mode)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, io.Reader, string) domain.BackupSummary); ok {
+		r0 = returnFunc(ctx, r, mode)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(domain.BackupSummary)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, io.Reader, string) error); ok {
+		r1 = returnFunc(ctx, r, mode)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockEventUseCase_DeleteEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteEvent'
-type MockEventUseCase_DeleteEvent_Call struct {
+// MockEventUseCase_LoadBackup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadBackup'
+type MockEventUseCase_LoadBackup_Call struct {
 	*mock.Call
 }
 
-// DeleteEvent is a helper method to define mock.On call
-//   - id string
-func (_e *MockEventUseCase_Expecter) DeleteEvent(id interface{}) *MockEventUseCase_DeleteEvent_Call {
-	return &MockEventUseCase_DeleteEvent_Call{Call: _e.mock.On("DeleteEvent", id)}
+// LoadBackup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r io.Reader
+//   - mode string
+func (_e *MockEventUseCase_Expecter) LoadBackup(ctx interface{}, r interface{}, mode interface{}) *MockEventUseCase_LoadBackup_Call {
+	return &MockEventUseCase_LoadBackup_Call{Call: _e.mock.On("LoadBackup", ctx, r, mode)}
 }
 
-func (_c *MockEventUseCase_DeleteEvent_Call) Run(run func(id string)) *MockEventUseCase_DeleteEvent_Call {
+func (_c *MockEventUseCase_LoadBackup_Call) Run(run func(ctx context.Context, r io.Reader, mode string)) *MockEventUseCase_LoadBackup_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 io.Reader
+		if args[1] != nil {
+			arg1 = args[1].(io.Reader)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockEventUseCase_DeleteEvent_Call) Return(err error) *MockEventUseCase_DeleteEvent_Call {
-	_c.Call.Return(err)
+func (_c *MockEventUseCase_LoadBackup_Call) Return(backupSummary domain.BackupSummary, err error) *MockEventUseCase_LoadBackup_Call {
+	_c.Call.Return(backupSummary, err)
 	return _c
 }
 
-func (_c *MockEventUseCase_DeleteEvent_Call) RunAndReturn(run func(id string) error) *MockEventUseCase_DeleteEvent_Call {
+func (_c *MockEventUseCase_LoadBackup_Call) RunAndReturn(run func(ctx context.Context, r io.Reader, mode string) (domain.BackupSummary, error)) *MockEventUseCase_LoadBackup_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetEventsForDay provides a mock function for the type MockEventUseCase
-func (_mock *MockEventUseCase) GetEventsForDay(userID int, dateStr string) ([]domain.Event, error) {
-	ret := _mock.Called(userID, dateStr)
+// OpenAttachment provides a mock function for the type MockEventUseCase
+func (_mock *MockEventUseCase) OpenAttachment(ctx context.Context, eventID string, attachmentID string, userID int) (domain.Attachment, io.ReadCloser, error) {
+	ret := _mock.Called(ctx, eventID, attachmentID, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetEventsForDay")
+		panic("no return value specified for OpenAttachment")
 	}
 
-	var r0 []domain.Event
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(int, string) ([]domain.Event, error)); ok {
-		return returnFunc(userID, dateStr)
+	var r0 domain.Attachment
+	var r1 io.ReadCloser
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int) (domain.Attachment, io.ReadCloser, error)); ok {
+		return returnFunc(ctx, eventID, attachmentID, userID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(int, string) []domain.Event); ok {
-		r0 = returnFunc(userID, dateStr)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int) domain.Attachment); ok {
+		r0 = returnFunc(ctx, eventID, attachmentID, userID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.Event)
+		r0 = ret.Get(0).(domain.Attachment)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, int) io.ReadCloser); ok {
+		r1 = returnFunc(ctx, eventID, attachmentID, userID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.ReadCloser)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(int, string) error); ok {
-		r1 = returnFunc(userID, dateStr)
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, int) error); ok {
+		r2 = returnFunc(ctx, eventID, attachmentID, userID)
 	} else {
-		r1 = ret.Error(1)
+		r2 = ret.Error(2)
 	}
-	return r0, r1
+	return r0, r1, r2
 }
 
-// MockEventUseCase_GetEventsForDay_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventsForDay'
-type MockEventUseCase_GetEventsForDay_Call struct {
+// MockEventUseCase_OpenAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenAttachment'
+type MockEventUseCase_OpenAttachment_Call struct {
 	*mock.Call
 }
 
-// GetEventsForDay is a helper method to define mock.On call
+// OpenAttachment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventID string
+//   - attachmentID string
 //   - userID int
-//   - dateStr string
-func (_e *MockEventUseCase_Expecter) GetEventsForDay(userID interface{}, dateStr interface{}) *MockEventUseCase_GetEventsForDay_Call {
-	return &MockEventUseCase_GetEventsForDay_Call{Call: _e.mock.On("GetEventsForDay", userID, dateStr)}
+func (_e *MockEventUseCase_Expecter) OpenAttachment(ctx interface{}, eventID interface{}, attachmentID interface{}, userID interface{}) *MockEventUseCase_OpenAttachment_Call {
+	return &MockEventUseCase_OpenAttachment_Call{Call: _e.mock.On("OpenAttachment", ctx, eventID, attachmentID, userID)}
 }
 
-func (_c *MockEventUseCase_GetEventsForDay_Call) Run(run func(userID int, dateStr string)) *MockEventUseCase_GetEventsForDay_Call {
+func (_c *MockEventUseCase_OpenAttachment_Call) Run(run func(ctx context.Context, eventID string, attachmentID string, userID int)) *MockEventUseCase_OpenAttachment_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 int
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(int)
+			arg0 = args[0].(context.Context)
 		}
 		var arg1 string
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockEventUseCase_GetEventsForDay_Call) Return(events []domain.Event, err error) *MockEventUseCase_GetEventsForDay_Call {
-	_c.Call.Return(events, err)
+func (_c *MockEventUseCase_OpenAttachment_Call) Return(attachment domain.Attachment, readCloser io.ReadCloser, err error) *MockEventUseCase_OpenAttachment_Call {
+	_c.Call.Return(attachment, readCloser, err)
 	return _c
 }
 
-func (_c *MockEventUseCase_GetEventsForDay_Call) RunAndReturn(run func(userID int, dateStr string) ([]domain.Event, error)) *MockEventUseCase_GetEventsForDay_Call {
+func (_c *MockEventUseCase_OpenAttachment_Call) RunAndReturn(run func(ctx context.Context, eventID string, attachmentID string, userID int) (domain.Attachment, io.ReadCloser, error)) *MockEventUseCase_OpenAttachment_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetEventsForMonth provides a mock function for the type MockEventUseCase
-func (_mock *MockEventUseCase) GetEventsForMonth(userID int, dateStr string) ([]domain.Event, error) {
-	ret := _mock.Called(userID, dateStr)
+// ResolveDate provides a mock function for the type MockEventUseCase
+func (_mock *MockEventUseCase) ResolveDate(ctx context.Context, userID int, dateStr string, opts domain.PeriodOptions) (time.Time, error) {
+	ret := _mock.Called(ctx, userID, dateStr, opts)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetEventsForMonth")
+		panic("no return value specified for ResolveDate")
 	}
 
-	var r0 []domain.Event
+	var r0 time.Time
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(int, string) ([]domain.Event, error)); ok {
-		return returnFunc(userID, dateStr)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, domain.PeriodOptions) (time.Time, error)); ok {
+		return returnFunc(ctx, userID, dateStr, opts)
 	}
-	if returnFunc, ok := ret.Get(0).(func(int, string) []domain.Event); ok {
-		r0 = returnFunc(userID, dateStr)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string, domain.PeriodOptions) time.Time); ok {
+		r0 = returnFunc(ctx, userID, dateStr, opts)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.Event)
-		}
+		r0 = ret.Get(0).(time.Time)
 	}
-	if returnFunc, ok := ret.Get(1).(func(int, string) error); ok {
-		r1 = returnFunc(userID, dateStr)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, string, domain.PeriodOptions) error); ok {
+		r1 = returnFunc(ctx, userID, dateStr, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockEventUseCase_GetEventsForMonth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventsForMonth'
-type MockEventUseCase_GetEventsForMonth_Call struct {
+// MockEventUseCase_ResolveDate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveDate'
+type MockEventUseCase_ResolveDate_Call struct {
 	*mock.Call
 }
 
-// GetEventsForMonth is a helper method to define mock.On call
+// ResolveDate is a helper method to define mock.On call
+//   - ctx context.Context
 //   - userID int
 //   - dateStr string
-func (_e *MockEventUseCase_Expecter) GetEventsForMonth(userID interface{}, dateStr interface{}) *MockEventUseCase_GetEventsForMonth_Call {
-	return &MockEventUseCase_GetEventsForMonth_Call{Call: _e.mock.On("GetEventsForMonth", userID, dateStr)}
+//   - opts domain.PeriodOptions
+func (_e *MockEventUseCase_Expecter) ResolveDate(ctx interface{}, userID interface{}, dateStr interface{}, opts interface{}) *MockEventUseCase_ResolveDate_Call {
+	return &MockEventUseCase_ResolveDate_Call{Call: _e.mock.On("ResolveDate", ctx, userID, dateStr, opts)}
+}
+
+func (_c *MockEventUseCase_ResolveDate_Call) Run(run func(ctx context.Context, userID int, dateStr string, opts domain.PeriodOptions)) *MockEventUseCase_ResolveDate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 domain.PeriodOptions
+		if args[3] != nil {
+			arg3 = args[3].(domain.PeriodOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventUseCase_ResolveDate_Call) Return(time1 time.Time, err error) *MockEventUseCase_ResolveDate_Call {
+	_c.Call.Return(time1, err)
+	return _c
 }
 
-func (_c *MockEventUseCase_GetEventsForMonth_Call) Run(run func(userID int, dateStr string)) *MockEventUseCase_GetEventsForMonth_Call {
+func (_c *MockEventUseCase_ResolveDate_Call) RunAndReturn(run func(ctx context.Context, userID int, dateStr string, opts domain.PeriodOptions) (time.Time, error)) *MockEventUseCase_ResolveDate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RespondToInvitation provides a mock function for the type MockEventUseCase
+func (_mock *MockEventUseCase) RespondToInvitation(ctx context.Context, id string, userID int, status domain.AttendeeStatus) error {
+	ret := _mock.Called(ctx, id, userID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RespondToInvitation")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, domain.AttendeeStatus) error); ok {
+		r0 = returnFunc(ctx, id, userID, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEventUseCase_RespondToInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RespondToInvitation'
+type MockEventUseCase_RespondToInvitation_Call struct {
+	*mock.Call
+}
+
+// RespondToInvitation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - userID int
+//   - status domain.AttendeeStatus
+func (_e *MockEventUseCase_Expecter) RespondToInvitation(ctx interface{}, id interface{}, userID interface{}, status interface{}) *MockEventUseCase_RespondToInvitation_Call {
+	return &MockEventUseCase_RespondToInvitation_Call{Call: _e.mock.On("RespondToInvitation", ctx, id, userID, status)}
+}
+
+func (_c *MockEventUseCase_RespondToInvitation_Call) Run(run func(ctx context.Context, id string, userID int, status domain.AttendeeStatus)) *MockEventUseCase_RespondToInvitation_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 int
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(int)
+			arg0 = args[0].(context.Context)
 		}
 		var arg1 string
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 domain.AttendeeStatus
+		if args[3] != nil {
+			arg3 = args[3].(domain.AttendeeStatus)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockEventUseCase_GetEventsForMonth_Call) Return(events []domain.Event, err error) *MockEventUseCase_GetEventsForMonth_Call {
-	_c.Call.Return(events, err)
+func (_c *MockEventUseCase_RespondToInvitation_Call) Return(err error) *MockEventUseCase_RespondToInvitation_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockEventUseCase_GetEventsForMonth_Call) RunAndReturn(run func(userID int, dateStr string) ([]domain.Event, error)) *MockEventUseCase_GetEventsForMonth_Call {
+func (_c *MockEventUseCase_RespondToInvitation_Call) RunAndReturn(run func(ctx context.Context, id string, userID int, status domain.AttendeeStatus) error) *MockEventUseCase_RespondToInvitation_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetEventsForWeek provides a mock function for the type MockEventUseCase
-func (_mock *MockEventUseCase) GetEventsForWeek(userID int, dateStr string) ([]domain.Event, error) {
-	ret := _mock.Called(userID, dateStr)
+// RestoreEvent provides a mock function for the type MockEventUseCase
+func (_mock *MockEventUseCase) RestoreEvent(ctx context.Context, id string, userID int) (domain.Event, error) {
+	ret := _mock.Called(ctx, id, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetEventsForWeek")
+		panic("no return value specified for RestoreEvent")
 	}
 
-	var r0 []domain.Event
+	var r0 domain.Event
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(int, string) ([]domain.Event, error)); ok {
-		return returnFunc(userID, dateStr)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) (domain.Event, error)); ok {
+		return returnFunc(ctx, id, userID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(int, string) []domain.Event); ok {
-		r0 = returnFunc(userID, dateStr)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) domain.Event); ok {
+		r0 = returnFunc(ctx, id, userID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.Event)
-		}
+		r0 = ret.Get(0).(domain.Event)
 	}
-	if returnFunc, ok := ret.Get(1).(func(int, string) error); ok {
-		r1 = returnFunc(userID, dateStr)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, id, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockEventUseCase_GetEventsForWeek_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEventsForWeek'
-type MockEventUseCase_GetEventsForWeek_Call struct {
+// MockEventUseCase_RestoreEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreEvent'
+type MockEventUseCase_RestoreEvent_Call struct {
 	*mock.Call
 }
 
-// GetEventsForWeek is a helper method to define mock.On call
+// RestoreEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
 //   - userID int
-//   - dateStr string
-func (_e *MockEventUseCase_Expecter) GetEventsForWeek(userID interface{}, dateStr interface{}) *MockEventUseCase_GetEventsForWeek_Call {
-	return &MockEventUseCase_GetEventsForWeek_Call{Call: _e.mock.On("GetEventsForWeek", userID, dateStr)}
+func (_e *MockEventUseCase_Expecter) RestoreEvent(ctx interface{}, id interface{}, userID interface{}) *MockEventUseCase_RestoreEvent_Call {
+	return &MockEventUseCase_RestoreEvent_Call{Call: _e.mock.On("RestoreEvent", ctx, id, userID)}
 }
 
-func (_c *MockEventUseCase_GetEventsForWeek_Call) Run(run func(userID int, dateStr string)) *MockEventUseCase_GetEventsForWeek_Call {
+func (_c *MockEventUseCase_RestoreEvent_Call) Run(run func(ctx context.Context, id string, userID int)) *MockEventUseCase_RestoreEvent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 int
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(int)
+			arg0 = args[0].(context.Context)
 		}
 		var arg1 string
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockEventUseCase_GetEventsForWeek_Call) Return(events []domain.Event, err error) *MockEventUseCase_GetEventsForWeek_Call {
-	_c.Call.Return(events, err)
+func (_c *MockEventUseCase_RestoreEvent_Call) Return(event domain.Event, err error) *MockEventUseCase_RestoreEvent_Call {
+	_c.Call.Return(event, err)
 	return _c
 }
 
-func (_c *MockEventUseCase_GetEventsForWeek_Call) RunAndReturn(run func(userID int, dateStr string) ([]domain.Event, error)) *MockEventUseCase_GetEventsForWeek_Call {
+func (_c *MockEventUseCase_RestoreEvent_Call) RunAndReturn(run func(ctx context.Context, id string, userID int) (domain.Event, error)) *MockEventUseCase_RestoreEvent_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // UpdateEvent provides a mock function for the type MockEventUseCase
-func (_mock *MockEventUseCase) UpdateEvent(id string, userID int, dateStr string, title string) error {
-	ret := _mock.Called(id, userID, dateStr, title)
+func (_mock *MockEventUseCase) UpdateEvent(ctx context.Context, id string, userID int, dateStr string, title string, attrs domain.EventAttrs) error {
+	ret := _mock.Called(ctx, id, userID, dateStr, title, attrs)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateEvent")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string, int, string, string) error); ok {
-		r0 = returnFunc(id, userID, dateStr, title)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, string, string, domain.EventAttrs) error); ok {
+		r0 = returnFunc(ctx, id, userID, dateStr, title, attrs)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -387,37 +1920,49 @@ type MockEventUseCase_UpdateEvent_Call struct {
 }
 
 // UpdateEvent is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id string
 //   - userID int
 //   - dateStr string
 //   - title string
-func (_e *MockEventUseCase_Expecter) UpdateEvent(id interface{}, userID interface{}, dateStr interface{}, title interface{}) *MockEventUseCase_UpdateEvent_Call {
-	return &MockEventUseCase_UpdateEvent_Call{Call: _e.mock.On("UpdateEvent", id, userID, dateStr, title)}
+//   - attrs domain.EventAttrs
+func (_e *MockEventUseCase_Expecter) UpdateEvent(ctx interface{}, id interface{}, userID interface{}, dateStr interface{}, title interface{}, attrs interface{}) *MockEventUseCase_UpdateEvent_Call {
+	return &MockEventUseCase_UpdateEvent_Call{Call: _e.mock.On("UpdateEvent", ctx, id, userID, dateStr, title, attrs)}
 }
 
-func (_c *MockEventUseCase_UpdateEvent_Call) Run(run func(id string, userID int, dateStr string, title string)) *MockEventUseCase_UpdateEvent_Call {
+func (_c *MockEventUseCase_UpdateEvent_Call) Run(run func(ctx context.Context, id string, userID int, dateStr string, title string, attrs domain.EventAttrs)) *MockEventUseCase_UpdateEvent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
 		}
-		var arg1 int
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(int)
+			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 int
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(int)
 		}
 		var arg3 string
 		if args[3] != nil {
 			arg3 = args[3].(string)
 		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 domain.EventAttrs
+		if args[5] != nil {
+			arg5 = args[5].(domain.EventAttrs)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
+			arg4,
+			arg5,
 		)
 	})
 	return _c
@@ -428,7 +1973,124 @@ func (_c *MockEventUseCase_UpdateEvent_Call) Return(err error) *MockEventUseCase
 	return _c
 }
 
-func (_c *MockEventUseCase_UpdateEvent_Call) RunAndReturn(run func(id string, userID int, dateStr string, title string) error) *MockEventUseCase_UpdateEvent_Call {
+func (_c *MockEventUseCase_UpdateEvent_Call) RunAndReturn(run func(ctx context.Context, id string, userID int, dateStr string, title string, attrs domain.EventAttrs) error) *MockEventUseCase_UpdateEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateUserSettings provides a mock function for the type MockEventUseCase
+func (_mock *MockEventUseCase) UpdateUserSettings(ctx context.Context, s domain.UserSettings) error {
+	ret := _mock.Called(ctx, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserSettings")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserSettings) error); ok {
+		r0 = returnFunc(ctx, s)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEventUseCase_UpdateUserSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserSettings'
+type MockEventUseCase_UpdateUserSettings_Call struct {
+	*mock.Call
+}
+
+// UpdateUserSettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - s domain.UserSettings
+func (_e *MockEventUseCase_Expecter) UpdateUserSettings(ctx interface{}, s interface{}) *MockEventUseCase_UpdateUserSettings_Call {
+	return &MockEventUseCase_UpdateUserSettings_Call{Call: _e.mock.On("UpdateUserSettings", ctx, s)}
+}
+
+func (_c *MockEventUseCase_UpdateUserSettings_Call) Run(run func(ctx context.Context, s domain.UserSettings)) *MockEventUseCase_UpdateUserSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.UserSettings
+		if args[1] != nil {
+			arg1 = args[1].(domain.UserSettings)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventUseCase_UpdateUserSettings_Call) Return(err error) *MockEventUseCase_UpdateUserSettings_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEventUseCase_UpdateUserSettings_Call) RunAndReturn(run func(ctx context.Context, s domain.UserSettings) error) *MockEventUseCase_UpdateUserSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Usage provides a mock function for the type MockEventUseCase
+func (_mock *MockEventUseCase) Usage(ctx context.Context) (domain.UsageReport, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Usage")
+	}
+
+	var r0 domain.UsageReport
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (domain.UsageReport, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) domain.UsageReport); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(domain.UsageReport)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEventUseCase_Usage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Usage'
+type MockEventUseCase_Usage_Call struct {
+	*mock.Call
+}
+
+// Usage is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockEventUseCase_Expecter) Usage(ctx interface{}) *MockEventUseCase_Usage_Call {
+	return &MockEventUseCase_Usage_Call{Call: _e.mock.On("Usage", ctx)}
+}
+
+func (_c *MockEventUseCase_Usage_Call) Run(run func(ctx context.Context)) *MockEventUseCase_Usage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventUseCase_Usage_Call) Return(usageReport domain.UsageReport, err error) *MockEventUseCase_Usage_Call {
+	_c.Call.Return(usageReport, err)
+	return _c
+}
+
+func (_c *MockEventUseCase_Usage_Call) RunAndReturn(run func(ctx context.Context) (domain.UsageReport, error)) *MockEventUseCase_Usage_Call {
 	_c.Call.Return(run)
 	return _c
 }